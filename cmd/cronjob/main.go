@@ -1,13 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
 
 	"genshin-quiz/config"
 	"genshin-quiz/internal/cronjob"
+	"genshin-quiz/internal/metrics"
 
 	"github.com/robfig/cron/v3"
 )
@@ -29,30 +29,64 @@ func main() {
 
 	switch command {
 	case "cronjob:every-five-minutes":
-		runEveryFiveMinutes(cronJob)
+		runEveryFiveMinutes(app, cronJob)
 	case "cronjob:run-once":
-		runOnce(cronJob)
+		err := runOnce(cronJob)
+		// 先关闭资源再退出，os.Exit 不会执行关闭步骤
+		app.Lifecycle.Shutdown()
+		if err != nil {
+			fmt.Printf("Run once failed: %v\n", err)
+			os.Exit(1)
+		}
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		os.Exit(1)
 	}
 }
 
-func runEveryFiveMinutes(cronJob *cronjob.Cronjob) {
+func runEveryFiveMinutes(app *config.App, cronJob *cronjob.Cronjob) {
 	c := cron.New()
 
 	// 每5分钟执行
 	_, err := c.AddFunc("*/5 * * * *", func() {
 		fmt.Println("Starting 5-minute statistics recalibration...")
 
-		if err := cronJob.RecalibrateUserStats(); err != nil {
+		if err := cronJob.Run("recalibrate_user_stats", cronJob.RecalibrateUserStats); err != nil {
 			fmt.Printf("Failed to recalibrate user stats: %v\n", err)
 		}
 
-		if err := cronJob.RecalibrateQuestionStats(); err != nil {
+		if err := cronJob.Run("recalibrate_question_stats", cronJob.RecalibrateQuestionStats); err != nil {
 			fmt.Printf("Failed to recalibrate question stats: %v\n", err)
 		}
 
+		if err := cronJob.Run("recalibrate_exam_stats", cronJob.RecalibrateExamStats); err != nil {
+			fmt.Printf("Failed to recalibrate exam stats: %v\n", err)
+		}
+
+		if err := cronJob.Run("recalculate_trending_scores", cronJob.RecalculateTrendingScores); err != nil {
+			fmt.Printf("Failed to recalculate trending scores: %v\n", err)
+		}
+
+		if err := cronJob.Run("publish_scheduled_questions", cronJob.PublishScheduledQuestions); err != nil {
+			fmt.Printf("Failed to publish scheduled questions: %v\n", err)
+		}
+
+		if err := cronJob.Run("lift_expired_suspensions", cronJob.LiftExpiredSuspensions); err != nil {
+			fmt.Printf("Failed to lift expired suspensions: %v\n", err)
+		}
+
+		if err := cronJob.Run("process_account_deletions", cronJob.ProcessAccountDeletions); err != nil {
+			fmt.Printf("Failed to process account deletions: %v\n", err)
+		}
+
+		if err := cronJob.Run("build_data_exports", cronJob.BuildDataExports); err != nil {
+			fmt.Printf("Failed to build data exports: %v\n", err)
+		}
+
+		if err := cronJob.Run("purge_expired_sessions", cronJob.PurgeExpiredSessions); err != nil {
+			fmt.Printf("Failed to purge expired sessions: %v\n", err)
+		}
+
 		fmt.Println("5-minute statistics recalibration completed.")
 	})
 
@@ -62,35 +96,70 @@ func runEveryFiveMinutes(cronJob *cronjob.Cronjob) {
 	}
 
 	fmt.Println("5-minute cron job started. Will run every 5 minutes.")
-	startCronAndWait(c)
+	metrics.Setup(app)
+	startCronAndWait(app, c)
 }
 
-func runOnce(cronJob *cronjob.Cronjob) {
+func runOnce(cronJob *cronjob.Cronjob) error {
 	fmt.Println("Running statistics recalibration once...")
 
-	if err := cronJob.RecalibrateUserStats(); err != nil {
-		fmt.Printf("Failed to recalibrate user stats: %v\n", err)
-		os.Exit(1)
+	if err := cronJob.Run("recalibrate_user_stats", cronJob.RecalibrateUserStats); err != nil {
+		return fmt.Errorf("failed to recalibrate user stats: %w", err)
 	}
 
-	if err := cronJob.RecalibrateQuestionStats(); err != nil {
-		fmt.Printf("Failed to recalibrate question stats: %v\n", err)
-		os.Exit(1)
+	if err := cronJob.Run("recalibrate_question_stats", cronJob.RecalibrateQuestionStats); err != nil {
+		return fmt.Errorf("failed to recalibrate question stats: %w", err)
+	}
+
+	if err := cronJob.Run("recalibrate_exam_stats", cronJob.RecalibrateExamStats); err != nil {
+		return fmt.Errorf("failed to recalibrate exam stats: %w", err)
+	}
+
+	if err := cronJob.Run("recalculate_trending_scores", cronJob.RecalculateTrendingScores); err != nil {
+		return fmt.Errorf("failed to recalculate trending scores: %w", err)
+	}
+
+	if err := cronJob.Run("publish_scheduled_questions", cronJob.PublishScheduledQuestions); err != nil {
+		return fmt.Errorf("failed to publish scheduled questions: %w", err)
+	}
+
+	if err := cronJob.Run("lift_expired_suspensions", cronJob.LiftExpiredSuspensions); err != nil {
+		return fmt.Errorf("failed to lift expired suspensions: %w", err)
+	}
+
+	if err := cronJob.Run("process_account_deletions", cronJob.ProcessAccountDeletions); err != nil {
+		return fmt.Errorf("failed to process account deletions: %w", err)
+	}
+
+	if err := cronJob.Run("build_data_exports", cronJob.BuildDataExports); err != nil {
+		return fmt.Errorf("failed to build data exports: %w", err)
+	}
+
+	if err := cronJob.Run("purge_expired_sessions", cronJob.PurgeExpiredSessions); err != nil {
+		return fmt.Errorf("failed to purge expired sessions: %w", err)
 	}
 
 	fmt.Println("Statistics recalibration completed.")
+	return nil
 }
 
-func startCronAndWait(c *cron.Cron) {
+// startCronAndWait 收到中断信号后不再调度新任务，正在执行的任务在退出期限内完成，
+// 超时则通过 Lifecycle.Context 取消，之后再关闭数据库等资源.
+func startCronAndWait(app *config.App, c *cron.Cron) {
+	app.Lifecycle.OnShutdown("cron", func(ctx context.Context) error {
+		select {
+		case <-c.Stop().Done():
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	quit := app.Lifecycle.Signals()
 	c.Start()
 
-	// 等待中断信号
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
 	fmt.Println("Cron job is running. Press Ctrl+C to stop.")
 	<-quit
 
 	fmt.Println("Shutting down cron job...")
-	c.Stop()
+	app.Lifecycle.Shutdown()
 }
@@ -3,16 +3,14 @@ package main
 import (
 	"log"
 	"os"
-	"time"
 
-	"github.com/getsentry/sentry-go"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 
 	"genshin-quiz/config"
 	"genshin-quiz/internal/enum"
+	"genshin-quiz/internal/metrics"
 	"genshin-quiz/internal/webserver"
-	"genshin-quiz/logger"
 )
 
 func main() {
@@ -39,14 +37,10 @@ func main() {
 
 	// Initialize configuration
 	app := config.NewApp()
-
-	defer sentry.Flush(2 * time.Second)
-
-	// Set up defer immediately after logger is created
-	defer logger.Sync()
+	metrics.Setup(app)
 
 	// Initialize server
 	server := webserver.NewServer(app)
-	// Start server in a goroutine
+	// 阻塞直到收到退出信号，Sentry、日志和数据库连接由 app.Lifecycle 关闭
 	server.Start()
 }
@@ -3,8 +3,10 @@ package config
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"genshin-quiz/internal/enum"
+	"genshin-quiz/internal/ratelimit"
 	"genshin-quiz/logger"
 	"log"
 	"os"
@@ -20,6 +22,8 @@ import (
 	"go.uber.org/zap"
 )
 
+const redisHealthCheckInterval = 30 * time.Second
+
 type App struct {
 	DB      *sql.DB
 	Redis   *redis.Client
@@ -27,22 +31,42 @@ type App struct {
 	Logger  *zap.Logger
 	Storage *azblob.SharedKeyCredential
 	Resend  *resend.Client
+	// 限流计数，配置了 Redis 时多实例共享，否则为进程内计数
+	Limiter ratelimit.Limiter
+	// 优雅退出，关闭顺序见 registerClosers
+	Lifecycle *Lifecycle
 
 	Config   AppConfig
 	Database DatabaseConfig
 	// Worker   WorkerConfig
 	Azure  AzureConfig
 	Server ServerConfig
+	OAuth  OAuthConfig
 }
 
+// AppConfig 启动时会整体写入日志，密钥和带凭据的连接串标记为 json:"-" 不输出.
 type AppConfig struct {
-	DatabaseURL string
-	JWTSecret   string
+	DatabaseURL string `json:"-"`
+	JWTSecret   string `json:"-"`
 	Environment enum.Environment
 	Version     string
-	SentryDSN   string
+	SentryDSN   string `json:"-"`
 	Domain      string
-	ResendKey   string
+	ResendKey   string `json:"-"`
+	RedisURL    string `json:"-"`
+	// goose 迁移文件所在目录，就绪检查据此判断迁移是否执行完毕
+	MigrationsDir string
+	// Prometheus 指标：MetricsAddr 为独立的管理端口，未设置时只有配置了 MetricsToken 才在主端口暴露
+	MetricsAddr  string
+	MetricsToken string `json:"-"`
+	// 访问令牌短期有效，刷新令牌所在会话的有效期
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// 注销冷静期及注销后内容的处理方式
+	AccountDeletionGrace time.Duration
+	DeletedContentPolicy enum.DeletedContentPolicy
+	// 管理人员必须开启两步验证，未验证的会话不具备管理权限
+	RequireStaffTwoFactor bool
 }
 
 type DatabaseConfig struct {
@@ -66,11 +90,34 @@ type AzureConfig struct {
 	ContainerName  string
 }
 
+// OAuthConfig 第三方登录配置，未设置 ClientID 的登录方式不可用.
+type OAuthConfig struct {
+	// 授权完成后跳转回前端的地址，需要在第三方平台登记
+	RedirectURL string
+	Google      OAuthProviderConfig
+	GitHub      OAuthProviderConfig
+}
+
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	// OIDC Issuer，用于读取 discovery 文档，本地可指向 mock OIDC 服务
+	Issuer string
+	// 非 OIDC 登录方式的授权、令牌和 API 地址，本地可指向 mock 服务
+	AuthURL  string
+	TokenURL string
+	APIURL   string
+}
+
 type ServerConfig struct {
 	Host         string
 	Port         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	// 每个关闭步骤（等待请求、任务完成等）的最长时间，各步骤合计需小于平台强制结束前的宽限期
+	ShutdownTimeout time.Duration
+	// 标记未就绪后等待负载均衡摘除实例的时间，之后才停止接收请求
+	ShutdownReadinessDelay time.Duration
 }
 
 func getEnv(key, defaultValue string) string {
@@ -94,6 +141,16 @@ func getEnvAsEnv(key string) enum.Environment {
 	}
 }
 
+func getEnvAsDeletedContentPolicy(key string) enum.DeletedContentPolicy {
+	policy := enum.DeletedContentPolicy(os.Getenv(key))
+	switch policy {
+	case enum.DeletedContentKeep, enum.DeletedContentRemove:
+		return policy
+	default:
+		return enum.DeletedContentKeep
+	}
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	valueStr := getEnv(key, "")
 	if value, err := strconv.Atoi(valueStr); err == nil {
@@ -102,6 +159,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue string) time.Duration {
 	valueStr := getEnv(key, defaultValue)
 	if value, err := time.ParseDuration(valueStr); err == nil {
@@ -154,6 +219,71 @@ func (app *App) initializeDatabase() (*sql.DB, error) {
 	return db, nil
 }
 
+func (app *App) initializeRedis() (*redis.Client, error) {
+	if app.Config.RedisURL == "" {
+		app.Logger.Info("Redis URL not configured, skipping Redis initialization")
+		return nil, nil
+	}
+
+	opt, err := redis.ParseURL(app.Config.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	client := redis.NewClient(opt)
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	app.Logger.Info("Redis connection established successfully")
+	return client, nil
+}
+
+// CheckRedis 检查 Redis 连接是否可用，未配置时同样返回错误.
+func (app *App) CheckRedis(ctx context.Context) error {
+	if app.Redis == nil {
+		return errors.New("redis not configured")
+	}
+	if err := app.Redis.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to ping redis: %w", err)
+	}
+	return nil
+}
+
+// watchRedis 定期检查 Redis 连接，状态变化时记录日志.
+// go-redis 会自动重连，这里只负责让故障和恢复在日志中可见.
+func (app *App) watchRedis(interval time.Duration, stop <-chan struct{}) {
+	healthy := true
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		err := app.CheckRedis(ctx)
+		cancel()
+
+		switch {
+		case err != nil && healthy:
+			app.Logger.Error("Redis health check failed", zap.Error(err))
+		case err == nil && !healthy:
+			app.Logger.Info("Redis connection recovered")
+		}
+		healthy = err == nil
+	}
+}
+
 func (app *App) initializeSentry() error {
 	// 只在非 debug 环境（生产环境）且设置了 Sentry DSN 时才初始化
 	// if app.Config.Environment != enum.PROD {
@@ -246,6 +376,40 @@ func (app *App) SendEmail(to, subject, htmlBody string) error {
 	return nil
 }
 
+// registerClosers 注册资源的关闭步骤，日志最后刷新以便记录其他步骤的错误.
+func (app *App) registerClosers() {
+	app.Lifecycle.OnShutdown("logger", func(context.Context) error {
+		logger.Sync()
+		return nil
+	})
+	if app.Config.SentryDSN != "" {
+		app.Lifecycle.OnShutdown("sentry", func(ctx context.Context) error {
+			if !sentry.FlushWithContext(ctx) {
+				return errors.New("sentry flush timed out")
+			}
+			return nil
+		})
+	}
+	app.Lifecycle.OnShutdown("database", func(context.Context) error {
+		return app.DB.Close()
+	})
+	if app.Redis != nil {
+		app.Lifecycle.OnShutdown("redis", func(context.Context) error {
+			return app.Redis.Close()
+		})
+
+		// 健康检查在关闭 Redis 之前停止
+		stop := make(chan struct{})
+		go app.watchRedis(redisHealthCheckInterval, stop)
+		app.Lifecycle.OnShutdown("redis health check", func(context.Context) error {
+			close(stop)
+			return nil
+		})
+	}
+	// 后台任务会用到数据库和 Redis，先于它们结束
+	app.Lifecycle.OnShutdown("background tasks", app.Lifecycle.Wait)
+}
+
 func NewApp() *App {
 	app := &App{
 		Config: AppConfig{
@@ -259,6 +423,18 @@ func NewApp() *App {
 			SentryDSN:   getEnv("SENTRY_DSN", ""),
 			Domain:      getEnv("APP_DOMAIN", "http://localhost:3000"),
 			ResendKey:   getEnv("RESEND_KEY", ""),
+			RedisURL:    getEnv("REDIS_URL", ""),
+
+			MigrationsDir: getEnv("MIGRATIONS_DIR", "migrations"),
+			MetricsAddr:   getEnv("METRICS_ADDR", ""),
+			MetricsToken:  getEnv("METRICS_TOKEN", ""),
+
+			AccessTokenTTL:  getEnvAsDuration("ACCESS_TOKEN_TTL", "15m"),
+			RefreshTokenTTL: getEnvAsDuration("REFRESH_TOKEN_TTL", "720h"),
+
+			AccountDeletionGrace:  getEnvAsDuration("ACCOUNT_DELETION_GRACE", "720h"),
+			DeletedContentPolicy:  getEnvAsDeletedContentPolicy("DELETED_CONTENT_POLICY"),
+			RequireStaffTwoFactor: getEnvAsBool("REQUIRE_STAFF_TWO_FACTOR", false),
 		},
 
 		Database: DatabaseConfig{
@@ -283,6 +459,25 @@ func NewApp() *App {
 			Port:         getEnv("PORT", "8080"),
 			ReadTimeout:  getEnvAsDuration("SERVER_READ_TIMEOUT", "30s"),
 			WriteTimeout: getEnvAsDuration("SERVER_WRITE_TIMEOUT", "30s"),
+
+			ShutdownTimeout:        getEnvAsDuration("SHUTDOWN_TIMEOUT", "8s"),
+			ShutdownReadinessDelay: getEnvAsDuration("SHUTDOWN_READINESS_DELAY", "0s"),
+		},
+	}
+
+	app.OAuth = OAuthConfig{
+		RedirectURL: getEnv("OAUTH_REDIRECT_URL", app.Config.Domain+"/auth/callback"),
+		Google: OAuthProviderConfig{
+			ClientID:     getEnv("OAUTH_GOOGLE_CLIENT_ID", ""),
+			ClientSecret: getEnv("OAUTH_GOOGLE_CLIENT_SECRET", ""),
+			Issuer:       getEnv("OAUTH_GOOGLE_ISSUER", "https://accounts.google.com"),
+		},
+		GitHub: OAuthProviderConfig{
+			ClientID:     getEnv("OAUTH_GITHUB_CLIENT_ID", ""),
+			ClientSecret: getEnv("OAUTH_GITHUB_CLIENT_SECRET", ""),
+			AuthURL:      getEnv("OAUTH_GITHUB_AUTH_URL", "https://github.com/login/oauth/authorize"),
+			TokenURL:     getEnv("OAUTH_GITHUB_TOKEN_URL", "https://github.com/login/oauth/access_token"),
+			APIURL:       getEnv("OAUTH_GITHUB_API_URL", "https://api.github.com"),
 		},
 	}
 
@@ -290,6 +485,7 @@ func NewApp() *App {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
 	app.Logger = logger.L
+	app.Lifecycle = newLifecycle(app.Logger, app.Server.ShutdownTimeout, app.Server.ShutdownReadinessDelay)
 
 	app.Logger.Info("Current App Config", zap.Any("config", app.Config))
 
@@ -310,5 +506,17 @@ func NewApp() *App {
 	}
 	app.DB = db
 
+	// redis（计时、缓存等），不可用时相关功能降级
+	rdb, err := app.initializeRedis()
+	if err != nil {
+		app.Logger.Error("Failed to initialize Redis", zap.Error(err))
+	}
+	app.Redis = rdb
+	app.Limiter = ratelimit.New(rdb)
+	if rdb == nil {
+		app.Logger.Warn("Redis unavailable, rate limits are counted per instance in memory")
+	}
+
+	app.registerClosers()
 	return app
 }
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Lifecycle 管理进程的优雅退出.
+// 退出时先标记未就绪，等待负载均衡摘除实例后再按注册的相反顺序执行关闭步骤：
+// 先注册的资源（日志、数据库）最后关闭，后注册的使用方（HTTP 服务、定时任务）先停止.
+// 每个步骤有独立的期限，前面的步骤超时不会挤占后面步骤的时间.
+type Lifecycle struct {
+	logger         *zap.Logger
+	timeout        time.Duration
+	readinessDelay time.Duration
+
+	mu    sync.Mutex
+	hooks []shutdownHook
+	ready atomic.Bool
+	once  sync.Once
+
+	// tasks 跟踪 Go 启动的后台任务，stopping 后不再接收新任务
+	tasks    sync.WaitGroup
+	stopping bool
+
+	// ctx 在关闭步骤超时或全部步骤结束时取消，后台任务据此中止
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newLifecycle(logger *zap.Logger, timeout, readinessDelay time.Duration) *Lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Lifecycle{
+		logger:         logger,
+		timeout:        timeout,
+		readinessDelay: readinessDelay,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// OnShutdown 注册关闭步骤，退出时按注册的相反顺序执行.
+func (l *Lifecycle) OnShutdown(name string, fn func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, shutdownHook{name: name, fn: fn})
+}
+
+// Context 后台任务使用的 ctx，等待任务的关闭步骤超时后取消.
+// 期限内任务可以正常完成，超时则被取消.
+func (l *Lifecycle) Context() context.Context {
+	return l.ctx
+}
+
+// Go 在后台执行任务，任务使用 Context 返回的 ctx，退出时会等待任务完成.
+// 开始等待后提交的任务直接在当前 goroutine 执行.
+func (l *Lifecycle) Go(fn func(ctx context.Context)) {
+	l.mu.Lock()
+	if l.stopping {
+		l.mu.Unlock()
+		fn(l.ctx)
+		return
+	}
+	l.tasks.Add(1)
+	l.mu.Unlock()
+
+	go func() {
+		defer l.tasks.Done()
+		fn(l.ctx)
+	}()
+}
+
+// Wait 等待 Go 启动的后台任务完成，ctx 到期时返回错误.
+func (l *Lifecycle) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.stopping = true
+	l.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		l.tasks.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetReady 服务开始接收请求后标记为就绪.
+func (l *Lifecycle) SetReady() {
+	l.ready.Store(true)
+}
+
+// Ready 开始退出后返回 false，负载均衡据此停止转发新请求.
+func (l *Lifecycle) Ready() bool {
+	return l.ready.Load()
+}
+
+// Signals 返回 SIGINT/SIGTERM 通知，只有调用后才会拦截这两个信号.
+func (l *Lifecycle) Signals() <-chan os.Signal {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	return quit
+}
+
+// Shutdown 标记未就绪，等待摘除后依次执行关闭步骤，每个步骤有独立的期限，多次调用只执行一次.
+func (l *Lifecycle) Shutdown() {
+	l.once.Do(func() {
+		defer l.cancel()
+
+		// 就绪探针失败到负载均衡停止转发之间有延迟，期间仍会收到新请求
+		if l.ready.Swap(false) && l.readinessDelay > 0 {
+			l.logger.Info("Waiting for readiness to propagate", zap.Duration("delay", l.readinessDelay))
+			time.Sleep(l.readinessDelay)
+		}
+		l.logger.Info("Shutting down", zap.Duration("step_timeout", l.timeout))
+
+		l.mu.Lock()
+		hooks := l.hooks
+		l.mu.Unlock()
+
+		for i := len(hooks) - 1; i >= 0; i-- {
+			l.runHook(hooks[i])
+		}
+	})
+}
+
+// runHook 在独立期限内执行关闭步骤，超时后取消后台任务，避免其继续使用随后关闭的资源.
+func (l *Lifecycle) runHook(hook shutdownHook) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	if err := hook.fn(ctx); err != nil {
+		l.logger.Error("Shutdown step failed", zap.String("step", hook.name), zap.Error(err))
+	}
+	if ctx.Err() != nil {
+		l.cancel()
+	}
+}
@@ -0,0 +1,18 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package enum
+
+import "github.com/go-jet/jet/v2/postgres"
+
+var DataExportFormat = &struct {
+	JSON postgres.StringExpression
+	Zip  postgres.StringExpression
+}{
+	JSON: postgres.NewEnumValue("json"),
+	Zip:  postgres.NewEnumValue("zip"),
+}
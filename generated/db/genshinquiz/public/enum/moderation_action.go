@@ -0,0 +1,22 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package enum
+
+import "github.com/go-jet/jet/v2/postgres"
+
+var ModerationAction = &struct {
+	Dismiss   postgres.StringExpression
+	Hide      postgres.StringExpression
+	Suspend   postgres.StringExpression
+	Unsuspend postgres.StringExpression
+}{
+	Dismiss:   postgres.NewEnumValue("dismiss"),
+	Hide:      postgres.NewEnumValue("hide"),
+	Suspend:   postgres.NewEnumValue("suspend"),
+	Unsuspend: postgres.NewEnumValue("unsuspend"),
+}
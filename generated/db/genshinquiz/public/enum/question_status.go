@@ -0,0 +1,24 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package enum
+
+import "github.com/go-jet/jet/v2/postgres"
+
+var QuestionStatus = &struct {
+	Draft         postgres.StringExpression
+	PendingReview postgres.StringExpression
+	Approved      postgres.StringExpression
+	Published     postgres.StringExpression
+	Rejected      postgres.StringExpression
+}{
+	Draft:         postgres.NewEnumValue("draft"),
+	PendingReview: postgres.NewEnumValue("pending_review"),
+	Approved:      postgres.NewEnumValue("approved"),
+	Published:     postgres.NewEnumValue("published"),
+	Rejected:      postgres.NewEnumValue("rejected"),
+}
@@ -0,0 +1,26 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package enum
+
+import "github.com/go-jet/jet/v2/postgres"
+
+var ReportReason = &struct {
+	Spam        postgres.StringExpression
+	Offensive   postgres.StringExpression
+	WrongAnswer postgres.StringExpression
+	Spoiler     postgres.StringExpression
+	Copyright   postgres.StringExpression
+	Other       postgres.StringExpression
+}{
+	Spam:        postgres.NewEnumValue("spam"),
+	Offensive:   postgres.NewEnumValue("offensive"),
+	WrongAnswer: postgres.NewEnumValue("wrong_answer"),
+	Spoiler:     postgres.NewEnumValue("spoiler"),
+	Copyright:   postgres.NewEnumValue("copyright"),
+	Other:       postgres.NewEnumValue("other"),
+}
@@ -0,0 +1,20 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package enum
+
+import "github.com/go-jet/jet/v2/postgres"
+
+var ReportStatus = &struct {
+	Open      postgres.StringExpression
+	Dismissed postgres.StringExpression
+	Resolved  postgres.StringExpression
+}{
+	Open:      postgres.NewEnumValue("open"),
+	Dismissed: postgres.NewEnumValue("dismissed"),
+	Resolved:  postgres.NewEnumValue("resolved"),
+}
@@ -0,0 +1,24 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package enum
+
+import "github.com/go-jet/jet/v2/postgres"
+
+var ReportTargetType = &struct {
+	Question        postgres.StringExpression
+	Poll            postgres.StringExpression
+	QuestionComment postgres.StringExpression
+	PollComment     postgres.StringExpression
+	User            postgres.StringExpression
+}{
+	Question:        postgres.NewEnumValue("question"),
+	Poll:            postgres.NewEnumValue("poll"),
+	QuestionComment: postgres.NewEnumValue("question_comment"),
+	PollComment:     postgres.NewEnumValue("poll_comment"),
+	User:            postgres.NewEnumValue("user"),
+}
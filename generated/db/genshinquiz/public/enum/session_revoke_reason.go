@@ -0,0 +1,22 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package enum
+
+import "github.com/go-jet/jet/v2/postgres"
+
+var SessionRevokeReason = &struct {
+	Logout          postgres.StringExpression
+	Revoked         postgres.StringExpression
+	PasswordChanged postgres.StringExpression
+	TokenReuse      postgres.StringExpression
+}{
+	Logout:          postgres.NewEnumValue("logout"),
+	Revoked:         postgres.NewEnumValue("revoked"),
+	PasswordChanged: postgres.NewEnumValue("password_changed"),
+	TokenReuse:      postgres.NewEnumValue("token_reuse"),
+}
@@ -0,0 +1,49 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import "errors"
+
+type DataExportFormat string
+
+const (
+	DataExportFormat_JSON DataExportFormat = "json"
+	DataExportFormat_Zip  DataExportFormat = "zip"
+)
+
+var DataExportFormatAllValues = []DataExportFormat{
+	DataExportFormat_JSON,
+	DataExportFormat_Zip,
+}
+
+func (e *DataExportFormat) Scan(value interface{}) error {
+	var enumValue string
+	switch val := value.(type) {
+	case string:
+		enumValue = val
+	case []byte:
+		enumValue = string(val)
+	default:
+		return errors.New("jet: Invalid scan value for AllTypesEnum enum. Enum value has to be of type string or []byte")
+	}
+
+	switch enumValue {
+	case "json":
+		*e = DataExportFormat_JSON
+	case "zip":
+		*e = DataExportFormat_Zip
+	default:
+		return errors.New("jet: Invalid scan value '" + enumValue + "' for DataExportFormat enum")
+	}
+
+	return nil
+}
+
+func (e DataExportFormat) String() string {
+	return string(e)
+}
@@ -0,0 +1,53 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import "errors"
+
+type DataExportStatus string
+
+const (
+	DataExportStatus_Pending   DataExportStatus = "pending"
+	DataExportStatus_Completed DataExportStatus = "completed"
+	DataExportStatus_Failed    DataExportStatus = "failed"
+)
+
+var DataExportStatusAllValues = []DataExportStatus{
+	DataExportStatus_Pending,
+	DataExportStatus_Completed,
+	DataExportStatus_Failed,
+}
+
+func (e *DataExportStatus) Scan(value interface{}) error {
+	var enumValue string
+	switch val := value.(type) {
+	case string:
+		enumValue = val
+	case []byte:
+		enumValue = string(val)
+	default:
+		return errors.New("jet: Invalid scan value for AllTypesEnum enum. Enum value has to be of type string or []byte")
+	}
+
+	switch enumValue {
+	case "pending":
+		*e = DataExportStatus_Pending
+	case "completed":
+		*e = DataExportStatus_Completed
+	case "failed":
+		*e = DataExportStatus_Failed
+	default:
+		return errors.New("jet: Invalid scan value '" + enumValue + "' for DataExportStatus enum")
+	}
+
+	return nil
+}
+
+func (e DataExportStatus) String() string {
+	return string(e)
+}
@@ -0,0 +1,57 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import "errors"
+
+type ModerationAction string
+
+const (
+	ModerationAction_Dismiss   ModerationAction = "dismiss"
+	ModerationAction_Hide      ModerationAction = "hide"
+	ModerationAction_Suspend   ModerationAction = "suspend"
+	ModerationAction_Unsuspend ModerationAction = "unsuspend"
+)
+
+var ModerationActionAllValues = []ModerationAction{
+	ModerationAction_Dismiss,
+	ModerationAction_Hide,
+	ModerationAction_Suspend,
+	ModerationAction_Unsuspend,
+}
+
+func (e *ModerationAction) Scan(value interface{}) error {
+	var enumValue string
+	switch val := value.(type) {
+	case string:
+		enumValue = val
+	case []byte:
+		enumValue = string(val)
+	default:
+		return errors.New("jet: Invalid scan value for AllTypesEnum enum. Enum value has to be of type string or []byte")
+	}
+
+	switch enumValue {
+	case "dismiss":
+		*e = ModerationAction_Dismiss
+	case "hide":
+		*e = ModerationAction_Hide
+	case "suspend":
+		*e = ModerationAction_Suspend
+	case "unsuspend":
+		*e = ModerationAction_Unsuspend
+	default:
+		return errors.New("jet: Invalid scan value '" + enumValue + "' for ModerationAction enum")
+	}
+
+	return nil
+}
+
+func (e ModerationAction) String() string {
+	return string(e)
+}
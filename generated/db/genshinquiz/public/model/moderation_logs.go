@@ -0,0 +1,23 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type ModerationLogs struct {
+	ID          int64 `sql:"primary_key"`
+	ModeratorID *int64
+	Action      ModerationAction
+	ReportID    *int64
+	TargetType  ReportTargetType
+	TargetID    int64
+	Note        *string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,23 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type OAuthStates struct {
+	ID           int64 `sql:"primary_key"`
+	StateHash    string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	LinkUserID   *int64
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,19 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type PollCommentReactions struct {
+	CommentID int64  `sql:"primary_key"`
+	UserID    int64  `sql:"primary_key"`
+	Reaction  string `sql:"primary_key"`
+	CreatedAt time.Time
+}
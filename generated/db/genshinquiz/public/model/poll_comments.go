@@ -21,4 +21,8 @@ type PollComments struct {
 	ParentID    *int64
 	Content     string
 	CreatedAt   time.Time
+	RootID      *int64
+	UpdatedAt   time.Time
+	DeletedAt   *time.Time
+	DeletedBy   *int64
 }
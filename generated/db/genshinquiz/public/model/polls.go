@@ -27,4 +27,7 @@ type Polls struct {
 	ParticipantsCount int64
 	TotalVotesCount   int64
 	LikesCount        int64
+	TrendingScore     float64
+	HiddenAt          *time.Time
+	HiddenBy          *int64
 }
@@ -9,13 +9,21 @@ package model
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type QuestionComments struct {
-	ID         int64 `sql:"primary_key"`
-	QuestionID int64
-	UserID     int64
-	Comment    string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID          int64 `sql:"primary_key"`
+	QuestionID  int64
+	UserID      int64
+	Comment     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CommentUUID uuid.UUID
+	ParentID    *int64
+	RootID      *int64
+	IsSpoiler   bool
+	DeletedAt   *time.Time
+	DeletedBy   *int64
 }
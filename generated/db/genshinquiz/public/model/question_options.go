@@ -22,4 +22,5 @@ type QuestionOptions struct {
 	IsAnswer      bool
 	CreatedAt     time.Time
 	SelectedCount int64
+	RemovedAt     *time.Time
 }
@@ -0,0 +1,61 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import "errors"
+
+type QuestionStatus string
+
+const (
+	QuestionStatus_Draft         QuestionStatus = "draft"
+	QuestionStatus_PendingReview QuestionStatus = "pending_review"
+	QuestionStatus_Approved      QuestionStatus = "approved"
+	QuestionStatus_Published     QuestionStatus = "published"
+	QuestionStatus_Rejected      QuestionStatus = "rejected"
+)
+
+var QuestionStatusAllValues = []QuestionStatus{
+	QuestionStatus_Draft,
+	QuestionStatus_PendingReview,
+	QuestionStatus_Approved,
+	QuestionStatus_Published,
+	QuestionStatus_Rejected,
+}
+
+func (e *QuestionStatus) Scan(value interface{}) error {
+	var enumValue string
+	switch val := value.(type) {
+	case string:
+		enumValue = val
+	case []byte:
+		enumValue = string(val)
+	default:
+		return errors.New("jet: Invalid scan value for AllTypesEnum enum. Enum value has to be of type string or []byte")
+	}
+
+	switch enumValue {
+	case "draft":
+		*e = QuestionStatus_Draft
+	case "pending_review":
+		*e = QuestionStatus_PendingReview
+	case "approved":
+		*e = QuestionStatus_Approved
+	case "published":
+		*e = QuestionStatus_Published
+	case "rejected":
+		*e = QuestionStatus_Rejected
+	default:
+		return errors.New("jet: Invalid scan value '" + enumValue + "' for QuestionStatus enum")
+	}
+
+	return nil
+}
+
+func (e QuestionStatus) String() string {
+	return string(e)
+}
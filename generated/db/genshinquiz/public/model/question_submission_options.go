@@ -7,13 +7,7 @@
 
 package model
 
-import (
-	"time"
-)
-
 type QuestionSubmissionOptions struct {
-	ID           int64 `sql:"primary_key"`
-	SubmissionID int64
-	OptionID     int64
-	CreatedAt    time.Time
+	SubmissionID int64 `sql:"primary_key"`
+	OptionID     int64 `sql:"primary_key"`
 }
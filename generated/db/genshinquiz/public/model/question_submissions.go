@@ -22,4 +22,5 @@ type QuestionSubmissions struct {
 	IsPractice     bool
 	TimeTaken      *int32
 	CreatedAt      time.Time
+	RevisionID     *int64
 }
@@ -14,17 +14,26 @@ import (
 )
 
 type Questions struct {
-	ID           int64 `sql:"primary_key"`
-	QuestionUUID uuid.UUID
-	Public       bool
-	QuestionType QuestionType
-	Category     Category
-	Difficulty   Difficulty
-	IsPublished  bool
-	PublishedAt  *time.Time
-	CreatedBy    int64
-	CreatedAt    time.Time
-	SubmitCount  int64
-	CorrectCount int64
-	Likes        int64
+	ID              int64 `sql:"primary_key"`
+	QuestionUUID    uuid.UUID
+	Public          bool
+	QuestionType    QuestionType
+	Category        Category
+	Difficulty      Difficulty
+	IsPublished     bool
+	PublishedAt     *time.Time
+	CreatedBy       int64
+	CreatedAt       time.Time
+	SubmitCount     int64
+	CorrectCount    int64
+	Likes           int64
+	CurrentRevision int32
+	UpdatedAt       *time.Time
+	DeletedAt       *time.Time
+	DeletedBy       *int64
+	Status          QuestionStatus
+	SubmittedAt     *time.Time
+	ReviewedBy      *int64
+	ReviewedAt      *time.Time
+	ReviewReason    *string
 }
@@ -13,11 +13,16 @@ import (
 	"github.com/lib/pq"
 )
 
-type ExamAnswers struct {
+type QuizAnswers struct {
 	ID                int64 `sql:"primary_key"`
 	AttemptID         int64
 	QuestionID        int64
 	SelectedOptionIds *pq.Int64Array
 	TimeTaken         *int32
 	CreatedAt         time.Time
+	IsCorrect         bool
+	Points            int32
+	DisplayOrder      int32
+	OptionOrder       *pq.Int64Array
+	CorrectOptionIds  *pq.Int64Array
 }
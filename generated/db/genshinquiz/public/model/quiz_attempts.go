@@ -13,13 +13,14 @@ import (
 	"github.com/google/uuid"
 )
 
-type ExamAttempts struct {
+type QuizAttempts struct {
 	ID          int64 `sql:"primary_key"`
 	AttemptUUID uuid.UUID
-	ExamID      int64
+	QuizID      int64
 	UserID      int64
-	TimeTaken   int32
+	StartedAt   time.Time
 	TotalScore  int32
 	MaxScore    int32
 	CreatedAt   time.Time
+	ShuffleSeed *int64
 }
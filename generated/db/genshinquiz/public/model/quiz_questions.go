@@ -11,9 +11,9 @@ import (
 	"time"
 )
 
-type ExamQuestions struct {
+type QuizQuestions struct {
 	ID            int64 `sql:"primary_key"`
-	ExamID        int64
+	QuizID        int64
 	QuestionID    int64
 	QuestionOrder int32
 	Points        int32
@@ -10,24 +10,16 @@ package model
 import (
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
-type Exams struct {
-	ID                  int64 `sql:"primary_key"`
-	ExamUUID            uuid.UUID
-	Public              bool
-	Difficulty          Difficulty
-	TimeLimit           *int32
-	AccessPassword      *string
-	CreatedBy           int64
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
+type QuizStats struct {
+	QuizID              int64 `sql:"primary_key"`
 	AttemptsCount       int64
 	TotalCorrectAnswers int64
 	HighestScore        int32
 	ShortestTime        *int32
 	AverageScore        decimal.Decimal
 	PassRate            decimal.Decimal
+	UpdatedAt           time.Time
 }
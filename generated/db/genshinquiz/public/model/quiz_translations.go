@@ -11,9 +11,9 @@ import (
 	"time"
 )
 
-type ExamTranslations struct {
+type QuizTranslations struct {
 	ID          int64 `sql:"primary_key"`
-	ExamID      int64
+	QuizID      int64
 	Language    string
 	Title       string
 	Description *string
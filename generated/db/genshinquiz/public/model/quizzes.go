@@ -0,0 +1,31 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Quizzes struct {
+	ID                int64 `sql:"primary_key"`
+	QuizUUID          uuid.UUID
+	Public            bool
+	Difficulty        Difficulty
+	TimeLimit         *int32
+	AccessCredentials *string
+	CreatedBy         int64
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Categories        pq.StringArray
+	LikesCount        int32
+	Shuffle           bool
+	TrendingScore     float64
+}
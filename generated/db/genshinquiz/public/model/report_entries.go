@@ -0,0 +1,21 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type ReportEntries struct {
+	ID         int64 `sql:"primary_key"`
+	ReportID   int64
+	ReporterID int64
+	Reason     ReportReason
+	Details    *string
+	CreatedAt  time.Time
+}
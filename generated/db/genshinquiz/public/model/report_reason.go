@@ -0,0 +1,65 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import "errors"
+
+type ReportReason string
+
+const (
+	ReportReason_Spam        ReportReason = "spam"
+	ReportReason_Offensive   ReportReason = "offensive"
+	ReportReason_WrongAnswer ReportReason = "wrong_answer"
+	ReportReason_Spoiler     ReportReason = "spoiler"
+	ReportReason_Copyright   ReportReason = "copyright"
+	ReportReason_Other       ReportReason = "other"
+)
+
+var ReportReasonAllValues = []ReportReason{
+	ReportReason_Spam,
+	ReportReason_Offensive,
+	ReportReason_WrongAnswer,
+	ReportReason_Spoiler,
+	ReportReason_Copyright,
+	ReportReason_Other,
+}
+
+func (e *ReportReason) Scan(value interface{}) error {
+	var enumValue string
+	switch val := value.(type) {
+	case string:
+		enumValue = val
+	case []byte:
+		enumValue = string(val)
+	default:
+		return errors.New("jet: Invalid scan value for AllTypesEnum enum. Enum value has to be of type string or []byte")
+	}
+
+	switch enumValue {
+	case "spam":
+		*e = ReportReason_Spam
+	case "offensive":
+		*e = ReportReason_Offensive
+	case "wrong_answer":
+		*e = ReportReason_WrongAnswer
+	case "spoiler":
+		*e = ReportReason_Spoiler
+	case "copyright":
+		*e = ReportReason_Copyright
+	case "other":
+		*e = ReportReason_Other
+	default:
+		return errors.New("jet: Invalid scan value '" + enumValue + "' for ReportReason enum")
+	}
+
+	return nil
+}
+
+func (e ReportReason) String() string {
+	return string(e)
+}
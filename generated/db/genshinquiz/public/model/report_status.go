@@ -0,0 +1,53 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import "errors"
+
+type ReportStatus string
+
+const (
+	ReportStatus_Open      ReportStatus = "open"
+	ReportStatus_Dismissed ReportStatus = "dismissed"
+	ReportStatus_Resolved  ReportStatus = "resolved"
+)
+
+var ReportStatusAllValues = []ReportStatus{
+	ReportStatus_Open,
+	ReportStatus_Dismissed,
+	ReportStatus_Resolved,
+}
+
+func (e *ReportStatus) Scan(value interface{}) error {
+	var enumValue string
+	switch val := value.(type) {
+	case string:
+		enumValue = val
+	case []byte:
+		enumValue = string(val)
+	default:
+		return errors.New("jet: Invalid scan value for AllTypesEnum enum. Enum value has to be of type string or []byte")
+	}
+
+	switch enumValue {
+	case "open":
+		*e = ReportStatus_Open
+	case "dismissed":
+		*e = ReportStatus_Dismissed
+	case "resolved":
+		*e = ReportStatus_Resolved
+	default:
+		return errors.New("jet: Invalid scan value '" + enumValue + "' for ReportStatus enum")
+	}
+
+	return nil
+}
+
+func (e ReportStatus) String() string {
+	return string(e)
+}
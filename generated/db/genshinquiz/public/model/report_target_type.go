@@ -0,0 +1,61 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import "errors"
+
+type ReportTargetType string
+
+const (
+	ReportTargetType_Question        ReportTargetType = "question"
+	ReportTargetType_Poll            ReportTargetType = "poll"
+	ReportTargetType_QuestionComment ReportTargetType = "question_comment"
+	ReportTargetType_PollComment     ReportTargetType = "poll_comment"
+	ReportTargetType_User            ReportTargetType = "user"
+)
+
+var ReportTargetTypeAllValues = []ReportTargetType{
+	ReportTargetType_Question,
+	ReportTargetType_Poll,
+	ReportTargetType_QuestionComment,
+	ReportTargetType_PollComment,
+	ReportTargetType_User,
+}
+
+func (e *ReportTargetType) Scan(value interface{}) error {
+	var enumValue string
+	switch val := value.(type) {
+	case string:
+		enumValue = val
+	case []byte:
+		enumValue = string(val)
+	default:
+		return errors.New("jet: Invalid scan value for AllTypesEnum enum. Enum value has to be of type string or []byte")
+	}
+
+	switch enumValue {
+	case "question":
+		*e = ReportTargetType_Question
+	case "poll":
+		*e = ReportTargetType_Poll
+	case "question_comment":
+		*e = ReportTargetType_QuestionComment
+	case "poll_comment":
+		*e = ReportTargetType_PollComment
+	case "user":
+		*e = ReportTargetType_User
+	default:
+		return errors.New("jet: Invalid scan value '" + enumValue + "' for ReportTargetType enum")
+	}
+
+	return nil
+}
+
+func (e ReportTargetType) String() string {
+	return string(e)
+}
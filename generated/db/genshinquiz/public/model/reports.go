@@ -0,0 +1,29 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Reports struct {
+	ID           int64 `sql:"primary_key"`
+	ReportUUID   uuid.UUID
+	TargetType   ReportTargetType
+	TargetID     int64
+	TargetUUID   uuid.UUID
+	TargetUserID *int64
+	Status       ReportStatus
+	ReportCount  int32
+	ResolvedBy   *int64
+	ResolvedAt   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
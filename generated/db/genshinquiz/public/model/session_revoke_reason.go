@@ -0,0 +1,57 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import "errors"
+
+type SessionRevokeReason string
+
+const (
+	SessionRevokeReason_Logout          SessionRevokeReason = "logout"
+	SessionRevokeReason_Revoked         SessionRevokeReason = "revoked"
+	SessionRevokeReason_PasswordChanged SessionRevokeReason = "password_changed"
+	SessionRevokeReason_TokenReuse      SessionRevokeReason = "token_reuse"
+)
+
+var SessionRevokeReasonAllValues = []SessionRevokeReason{
+	SessionRevokeReason_Logout,
+	SessionRevokeReason_Revoked,
+	SessionRevokeReason_PasswordChanged,
+	SessionRevokeReason_TokenReuse,
+}
+
+func (e *SessionRevokeReason) Scan(value interface{}) error {
+	var enumValue string
+	switch val := value.(type) {
+	case string:
+		enumValue = val
+	case []byte:
+		enumValue = string(val)
+	default:
+		return errors.New("jet: Invalid scan value for AllTypesEnum enum. Enum value has to be of type string or []byte")
+	}
+
+	switch enumValue {
+	case "logout":
+		*e = SessionRevokeReason_Logout
+	case "revoked":
+		*e = SessionRevokeReason_Revoked
+	case "password_changed":
+		*e = SessionRevokeReason_PasswordChanged
+	case "token_reuse":
+		*e = SessionRevokeReason_TokenReuse
+	default:
+		return errors.New("jet: Invalid scan value '" + enumValue + "' for SessionRevokeReason enum")
+	}
+
+	return nil
+}
+
+func (e SessionRevokeReason) String() string {
+	return string(e)
+}
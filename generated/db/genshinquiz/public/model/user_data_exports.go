@@ -0,0 +1,27 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserDataExports struct {
+	ID          int64 `sql:"primary_key"`
+	ExportUUID  uuid.UUID
+	UserID      int64
+	Format      DataExportFormat
+	Status      DataExportStatus
+	Data        *[]byte
+	Error       *string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+}
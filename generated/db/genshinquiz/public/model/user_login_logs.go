@@ -16,7 +16,8 @@ type UserLoginLogs struct {
 	UserID         int64
 	IPAddress      string
 	UserAgent      *string
-	CredentialType int16 // Login provider: 0=password, 1=google, 2=apple, 3=github
+	CredentialType int16 // Login provider: 0=password, 1=google, 2=apple, 3=github, 4=magic_link
 	Status         int16 // Login result: 0=success, 1=failed, 2=blocked
 	LoginAt        time.Time
+	SecondFactor   *int16 // Second factor: NULL=none, 0=totp, 1=recovery_code
 }
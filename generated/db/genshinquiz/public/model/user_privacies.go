@@ -12,11 +12,12 @@ import (
 )
 
 type UserPrivacies struct {
-	UserID             int64 `sql:"primary_key"`
-	EmailVisibility    int16 // Visibility: 0=private, 1=public, 2=friends
-	BirthdayVisibility int16 // Visibility: 0=private, 1=public, 2=friends
-	GenderVisibility   int16 // Visibility: 0=private, 1=public, 2=friends
-	CountryVisibility  int16 // Visibility: 0=private, 1=public, 2=friends
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	UserID                int64 `sql:"primary_key"`
+	EmailVisibility       int16 // Visibility: 0=private, 1=public, 2=friends
+	BirthdayVisibility    int16 // Visibility: 0=private, 1=public, 2=friends
+	GenderVisibility      int16 // Visibility: 0=private, 1=public, 2=friends
+	CountryVisibility     int16 // Visibility: 0=private, 1=public, 2=friends
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	LeaderboardVisibility int16 // Visibility: 0=private, 1=public, 2=friends
 }
@@ -0,0 +1,20 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type UserRefreshTokens struct {
+	ID        int64 `sql:"primary_key"`
+	SessionID int64
+	TokenHash string
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
@@ -0,0 +1,27 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserSessions struct {
+	ID                int64 `sql:"primary_key"`
+	SessionUUID       uuid.UUID
+	UserID            int64
+	LoginLogID        *int64
+	CreatedAt         time.Time
+	LastUsedAt        time.Time
+	ExpiresAt         time.Time
+	RevokedAt         *time.Time
+	RevokeReason      *SessionRevokeReason
+	TwoFactorVerified bool
+}
@@ -0,0 +1,21 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type UserTwoFactor struct {
+	UserID       int64 `sql:"primary_key"`
+	Secret       string
+	EnabledAt    *time.Time
+	LastUsedStep *int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
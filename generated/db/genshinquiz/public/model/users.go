@@ -14,18 +14,22 @@ import (
 )
 
 type Users struct {
-	ID            int64 `sql:"primary_key"`
-	UserUUID      uuid.UUID
-	Email         string
-	Nickname      string
-	AvatarURL     *string
-	Biography     *string
-	Language      string // IETF BCP 47 language tag
-	UserRole      int16  // 0=regular user, 1=admin, 2=moderator
-	EmailVerified bool
-	Status        int16 // 0=active, 1=suspended, 2=deleted
-	DeletedAt     *time.Time
-	CreatedIP     *string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID                  int64 `sql:"primary_key"`
+	UserUUID            uuid.UUID
+	Email               string
+	Nickname            string
+	AvatarURL           *string
+	Biography           *string
+	Language            string // IETF BCP 47 language tag
+	UserRole            int16  // 0=regular user, 1=admin, 2=moderator
+	EmailVerified       bool
+	Status              int16 // 0=active, 1=suspended, 2=deleted
+	DeletedAt           *time.Time
+	CreatedIP           *string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	SuspendedUntil      *time.Time
+	SuspensionReason    *string
+	DeletionRequestedAt *time.Time
+	DeletionScheduledAt *time.Time
 }
@@ -0,0 +1,99 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var ModerationLogs = newModerationLogsTable("public", "moderation_logs", "")
+
+type moderationLogsTable struct {
+	postgres.Table
+
+	// Columns
+	ID          postgres.ColumnInteger
+	ModeratorID postgres.ColumnInteger
+	Action      postgres.ColumnString
+	ReportID    postgres.ColumnInteger
+	TargetType  postgres.ColumnString
+	TargetID    postgres.ColumnInteger
+	Note        postgres.ColumnString
+	CreatedAt   postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type ModerationLogsTable struct {
+	moderationLogsTable
+
+	EXCLUDED moderationLogsTable
+}
+
+// AS creates new ModerationLogsTable with assigned alias
+func (a ModerationLogsTable) AS(alias string) *ModerationLogsTable {
+	return newModerationLogsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new ModerationLogsTable with assigned schema name
+func (a ModerationLogsTable) FromSchema(schemaName string) *ModerationLogsTable {
+	return newModerationLogsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new ModerationLogsTable with assigned table prefix
+func (a ModerationLogsTable) WithPrefix(prefix string) *ModerationLogsTable {
+	return newModerationLogsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new ModerationLogsTable with assigned table suffix
+func (a ModerationLogsTable) WithSuffix(suffix string) *ModerationLogsTable {
+	return newModerationLogsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newModerationLogsTable(schemaName, tableName, alias string) *ModerationLogsTable {
+	return &ModerationLogsTable{
+		moderationLogsTable: newModerationLogsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:            newModerationLogsTableImpl("", "excluded", ""),
+	}
+}
+
+func newModerationLogsTableImpl(schemaName, tableName, alias string) moderationLogsTable {
+	var (
+		IDColumn          = postgres.IntegerColumn("id")
+		ModeratorIDColumn = postgres.IntegerColumn("moderator_id")
+		ActionColumn      = postgres.StringColumn("action")
+		ReportIDColumn    = postgres.IntegerColumn("report_id")
+		TargetTypeColumn  = postgres.StringColumn("target_type")
+		TargetIDColumn    = postgres.IntegerColumn("target_id")
+		NoteColumn        = postgres.StringColumn("note")
+		CreatedAtColumn   = postgres.TimestampzColumn("created_at")
+		allColumns        = postgres.ColumnList{IDColumn, ModeratorIDColumn, ActionColumn, ReportIDColumn, TargetTypeColumn, TargetIDColumn, NoteColumn, CreatedAtColumn}
+		mutableColumns    = postgres.ColumnList{ModeratorIDColumn, ActionColumn, ReportIDColumn, TargetTypeColumn, TargetIDColumn, NoteColumn, CreatedAtColumn}
+		defaultColumns    = postgres.ColumnList{IDColumn, CreatedAtColumn}
+	)
+
+	return moderationLogsTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:          IDColumn,
+		ModeratorID: ModeratorIDColumn,
+		Action:      ActionColumn,
+		ReportID:    ReportIDColumn,
+		TargetType:  TargetTypeColumn,
+		TargetID:    TargetIDColumn,
+		Note:        NoteColumn,
+		CreatedAt:   CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
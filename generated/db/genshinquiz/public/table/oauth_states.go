@@ -0,0 +1,99 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var OAuthStates = newOAuthStatesTable("public", "oauth_states", "")
+
+type oAuthStatesTable struct {
+	postgres.Table
+
+	// Columns
+	ID           postgres.ColumnInteger
+	StateHash    postgres.ColumnString
+	Provider     postgres.ColumnString
+	CodeVerifier postgres.ColumnString
+	Nonce        postgres.ColumnString
+	LinkUserID   postgres.ColumnInteger
+	CreatedAt    postgres.ColumnTimestampz
+	ExpiresAt    postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type OAuthStatesTable struct {
+	oAuthStatesTable
+
+	EXCLUDED oAuthStatesTable
+}
+
+// AS creates new OAuthStatesTable with assigned alias
+func (a OAuthStatesTable) AS(alias string) *OAuthStatesTable {
+	return newOAuthStatesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new OAuthStatesTable with assigned schema name
+func (a OAuthStatesTable) FromSchema(schemaName string) *OAuthStatesTable {
+	return newOAuthStatesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new OAuthStatesTable with assigned table prefix
+func (a OAuthStatesTable) WithPrefix(prefix string) *OAuthStatesTable {
+	return newOAuthStatesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new OAuthStatesTable with assigned table suffix
+func (a OAuthStatesTable) WithSuffix(suffix string) *OAuthStatesTable {
+	return newOAuthStatesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newOAuthStatesTable(schemaName, tableName, alias string) *OAuthStatesTable {
+	return &OAuthStatesTable{
+		oAuthStatesTable: newOAuthStatesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:         newOAuthStatesTableImpl("", "excluded", ""),
+	}
+}
+
+func newOAuthStatesTableImpl(schemaName, tableName, alias string) oAuthStatesTable {
+	var (
+		IDColumn           = postgres.IntegerColumn("id")
+		StateHashColumn    = postgres.StringColumn("state_hash")
+		ProviderColumn     = postgres.StringColumn("provider")
+		CodeVerifierColumn = postgres.StringColumn("code_verifier")
+		NonceColumn        = postgres.StringColumn("nonce")
+		LinkUserIDColumn   = postgres.IntegerColumn("link_user_id")
+		CreatedAtColumn    = postgres.TimestampzColumn("created_at")
+		ExpiresAtColumn    = postgres.TimestampzColumn("expires_at")
+		allColumns         = postgres.ColumnList{IDColumn, StateHashColumn, ProviderColumn, CodeVerifierColumn, NonceColumn, LinkUserIDColumn, CreatedAtColumn, ExpiresAtColumn}
+		mutableColumns     = postgres.ColumnList{StateHashColumn, ProviderColumn, CodeVerifierColumn, NonceColumn, LinkUserIDColumn, CreatedAtColumn, ExpiresAtColumn}
+		defaultColumns     = postgres.ColumnList{IDColumn, CreatedAtColumn}
+	)
+
+	return oAuthStatesTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:           IDColumn,
+		StateHash:    StateHashColumn,
+		Provider:     ProviderColumn,
+		CodeVerifier: CodeVerifierColumn,
+		Nonce:        NonceColumn,
+		LinkUserID:   LinkUserIDColumn,
+		CreatedAt:    CreatedAtColumn,
+		ExpiresAt:    ExpiresAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
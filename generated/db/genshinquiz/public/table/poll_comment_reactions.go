@@ -0,0 +1,87 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var PollCommentReactions = newPollCommentReactionsTable("public", "poll_comment_reactions", "")
+
+type pollCommentReactionsTable struct {
+	postgres.Table
+
+	// Columns
+	CommentID postgres.ColumnInteger
+	UserID    postgres.ColumnInteger
+	Reaction  postgres.ColumnString
+	CreatedAt postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type PollCommentReactionsTable struct {
+	pollCommentReactionsTable
+
+	EXCLUDED pollCommentReactionsTable
+}
+
+// AS creates new PollCommentReactionsTable with assigned alias
+func (a PollCommentReactionsTable) AS(alias string) *PollCommentReactionsTable {
+	return newPollCommentReactionsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new PollCommentReactionsTable with assigned schema name
+func (a PollCommentReactionsTable) FromSchema(schemaName string) *PollCommentReactionsTable {
+	return newPollCommentReactionsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new PollCommentReactionsTable with assigned table prefix
+func (a PollCommentReactionsTable) WithPrefix(prefix string) *PollCommentReactionsTable {
+	return newPollCommentReactionsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new PollCommentReactionsTable with assigned table suffix
+func (a PollCommentReactionsTable) WithSuffix(suffix string) *PollCommentReactionsTable {
+	return newPollCommentReactionsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newPollCommentReactionsTable(schemaName, tableName, alias string) *PollCommentReactionsTable {
+	return &PollCommentReactionsTable{
+		pollCommentReactionsTable: newPollCommentReactionsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:                  newPollCommentReactionsTableImpl("", "excluded", ""),
+	}
+}
+
+func newPollCommentReactionsTableImpl(schemaName, tableName, alias string) pollCommentReactionsTable {
+	var (
+		CommentIDColumn = postgres.IntegerColumn("comment_id")
+		UserIDColumn    = postgres.IntegerColumn("user_id")
+		ReactionColumn  = postgres.StringColumn("reaction")
+		CreatedAtColumn = postgres.TimestampzColumn("created_at")
+		allColumns      = postgres.ColumnList{CommentIDColumn, UserIDColumn, ReactionColumn, CreatedAtColumn}
+		mutableColumns  = postgres.ColumnList{CreatedAtColumn}
+		defaultColumns  = postgres.ColumnList{CreatedAtColumn}
+	)
+
+	return pollCommentReactionsTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		CommentID: CommentIDColumn,
+		UserID:    UserIDColumn,
+		Reaction:  ReactionColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
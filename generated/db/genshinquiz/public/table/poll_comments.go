@@ -24,6 +24,10 @@ type pollCommentsTable struct {
 	ParentID    postgres.ColumnInteger
 	Content     postgres.ColumnString
 	CreatedAt   postgres.ColumnTimestampz
+	RootID      postgres.ColumnInteger
+	UpdatedAt   postgres.ColumnTimestampz
+	DeletedAt   postgres.ColumnTimestampz
+	DeletedBy   postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -72,9 +76,13 @@ func newPollCommentsTableImpl(schemaName, tableName, alias string) pollCommentsT
 		ParentIDColumn    = postgres.IntegerColumn("parent_id")
 		ContentColumn     = postgres.StringColumn("content")
 		CreatedAtColumn   = postgres.TimestampzColumn("created_at")
-		allColumns        = postgres.ColumnList{IDColumn, CommentUUIDColumn, PollIDColumn, UserIDColumn, ParentIDColumn, ContentColumn, CreatedAtColumn}
-		mutableColumns    = postgres.ColumnList{CommentUUIDColumn, PollIDColumn, UserIDColumn, ParentIDColumn, ContentColumn, CreatedAtColumn}
-		defaultColumns    = postgres.ColumnList{IDColumn, CommentUUIDColumn, CreatedAtColumn}
+		RootIDColumn      = postgres.IntegerColumn("root_id")
+		UpdatedAtColumn   = postgres.TimestampzColumn("updated_at")
+		DeletedAtColumn   = postgres.TimestampzColumn("deleted_at")
+		DeletedByColumn   = postgres.IntegerColumn("deleted_by")
+		allColumns        = postgres.ColumnList{IDColumn, CommentUUIDColumn, PollIDColumn, UserIDColumn, ParentIDColumn, ContentColumn, CreatedAtColumn, RootIDColumn, UpdatedAtColumn, DeletedAtColumn, DeletedByColumn}
+		mutableColumns    = postgres.ColumnList{CommentUUIDColumn, PollIDColumn, UserIDColumn, ParentIDColumn, ContentColumn, CreatedAtColumn, RootIDColumn, UpdatedAtColumn, DeletedAtColumn, DeletedByColumn}
+		defaultColumns    = postgres.ColumnList{IDColumn, CommentUUIDColumn, CreatedAtColumn, UpdatedAtColumn}
 	)
 
 	return pollCommentsTable{
@@ -88,6 +96,10 @@ func newPollCommentsTableImpl(schemaName, tableName, alias string) pollCommentsT
 		ParentID:    ParentIDColumn,
 		Content:     ContentColumn,
 		CreatedAt:   CreatedAtColumn,
+		RootID:      RootIDColumn,
+		UpdatedAt:   UpdatedAtColumn,
+		DeletedAt:   DeletedAtColumn,
+		DeletedBy:   DeletedByColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -30,6 +30,9 @@ type pollsTable struct {
 	ParticipantsCount postgres.ColumnInteger
 	TotalVotesCount   postgres.ColumnInteger
 	LikesCount        postgres.ColumnInteger
+	TrendingScore     postgres.ColumnFloat
+	HiddenAt          postgres.ColumnTimestampz
+	HiddenBy          postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -84,9 +87,12 @@ func newPollsTableImpl(schemaName, tableName, alias string) pollsTable {
 		ParticipantsCountColumn = postgres.IntegerColumn("participants_count")
 		TotalVotesCountColumn   = postgres.IntegerColumn("total_votes_count")
 		LikesCountColumn        = postgres.IntegerColumn("likes_count")
-		allColumns              = postgres.ColumnList{IDColumn, PollUUIDColumn, PublicColumn, CategoryColumn, StartAtColumn, ExpiresAtColumn, VotesPerUserColumn, VotesPerOptionColumn, CreatedByColumn, CreatedAtColumn, ParticipantsCountColumn, TotalVotesCountColumn, LikesCountColumn}
-		mutableColumns          = postgres.ColumnList{PollUUIDColumn, PublicColumn, CategoryColumn, StartAtColumn, ExpiresAtColumn, VotesPerUserColumn, VotesPerOptionColumn, CreatedByColumn, CreatedAtColumn, ParticipantsCountColumn, TotalVotesCountColumn, LikesCountColumn}
-		defaultColumns          = postgres.ColumnList{IDColumn, PollUUIDColumn, PublicColumn, StartAtColumn, VotesPerUserColumn, VotesPerOptionColumn, CreatedAtColumn, ParticipantsCountColumn, TotalVotesCountColumn, LikesCountColumn}
+		TrendingScoreColumn     = postgres.FloatColumn("trending_score")
+		HiddenAtColumn          = postgres.TimestampzColumn("hidden_at")
+		HiddenByColumn          = postgres.IntegerColumn("hidden_by")
+		allColumns              = postgres.ColumnList{IDColumn, PollUUIDColumn, PublicColumn, CategoryColumn, StartAtColumn, ExpiresAtColumn, VotesPerUserColumn, VotesPerOptionColumn, CreatedByColumn, CreatedAtColumn, ParticipantsCountColumn, TotalVotesCountColumn, LikesCountColumn, TrendingScoreColumn, HiddenAtColumn, HiddenByColumn}
+		mutableColumns          = postgres.ColumnList{PollUUIDColumn, PublicColumn, CategoryColumn, StartAtColumn, ExpiresAtColumn, VotesPerUserColumn, VotesPerOptionColumn, CreatedByColumn, CreatedAtColumn, ParticipantsCountColumn, TotalVotesCountColumn, LikesCountColumn, TrendingScoreColumn, HiddenAtColumn, HiddenByColumn}
+		defaultColumns          = postgres.ColumnList{IDColumn, PollUUIDColumn, PublicColumn, StartAtColumn, VotesPerUserColumn, VotesPerOptionColumn, CreatedAtColumn, ParticipantsCountColumn, TotalVotesCountColumn, LikesCountColumn, TrendingScoreColumn}
 	)
 
 	return pollsTable{
@@ -106,6 +112,9 @@ func newPollsTableImpl(schemaName, tableName, alias string) pollsTable {
 		ParticipantsCount: ParticipantsCountColumn,
 		TotalVotesCount:   TotalVotesCountColumn,
 		LikesCount:        LikesCountColumn,
+		TrendingScore:     TrendingScoreColumn,
+		HiddenAt:          HiddenAtColumn,
+		HiddenBy:          HiddenByColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
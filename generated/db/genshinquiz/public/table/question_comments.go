@@ -17,12 +17,18 @@ type questionCommentsTable struct {
 	postgres.Table
 
 	// Columns
-	ID         postgres.ColumnInteger
-	QuestionID postgres.ColumnInteger
-	UserID     postgres.ColumnInteger
-	Comment    postgres.ColumnString
-	CreatedAt  postgres.ColumnTimestampz
-	UpdatedAt  postgres.ColumnTimestampz
+	ID          postgres.ColumnInteger
+	QuestionID  postgres.ColumnInteger
+	UserID      postgres.ColumnInteger
+	Comment     postgres.ColumnString
+	CreatedAt   postgres.ColumnTimestampz
+	UpdatedAt   postgres.ColumnTimestampz
+	CommentUUID postgres.ColumnString
+	ParentID    postgres.ColumnInteger
+	RootID      postgres.ColumnInteger
+	IsSpoiler   postgres.ColumnBool
+	DeletedAt   postgres.ColumnTimestampz
+	DeletedBy   postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -64,27 +70,39 @@ func newQuestionCommentsTable(schemaName, tableName, alias string) *QuestionComm
 
 func newQuestionCommentsTableImpl(schemaName, tableName, alias string) questionCommentsTable {
 	var (
-		IDColumn         = postgres.IntegerColumn("id")
-		QuestionIDColumn = postgres.IntegerColumn("question_id")
-		UserIDColumn     = postgres.IntegerColumn("user_id")
-		CommentColumn    = postgres.StringColumn("comment")
-		CreatedAtColumn  = postgres.TimestampzColumn("created_at")
-		UpdatedAtColumn  = postgres.TimestampzColumn("updated_at")
-		allColumns       = postgres.ColumnList{IDColumn, QuestionIDColumn, UserIDColumn, CommentColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns   = postgres.ColumnList{QuestionIDColumn, UserIDColumn, CommentColumn, CreatedAtColumn, UpdatedAtColumn}
-		defaultColumns   = postgres.ColumnList{IDColumn, CreatedAtColumn, UpdatedAtColumn}
+		IDColumn          = postgres.IntegerColumn("id")
+		QuestionIDColumn  = postgres.IntegerColumn("question_id")
+		UserIDColumn      = postgres.IntegerColumn("user_id")
+		CommentColumn     = postgres.StringColumn("comment")
+		CreatedAtColumn   = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn   = postgres.TimestampzColumn("updated_at")
+		CommentUUIDColumn = postgres.StringColumn("comment_uuid")
+		ParentIDColumn    = postgres.IntegerColumn("parent_id")
+		RootIDColumn      = postgres.IntegerColumn("root_id")
+		IsSpoilerColumn   = postgres.BoolColumn("is_spoiler")
+		DeletedAtColumn   = postgres.TimestampzColumn("deleted_at")
+		DeletedByColumn   = postgres.IntegerColumn("deleted_by")
+		allColumns        = postgres.ColumnList{IDColumn, QuestionIDColumn, UserIDColumn, CommentColumn, CreatedAtColumn, UpdatedAtColumn, CommentUUIDColumn, ParentIDColumn, RootIDColumn, IsSpoilerColumn, DeletedAtColumn, DeletedByColumn}
+		mutableColumns    = postgres.ColumnList{QuestionIDColumn, UserIDColumn, CommentColumn, CreatedAtColumn, UpdatedAtColumn, CommentUUIDColumn, ParentIDColumn, RootIDColumn, IsSpoilerColumn, DeletedAtColumn, DeletedByColumn}
+		defaultColumns    = postgres.ColumnList{IDColumn, CreatedAtColumn, UpdatedAtColumn, CommentUUIDColumn, IsSpoilerColumn}
 	)
 
 	return questionCommentsTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:         IDColumn,
-		QuestionID: QuestionIDColumn,
-		UserID:     UserIDColumn,
-		Comment:    CommentColumn,
-		CreatedAt:  CreatedAtColumn,
-		UpdatedAt:  UpdatedAtColumn,
+		ID:          IDColumn,
+		QuestionID:  QuestionIDColumn,
+		UserID:      UserIDColumn,
+		Comment:     CommentColumn,
+		CreatedAt:   CreatedAtColumn,
+		UpdatedAt:   UpdatedAtColumn,
+		CommentUUID: CommentUUIDColumn,
+		ParentID:    ParentIDColumn,
+		RootID:      RootIDColumn,
+		IsSpoiler:   IsSpoilerColumn,
+		DeletedAt:   DeletedAtColumn,
+		DeletedBy:   DeletedByColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
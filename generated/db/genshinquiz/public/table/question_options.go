@@ -25,6 +25,7 @@ type questionOptionsTable struct {
 	IsAnswer      postgres.ColumnBool
 	CreatedAt     postgres.ColumnTimestampz
 	SelectedCount postgres.ColumnInteger
+	RemovedAt     postgres.ColumnTimestampz
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -74,8 +75,9 @@ func newQuestionOptionsTableImpl(schemaName, tableName, alias string) questionOp
 		IsAnswerColumn      = postgres.BoolColumn("is_answer")
 		CreatedAtColumn     = postgres.TimestampzColumn("created_at")
 		SelectedCountColumn = postgres.IntegerColumn("selected_count")
-		allColumns          = postgres.ColumnList{IDColumn, OptionUUIDColumn, QuestionIDColumn, OptionTypeColumn, ImgURLColumn, IsAnswerColumn, CreatedAtColumn, SelectedCountColumn}
-		mutableColumns      = postgres.ColumnList{OptionUUIDColumn, QuestionIDColumn, OptionTypeColumn, ImgURLColumn, IsAnswerColumn, CreatedAtColumn, SelectedCountColumn}
+		RemovedAtColumn     = postgres.TimestampzColumn("removed_at")
+		allColumns          = postgres.ColumnList{IDColumn, OptionUUIDColumn, QuestionIDColumn, OptionTypeColumn, ImgURLColumn, IsAnswerColumn, CreatedAtColumn, SelectedCountColumn, RemovedAtColumn}
+		mutableColumns      = postgres.ColumnList{OptionUUIDColumn, QuestionIDColumn, OptionTypeColumn, ImgURLColumn, IsAnswerColumn, CreatedAtColumn, SelectedCountColumn, RemovedAtColumn}
 		defaultColumns      = postgres.ColumnList{IDColumn, OptionUUIDColumn, OptionTypeColumn, IsAnswerColumn, CreatedAtColumn, SelectedCountColumn}
 	)
 
@@ -91,6 +93,7 @@ func newQuestionOptionsTableImpl(schemaName, tableName, alias string) questionOp
 		IsAnswer:      IsAnswerColumn,
 		CreatedAt:     CreatedAtColumn,
 		SelectedCount: SelectedCountColumn,
+		RemovedAt:     RemovedAtColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
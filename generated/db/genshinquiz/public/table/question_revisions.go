@@ -0,0 +1,93 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var QuestionRevisions = newQuestionRevisionsTable("public", "question_revisions", "")
+
+type questionRevisionsTable struct {
+	postgres.Table
+
+	// Columns
+	ID         postgres.ColumnInteger
+	QuestionID postgres.ColumnInteger
+	Revision   postgres.ColumnInteger
+	Snapshot   postgres.ColumnString
+	CreatedBy  postgres.ColumnInteger
+	CreatedAt  postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type QuestionRevisionsTable struct {
+	questionRevisionsTable
+
+	EXCLUDED questionRevisionsTable
+}
+
+// AS creates new QuestionRevisionsTable with assigned alias
+func (a QuestionRevisionsTable) AS(alias string) *QuestionRevisionsTable {
+	return newQuestionRevisionsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new QuestionRevisionsTable with assigned schema name
+func (a QuestionRevisionsTable) FromSchema(schemaName string) *QuestionRevisionsTable {
+	return newQuestionRevisionsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new QuestionRevisionsTable with assigned table prefix
+func (a QuestionRevisionsTable) WithPrefix(prefix string) *QuestionRevisionsTable {
+	return newQuestionRevisionsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new QuestionRevisionsTable with assigned table suffix
+func (a QuestionRevisionsTable) WithSuffix(suffix string) *QuestionRevisionsTable {
+	return newQuestionRevisionsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newQuestionRevisionsTable(schemaName, tableName, alias string) *QuestionRevisionsTable {
+	return &QuestionRevisionsTable{
+		questionRevisionsTable: newQuestionRevisionsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:               newQuestionRevisionsTableImpl("", "excluded", ""),
+	}
+}
+
+func newQuestionRevisionsTableImpl(schemaName, tableName, alias string) questionRevisionsTable {
+	var (
+		IDColumn         = postgres.IntegerColumn("id")
+		QuestionIDColumn = postgres.IntegerColumn("question_id")
+		RevisionColumn   = postgres.IntegerColumn("revision")
+		SnapshotColumn   = postgres.StringColumn("snapshot")
+		CreatedByColumn  = postgres.IntegerColumn("created_by")
+		CreatedAtColumn  = postgres.TimestampzColumn("created_at")
+		allColumns       = postgres.ColumnList{IDColumn, QuestionIDColumn, RevisionColumn, SnapshotColumn, CreatedByColumn, CreatedAtColumn}
+		mutableColumns   = postgres.ColumnList{QuestionIDColumn, RevisionColumn, SnapshotColumn, CreatedByColumn, CreatedAtColumn}
+		defaultColumns   = postgres.ColumnList{IDColumn, CreatedAtColumn}
+	)
+
+	return questionRevisionsTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:         IDColumn,
+		QuestionID: QuestionIDColumn,
+		Revision:   RevisionColumn,
+		Snapshot:   SnapshotColumn,
+		CreatedBy:  CreatedByColumn,
+		CreatedAt:  CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
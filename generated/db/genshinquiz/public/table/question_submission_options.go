@@ -17,10 +17,8 @@ type questionSubmissionOptionsTable struct {
 	postgres.Table
 
 	// Columns
-	ID           postgres.ColumnInteger
 	SubmissionID postgres.ColumnInteger
 	OptionID     postgres.ColumnInteger
-	CreatedAt    postgres.ColumnTimestampz
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -62,23 +60,19 @@ func newQuestionSubmissionOptionsTable(schemaName, tableName, alias string) *Que
 
 func newQuestionSubmissionOptionsTableImpl(schemaName, tableName, alias string) questionSubmissionOptionsTable {
 	var (
-		IDColumn           = postgres.IntegerColumn("id")
 		SubmissionIDColumn = postgres.IntegerColumn("submission_id")
 		OptionIDColumn     = postgres.IntegerColumn("option_id")
-		CreatedAtColumn    = postgres.TimestampzColumn("created_at")
-		allColumns         = postgres.ColumnList{IDColumn, SubmissionIDColumn, OptionIDColumn, CreatedAtColumn}
-		mutableColumns     = postgres.ColumnList{SubmissionIDColumn, OptionIDColumn, CreatedAtColumn}
-		defaultColumns     = postgres.ColumnList{IDColumn, CreatedAtColumn}
+		allColumns         = postgres.ColumnList{SubmissionIDColumn, OptionIDColumn}
+		mutableColumns     = postgres.ColumnList{}
+		defaultColumns     = postgres.ColumnList{}
 	)
 
 	return questionSubmissionOptionsTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:           IDColumn,
 		SubmissionID: SubmissionIDColumn,
 		OptionID:     OptionIDColumn,
-		CreatedAt:    CreatedAtColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -25,6 +25,7 @@ type questionSubmissionsTable struct {
 	IsPractice     postgres.ColumnBool
 	TimeTaken      postgres.ColumnInteger
 	CreatedAt      postgres.ColumnTimestampz
+	RevisionID     postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -74,8 +75,9 @@ func newQuestionSubmissionsTableImpl(schemaName, tableName, alias string) questi
 		IsPracticeColumn     = postgres.BoolColumn("is_practice")
 		TimeTakenColumn      = postgres.IntegerColumn("time_taken")
 		CreatedAtColumn      = postgres.TimestampzColumn("created_at")
-		allColumns           = postgres.ColumnList{IDColumn, SubmissionUUIDColumn, QuestionIDColumn, UserIDColumn, IsCorrectColumn, IsPracticeColumn, TimeTakenColumn, CreatedAtColumn}
-		mutableColumns       = postgres.ColumnList{SubmissionUUIDColumn, QuestionIDColumn, UserIDColumn, IsCorrectColumn, IsPracticeColumn, TimeTakenColumn, CreatedAtColumn}
+		RevisionIDColumn     = postgres.IntegerColumn("revision_id")
+		allColumns           = postgres.ColumnList{IDColumn, SubmissionUUIDColumn, QuestionIDColumn, UserIDColumn, IsCorrectColumn, IsPracticeColumn, TimeTakenColumn, CreatedAtColumn, RevisionIDColumn}
+		mutableColumns       = postgres.ColumnList{SubmissionUUIDColumn, QuestionIDColumn, UserIDColumn, IsCorrectColumn, IsPracticeColumn, TimeTakenColumn, CreatedAtColumn, RevisionIDColumn}
 		defaultColumns       = postgres.ColumnList{IDColumn, SubmissionUUIDColumn, IsCorrectColumn, IsPracticeColumn, CreatedAtColumn}
 	)
 
@@ -91,6 +93,7 @@ func newQuestionSubmissionsTableImpl(schemaName, tableName, alias string) questi
 		IsPractice:     IsPracticeColumn,
 		TimeTaken:      TimeTakenColumn,
 		CreatedAt:      CreatedAtColumn,
+		RevisionID:     RevisionIDColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -17,19 +17,28 @@ type questionsTable struct {
 	postgres.Table
 
 	// Columns
-	ID           postgres.ColumnInteger
-	QuestionUUID postgres.ColumnString
-	Public       postgres.ColumnBool
-	QuestionType postgres.ColumnString
-	Category     postgres.ColumnString
-	Difficulty   postgres.ColumnString
-	IsPublished  postgres.ColumnBool
-	PublishedAt  postgres.ColumnTimestampz
-	CreatedBy    postgres.ColumnInteger
-	CreatedAt    postgres.ColumnTimestampz
-	SubmitCount  postgres.ColumnInteger
-	CorrectCount postgres.ColumnInteger
-	Likes        postgres.ColumnInteger
+	ID              postgres.ColumnInteger
+	QuestionUUID    postgres.ColumnString
+	Public          postgres.ColumnBool
+	QuestionType    postgres.ColumnString
+	Category        postgres.ColumnString
+	Difficulty      postgres.ColumnString
+	IsPublished     postgres.ColumnBool
+	PublishedAt     postgres.ColumnTimestampz
+	CreatedBy       postgres.ColumnInteger
+	CreatedAt       postgres.ColumnTimestampz
+	SubmitCount     postgres.ColumnInteger
+	CorrectCount    postgres.ColumnInteger
+	Likes           postgres.ColumnInteger
+	CurrentRevision postgres.ColumnInteger
+	UpdatedAt       postgres.ColumnTimestampz
+	DeletedAt       postgres.ColumnTimestampz
+	DeletedBy       postgres.ColumnInteger
+	Status          postgres.ColumnString
+	SubmittedAt     postgres.ColumnTimestampz
+	ReviewedBy      postgres.ColumnInteger
+	ReviewedAt      postgres.ColumnTimestampz
+	ReviewReason    postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -71,41 +80,59 @@ func newQuestionsTable(schemaName, tableName, alias string) *QuestionsTable {
 
 func newQuestionsTableImpl(schemaName, tableName, alias string) questionsTable {
 	var (
-		IDColumn           = postgres.IntegerColumn("id")
-		QuestionUUIDColumn = postgres.StringColumn("question_uuid")
-		PublicColumn       = postgres.BoolColumn("public")
-		QuestionTypeColumn = postgres.StringColumn("question_type")
-		CategoryColumn     = postgres.StringColumn("category")
-		DifficultyColumn   = postgres.StringColumn("difficulty")
-		IsPublishedColumn  = postgres.BoolColumn("is_published")
-		PublishedAtColumn  = postgres.TimestampzColumn("published_at")
-		CreatedByColumn    = postgres.IntegerColumn("created_by")
-		CreatedAtColumn    = postgres.TimestampzColumn("created_at")
-		SubmitCountColumn  = postgres.IntegerColumn("submit_count")
-		CorrectCountColumn = postgres.IntegerColumn("correct_count")
-		LikesColumn        = postgres.IntegerColumn("likes")
-		allColumns         = postgres.ColumnList{IDColumn, QuestionUUIDColumn, PublicColumn, QuestionTypeColumn, CategoryColumn, DifficultyColumn, IsPublishedColumn, PublishedAtColumn, CreatedByColumn, CreatedAtColumn, SubmitCountColumn, CorrectCountColumn, LikesColumn}
-		mutableColumns     = postgres.ColumnList{QuestionUUIDColumn, PublicColumn, QuestionTypeColumn, CategoryColumn, DifficultyColumn, IsPublishedColumn, PublishedAtColumn, CreatedByColumn, CreatedAtColumn, SubmitCountColumn, CorrectCountColumn, LikesColumn}
-		defaultColumns     = postgres.ColumnList{IDColumn, QuestionUUIDColumn, PublicColumn, IsPublishedColumn, CreatedAtColumn, SubmitCountColumn, CorrectCountColumn, LikesColumn}
+		IDColumn              = postgres.IntegerColumn("id")
+		QuestionUUIDColumn    = postgres.StringColumn("question_uuid")
+		PublicColumn          = postgres.BoolColumn("public")
+		QuestionTypeColumn    = postgres.StringColumn("question_type")
+		CategoryColumn        = postgres.StringColumn("category")
+		DifficultyColumn      = postgres.StringColumn("difficulty")
+		IsPublishedColumn     = postgres.BoolColumn("is_published")
+		PublishedAtColumn     = postgres.TimestampzColumn("published_at")
+		CreatedByColumn       = postgres.IntegerColumn("created_by")
+		CreatedAtColumn       = postgres.TimestampzColumn("created_at")
+		SubmitCountColumn     = postgres.IntegerColumn("submit_count")
+		CorrectCountColumn    = postgres.IntegerColumn("correct_count")
+		LikesColumn           = postgres.IntegerColumn("likes")
+		CurrentRevisionColumn = postgres.IntegerColumn("current_revision")
+		UpdatedAtColumn       = postgres.TimestampzColumn("updated_at")
+		DeletedAtColumn       = postgres.TimestampzColumn("deleted_at")
+		DeletedByColumn       = postgres.IntegerColumn("deleted_by")
+		StatusColumn          = postgres.StringColumn("status")
+		SubmittedAtColumn     = postgres.TimestampzColumn("submitted_at")
+		ReviewedByColumn      = postgres.IntegerColumn("reviewed_by")
+		ReviewedAtColumn      = postgres.TimestampzColumn("reviewed_at")
+		ReviewReasonColumn    = postgres.StringColumn("review_reason")
+		allColumns            = postgres.ColumnList{IDColumn, QuestionUUIDColumn, PublicColumn, QuestionTypeColumn, CategoryColumn, DifficultyColumn, IsPublishedColumn, PublishedAtColumn, CreatedByColumn, CreatedAtColumn, SubmitCountColumn, CorrectCountColumn, LikesColumn, CurrentRevisionColumn, UpdatedAtColumn, DeletedAtColumn, DeletedByColumn, StatusColumn, SubmittedAtColumn, ReviewedByColumn, ReviewedAtColumn, ReviewReasonColumn}
+		mutableColumns        = postgres.ColumnList{QuestionUUIDColumn, PublicColumn, QuestionTypeColumn, CategoryColumn, DifficultyColumn, IsPublishedColumn, PublishedAtColumn, CreatedByColumn, CreatedAtColumn, SubmitCountColumn, CorrectCountColumn, LikesColumn, CurrentRevisionColumn, UpdatedAtColumn, DeletedAtColumn, DeletedByColumn, StatusColumn, SubmittedAtColumn, ReviewedByColumn, ReviewedAtColumn, ReviewReasonColumn}
+		defaultColumns        = postgres.ColumnList{IDColumn, QuestionUUIDColumn, PublicColumn, IsPublishedColumn, CreatedAtColumn, SubmitCountColumn, CorrectCountColumn, LikesColumn, CurrentRevisionColumn, StatusColumn}
 	)
 
 	return questionsTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:           IDColumn,
-		QuestionUUID: QuestionUUIDColumn,
-		Public:       PublicColumn,
-		QuestionType: QuestionTypeColumn,
-		Category:     CategoryColumn,
-		Difficulty:   DifficultyColumn,
-		IsPublished:  IsPublishedColumn,
-		PublishedAt:  PublishedAtColumn,
-		CreatedBy:    CreatedByColumn,
-		CreatedAt:    CreatedAtColumn,
-		SubmitCount:  SubmitCountColumn,
-		CorrectCount: CorrectCountColumn,
-		Likes:        LikesColumn,
+		ID:              IDColumn,
+		QuestionUUID:    QuestionUUIDColumn,
+		Public:          PublicColumn,
+		QuestionType:    QuestionTypeColumn,
+		Category:        CategoryColumn,
+		Difficulty:      DifficultyColumn,
+		IsPublished:     IsPublishedColumn,
+		PublishedAt:     PublishedAtColumn,
+		CreatedBy:       CreatedByColumn,
+		CreatedAt:       CreatedAtColumn,
+		SubmitCount:     SubmitCountColumn,
+		CorrectCount:    CorrectCountColumn,
+		Likes:           LikesColumn,
+		CurrentRevision: CurrentRevisionColumn,
+		UpdatedAt:       UpdatedAtColumn,
+		DeletedAt:       DeletedAtColumn,
+		DeletedBy:       DeletedByColumn,
+		Status:          StatusColumn,
+		SubmittedAt:     SubmittedAtColumn,
+		ReviewedBy:      ReviewedByColumn,
+		ReviewedAt:      ReviewedAtColumn,
+		ReviewReason:    ReviewReasonColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
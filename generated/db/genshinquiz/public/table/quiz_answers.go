@@ -0,0 +1,108 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var QuizAnswers = newQuizAnswersTable("public", "quiz_answers", "")
+
+type quizAnswersTable struct {
+	postgres.Table
+
+	// Columns
+	ID                postgres.ColumnInteger
+	AttemptID         postgres.ColumnInteger
+	QuestionID        postgres.ColumnInteger
+	SelectedOptionIds postgres.ColumnIntegerArray
+	TimeTaken         postgres.ColumnInteger
+	CreatedAt         postgres.ColumnTimestampz
+	IsCorrect         postgres.ColumnBool
+	Points            postgres.ColumnInteger
+	DisplayOrder      postgres.ColumnInteger
+	OptionOrder       postgres.ColumnIntegerArray
+	CorrectOptionIds  postgres.ColumnIntegerArray
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type QuizAnswersTable struct {
+	quizAnswersTable
+
+	EXCLUDED quizAnswersTable
+}
+
+// AS creates new QuizAnswersTable with assigned alias
+func (a QuizAnswersTable) AS(alias string) *QuizAnswersTable {
+	return newQuizAnswersTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new QuizAnswersTable with assigned schema name
+func (a QuizAnswersTable) FromSchema(schemaName string) *QuizAnswersTable {
+	return newQuizAnswersTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new QuizAnswersTable with assigned table prefix
+func (a QuizAnswersTable) WithPrefix(prefix string) *QuizAnswersTable {
+	return newQuizAnswersTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new QuizAnswersTable with assigned table suffix
+func (a QuizAnswersTable) WithSuffix(suffix string) *QuizAnswersTable {
+	return newQuizAnswersTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newQuizAnswersTable(schemaName, tableName, alias string) *QuizAnswersTable {
+	return &QuizAnswersTable{
+		quizAnswersTable: newQuizAnswersTableImpl(schemaName, tableName, alias),
+		EXCLUDED:         newQuizAnswersTableImpl("", "excluded", ""),
+	}
+}
+
+func newQuizAnswersTableImpl(schemaName, tableName, alias string) quizAnswersTable {
+	var (
+		IDColumn                = postgres.IntegerColumn("id")
+		AttemptIDColumn         = postgres.IntegerColumn("attempt_id")
+		QuestionIDColumn        = postgres.IntegerColumn("question_id")
+		SelectedOptionIdsColumn = postgres.IntegerArrayColumn("selected_option_ids")
+		TimeTakenColumn         = postgres.IntegerColumn("time_taken")
+		CreatedAtColumn         = postgres.TimestampzColumn("created_at")
+		IsCorrectColumn         = postgres.BoolColumn("is_correct")
+		PointsColumn            = postgres.IntegerColumn("points")
+		DisplayOrderColumn      = postgres.IntegerColumn("display_order")
+		OptionOrderColumn       = postgres.IntegerArrayColumn("option_order")
+		CorrectOptionIdsColumn  = postgres.IntegerArrayColumn("correct_option_ids")
+		allColumns              = postgres.ColumnList{IDColumn, AttemptIDColumn, QuestionIDColumn, SelectedOptionIdsColumn, TimeTakenColumn, CreatedAtColumn, IsCorrectColumn, PointsColumn, DisplayOrderColumn, OptionOrderColumn, CorrectOptionIdsColumn}
+		mutableColumns          = postgres.ColumnList{AttemptIDColumn, QuestionIDColumn, SelectedOptionIdsColumn, TimeTakenColumn, CreatedAtColumn, IsCorrectColumn, PointsColumn, DisplayOrderColumn, OptionOrderColumn, CorrectOptionIdsColumn}
+		defaultColumns          = postgres.ColumnList{IDColumn, CreatedAtColumn, IsCorrectColumn, PointsColumn, DisplayOrderColumn}
+	)
+
+	return quizAnswersTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:                IDColumn,
+		AttemptID:         AttemptIDColumn,
+		QuestionID:        QuestionIDColumn,
+		SelectedOptionIds: SelectedOptionIdsColumn,
+		TimeTaken:         TimeTakenColumn,
+		CreatedAt:         CreatedAtColumn,
+		IsCorrect:         IsCorrectColumn,
+		Points:            PointsColumn,
+		DisplayOrder:      DisplayOrderColumn,
+		OptionOrder:       OptionOrderColumn,
+		CorrectOptionIds:  CorrectOptionIdsColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
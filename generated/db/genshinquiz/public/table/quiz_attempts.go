@@ -0,0 +1,102 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var QuizAttempts = newQuizAttemptsTable("public", "quiz_attempts", "")
+
+type quizAttemptsTable struct {
+	postgres.Table
+
+	// Columns
+	ID          postgres.ColumnInteger
+	AttemptUUID postgres.ColumnString
+	QuizID      postgres.ColumnInteger
+	UserID      postgres.ColumnInteger
+	StartedAt   postgres.ColumnTimestampz
+	TotalScore  postgres.ColumnInteger
+	MaxScore    postgres.ColumnInteger
+	CreatedAt   postgres.ColumnTimestampz
+	ShuffleSeed postgres.ColumnInteger
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type QuizAttemptsTable struct {
+	quizAttemptsTable
+
+	EXCLUDED quizAttemptsTable
+}
+
+// AS creates new QuizAttemptsTable with assigned alias
+func (a QuizAttemptsTable) AS(alias string) *QuizAttemptsTable {
+	return newQuizAttemptsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new QuizAttemptsTable with assigned schema name
+func (a QuizAttemptsTable) FromSchema(schemaName string) *QuizAttemptsTable {
+	return newQuizAttemptsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new QuizAttemptsTable with assigned table prefix
+func (a QuizAttemptsTable) WithPrefix(prefix string) *QuizAttemptsTable {
+	return newQuizAttemptsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new QuizAttemptsTable with assigned table suffix
+func (a QuizAttemptsTable) WithSuffix(suffix string) *QuizAttemptsTable {
+	return newQuizAttemptsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newQuizAttemptsTable(schemaName, tableName, alias string) *QuizAttemptsTable {
+	return &QuizAttemptsTable{
+		quizAttemptsTable: newQuizAttemptsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:          newQuizAttemptsTableImpl("", "excluded", ""),
+	}
+}
+
+func newQuizAttemptsTableImpl(schemaName, tableName, alias string) quizAttemptsTable {
+	var (
+		IDColumn          = postgres.IntegerColumn("id")
+		AttemptUUIDColumn = postgres.StringColumn("attempt_uuid")
+		QuizIDColumn      = postgres.IntegerColumn("quiz_id")
+		UserIDColumn      = postgres.IntegerColumn("user_id")
+		StartedAtColumn   = postgres.TimestampzColumn("started_at")
+		TotalScoreColumn  = postgres.IntegerColumn("total_score")
+		MaxScoreColumn    = postgres.IntegerColumn("max_score")
+		CreatedAtColumn   = postgres.TimestampzColumn("created_at")
+		ShuffleSeedColumn = postgres.IntegerColumn("shuffle_seed")
+		allColumns        = postgres.ColumnList{IDColumn, AttemptUUIDColumn, QuizIDColumn, UserIDColumn, StartedAtColumn, TotalScoreColumn, MaxScoreColumn, CreatedAtColumn, ShuffleSeedColumn}
+		mutableColumns    = postgres.ColumnList{AttemptUUIDColumn, QuizIDColumn, UserIDColumn, StartedAtColumn, TotalScoreColumn, MaxScoreColumn, CreatedAtColumn, ShuffleSeedColumn}
+		defaultColumns    = postgres.ColumnList{IDColumn, AttemptUUIDColumn, CreatedAtColumn}
+	)
+
+	return quizAttemptsTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:          IDColumn,
+		AttemptUUID: AttemptUUIDColumn,
+		QuizID:      QuizIDColumn,
+		UserID:      UserIDColumn,
+		StartedAt:   StartedAtColumn,
+		TotalScore:  TotalScoreColumn,
+		MaxScore:    MaxScoreColumn,
+		CreatedAt:   CreatedAtColumn,
+		ShuffleSeed: ShuffleSeedColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
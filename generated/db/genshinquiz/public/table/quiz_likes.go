@@ -0,0 +1,93 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var QuizLikes = newQuizLikesTable("public", "quiz_likes", "")
+
+type quizLikesTable struct {
+	postgres.Table
+
+	// Columns
+	ID        postgres.ColumnInteger
+	QuizID    postgres.ColumnInteger
+	UserID    postgres.ColumnInteger
+	Value     postgres.ColumnInteger
+	CreatedAt postgres.ColumnTimestampz
+	UpdatedAt postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type QuizLikesTable struct {
+	quizLikesTable
+
+	EXCLUDED quizLikesTable
+}
+
+// AS creates new QuizLikesTable with assigned alias
+func (a QuizLikesTable) AS(alias string) *QuizLikesTable {
+	return newQuizLikesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new QuizLikesTable with assigned schema name
+func (a QuizLikesTable) FromSchema(schemaName string) *QuizLikesTable {
+	return newQuizLikesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new QuizLikesTable with assigned table prefix
+func (a QuizLikesTable) WithPrefix(prefix string) *QuizLikesTable {
+	return newQuizLikesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new QuizLikesTable with assigned table suffix
+func (a QuizLikesTable) WithSuffix(suffix string) *QuizLikesTable {
+	return newQuizLikesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newQuizLikesTable(schemaName, tableName, alias string) *QuizLikesTable {
+	return &QuizLikesTable{
+		quizLikesTable: newQuizLikesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:       newQuizLikesTableImpl("", "excluded", ""),
+	}
+}
+
+func newQuizLikesTableImpl(schemaName, tableName, alias string) quizLikesTable {
+	var (
+		IDColumn        = postgres.IntegerColumn("id")
+		QuizIDColumn    = postgres.IntegerColumn("quiz_id")
+		UserIDColumn    = postgres.IntegerColumn("user_id")
+		ValueColumn     = postgres.IntegerColumn("value")
+		CreatedAtColumn = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn = postgres.TimestampzColumn("updated_at")
+		allColumns      = postgres.ColumnList{IDColumn, QuizIDColumn, UserIDColumn, ValueColumn, CreatedAtColumn, UpdatedAtColumn}
+		mutableColumns  = postgres.ColumnList{QuizIDColumn, UserIDColumn, ValueColumn, CreatedAtColumn, UpdatedAtColumn}
+		defaultColumns  = postgres.ColumnList{IDColumn, CreatedAtColumn, UpdatedAtColumn}
+	)
+
+	return quizLikesTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		QuizID:    QuizIDColumn,
+		UserID:    UserIDColumn,
+		Value:     ValueColumn,
+		CreatedAt: CreatedAtColumn,
+		UpdatedAt: UpdatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
@@ -11,14 +11,14 @@ import (
 	"github.com/go-jet/jet/v2/postgres"
 )
 
-var ExamQuestions = newExamQuestionsTable("public", "exam_questions", "")
+var QuizQuestions = newQuizQuestionsTable("public", "quiz_questions", "")
 
-type examQuestionsTable struct {
+type quizQuestionsTable struct {
 	postgres.Table
 
 	// Columns
 	ID            postgres.ColumnInteger
-	ExamID        postgres.ColumnInteger
+	QuizID        postgres.ColumnInteger
 	QuestionID    postgres.ColumnInteger
 	QuestionOrder postgres.ColumnInteger
 	Points        postgres.ColumnInteger
@@ -29,58 +29,58 @@ type examQuestionsTable struct {
 	DefaultColumns postgres.ColumnList
 }
 
-type ExamQuestionsTable struct {
-	examQuestionsTable
+type QuizQuestionsTable struct {
+	quizQuestionsTable
 
-	EXCLUDED examQuestionsTable
+	EXCLUDED quizQuestionsTable
 }
 
-// AS creates new ExamQuestionsTable with assigned alias
-func (a ExamQuestionsTable) AS(alias string) *ExamQuestionsTable {
-	return newExamQuestionsTable(a.SchemaName(), a.TableName(), alias)
+// AS creates new QuizQuestionsTable with assigned alias
+func (a QuizQuestionsTable) AS(alias string) *QuizQuestionsTable {
+	return newQuizQuestionsTable(a.SchemaName(), a.TableName(), alias)
 }
 
-// Schema creates new ExamQuestionsTable with assigned schema name
-func (a ExamQuestionsTable) FromSchema(schemaName string) *ExamQuestionsTable {
-	return newExamQuestionsTable(schemaName, a.TableName(), a.Alias())
+// Schema creates new QuizQuestionsTable with assigned schema name
+func (a QuizQuestionsTable) FromSchema(schemaName string) *QuizQuestionsTable {
+	return newQuizQuestionsTable(schemaName, a.TableName(), a.Alias())
 }
 
-// WithPrefix creates new ExamQuestionsTable with assigned table prefix
-func (a ExamQuestionsTable) WithPrefix(prefix string) *ExamQuestionsTable {
-	return newExamQuestionsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+// WithPrefix creates new QuizQuestionsTable with assigned table prefix
+func (a QuizQuestionsTable) WithPrefix(prefix string) *QuizQuestionsTable {
+	return newQuizQuestionsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
 }
 
-// WithSuffix creates new ExamQuestionsTable with assigned table suffix
-func (a ExamQuestionsTable) WithSuffix(suffix string) *ExamQuestionsTable {
-	return newExamQuestionsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+// WithSuffix creates new QuizQuestionsTable with assigned table suffix
+func (a QuizQuestionsTable) WithSuffix(suffix string) *QuizQuestionsTable {
+	return newQuizQuestionsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
 }
 
-func newExamQuestionsTable(schemaName, tableName, alias string) *ExamQuestionsTable {
-	return &ExamQuestionsTable{
-		examQuestionsTable: newExamQuestionsTableImpl(schemaName, tableName, alias),
-		EXCLUDED:           newExamQuestionsTableImpl("", "excluded", ""),
+func newQuizQuestionsTable(schemaName, tableName, alias string) *QuizQuestionsTable {
+	return &QuizQuestionsTable{
+		quizQuestionsTable: newQuizQuestionsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:           newQuizQuestionsTableImpl("", "excluded", ""),
 	}
 }
 
-func newExamQuestionsTableImpl(schemaName, tableName, alias string) examQuestionsTable {
+func newQuizQuestionsTableImpl(schemaName, tableName, alias string) quizQuestionsTable {
 	var (
 		IDColumn            = postgres.IntegerColumn("id")
-		ExamIDColumn        = postgres.IntegerColumn("exam_id")
+		QuizIDColumn        = postgres.IntegerColumn("quiz_id")
 		QuestionIDColumn    = postgres.IntegerColumn("question_id")
 		QuestionOrderColumn = postgres.IntegerColumn("question_order")
 		PointsColumn        = postgres.IntegerColumn("points")
 		CreatedAtColumn     = postgres.TimestampzColumn("created_at")
-		allColumns          = postgres.ColumnList{IDColumn, ExamIDColumn, QuestionIDColumn, QuestionOrderColumn, PointsColumn, CreatedAtColumn}
-		mutableColumns      = postgres.ColumnList{ExamIDColumn, QuestionIDColumn, QuestionOrderColumn, PointsColumn, CreatedAtColumn}
+		allColumns          = postgres.ColumnList{IDColumn, QuizIDColumn, QuestionIDColumn, QuestionOrderColumn, PointsColumn, CreatedAtColumn}
+		mutableColumns      = postgres.ColumnList{QuizIDColumn, QuestionIDColumn, QuestionOrderColumn, PointsColumn, CreatedAtColumn}
 		defaultColumns      = postgres.ColumnList{IDColumn, QuestionOrderColumn, PointsColumn, CreatedAtColumn}
 	)
 
-	return examQuestionsTable{
+	return quizQuestionsTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
 		ID:            IDColumn,
-		ExamID:        ExamIDColumn,
+		QuizID:        QuizIDColumn,
 		QuestionID:    QuestionIDColumn,
 		QuestionOrder: QuestionOrderColumn,
 		Points:        PointsColumn,
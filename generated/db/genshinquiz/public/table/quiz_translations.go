@@ -11,14 +11,14 @@ import (
 	"github.com/go-jet/jet/v2/postgres"
 )
 
-var ExamTranslations = newExamTranslationsTable("public", "exam_translations", "")
+var QuizTranslations = newQuizTranslationsTable("public", "quiz_translations", "")
 
-type examTranslationsTable struct {
+type quizTranslationsTable struct {
 	postgres.Table
 
 	// Columns
 	ID          postgres.ColumnInteger
-	ExamID      postgres.ColumnInteger
+	QuizID      postgres.ColumnInteger
 	Language    postgres.ColumnString
 	Title       postgres.ColumnString
 	Description postgres.ColumnString
@@ -30,59 +30,59 @@ type examTranslationsTable struct {
 	DefaultColumns postgres.ColumnList
 }
 
-type ExamTranslationsTable struct {
-	examTranslationsTable
+type QuizTranslationsTable struct {
+	quizTranslationsTable
 
-	EXCLUDED examTranslationsTable
+	EXCLUDED quizTranslationsTable
 }
 
-// AS creates new ExamTranslationsTable with assigned alias
-func (a ExamTranslationsTable) AS(alias string) *ExamTranslationsTable {
-	return newExamTranslationsTable(a.SchemaName(), a.TableName(), alias)
+// AS creates new QuizTranslationsTable with assigned alias
+func (a QuizTranslationsTable) AS(alias string) *QuizTranslationsTable {
+	return newQuizTranslationsTable(a.SchemaName(), a.TableName(), alias)
 }
 
-// Schema creates new ExamTranslationsTable with assigned schema name
-func (a ExamTranslationsTable) FromSchema(schemaName string) *ExamTranslationsTable {
-	return newExamTranslationsTable(schemaName, a.TableName(), a.Alias())
+// Schema creates new QuizTranslationsTable with assigned schema name
+func (a QuizTranslationsTable) FromSchema(schemaName string) *QuizTranslationsTable {
+	return newQuizTranslationsTable(schemaName, a.TableName(), a.Alias())
 }
 
-// WithPrefix creates new ExamTranslationsTable with assigned table prefix
-func (a ExamTranslationsTable) WithPrefix(prefix string) *ExamTranslationsTable {
-	return newExamTranslationsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+// WithPrefix creates new QuizTranslationsTable with assigned table prefix
+func (a QuizTranslationsTable) WithPrefix(prefix string) *QuizTranslationsTable {
+	return newQuizTranslationsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
 }
 
-// WithSuffix creates new ExamTranslationsTable with assigned table suffix
-func (a ExamTranslationsTable) WithSuffix(suffix string) *ExamTranslationsTable {
-	return newExamTranslationsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+// WithSuffix creates new QuizTranslationsTable with assigned table suffix
+func (a QuizTranslationsTable) WithSuffix(suffix string) *QuizTranslationsTable {
+	return newQuizTranslationsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
 }
 
-func newExamTranslationsTable(schemaName, tableName, alias string) *ExamTranslationsTable {
-	return &ExamTranslationsTable{
-		examTranslationsTable: newExamTranslationsTableImpl(schemaName, tableName, alias),
-		EXCLUDED:              newExamTranslationsTableImpl("", "excluded", ""),
+func newQuizTranslationsTable(schemaName, tableName, alias string) *QuizTranslationsTable {
+	return &QuizTranslationsTable{
+		quizTranslationsTable: newQuizTranslationsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:              newQuizTranslationsTableImpl("", "excluded", ""),
 	}
 }
 
-func newExamTranslationsTableImpl(schemaName, tableName, alias string) examTranslationsTable {
+func newQuizTranslationsTableImpl(schemaName, tableName, alias string) quizTranslationsTable {
 	var (
 		IDColumn          = postgres.IntegerColumn("id")
-		ExamIDColumn      = postgres.IntegerColumn("exam_id")
+		QuizIDColumn      = postgres.IntegerColumn("quiz_id")
 		LanguageColumn    = postgres.StringColumn("language")
 		TitleColumn       = postgres.StringColumn("title")
 		DescriptionColumn = postgres.StringColumn("description")
 		CreatedAtColumn   = postgres.TimestampzColumn("created_at")
 		UpdatedAtColumn   = postgres.TimestampzColumn("updated_at")
-		allColumns        = postgres.ColumnList{IDColumn, ExamIDColumn, LanguageColumn, TitleColumn, DescriptionColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns    = postgres.ColumnList{ExamIDColumn, LanguageColumn, TitleColumn, DescriptionColumn, CreatedAtColumn, UpdatedAtColumn}
+		allColumns        = postgres.ColumnList{IDColumn, QuizIDColumn, LanguageColumn, TitleColumn, DescriptionColumn, CreatedAtColumn, UpdatedAtColumn}
+		mutableColumns    = postgres.ColumnList{QuizIDColumn, LanguageColumn, TitleColumn, DescriptionColumn, CreatedAtColumn, UpdatedAtColumn}
 		defaultColumns    = postgres.ColumnList{IDColumn, CreatedAtColumn, UpdatedAtColumn}
 	)
 
-	return examTranslationsTable{
+	return quizTranslationsTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
 		ID:          IDColumn,
-		ExamID:      ExamIDColumn,
+		QuizID:      QuizIDColumn,
 		Language:    LanguageColumn,
 		Title:       TitleColumn,
 		Description: DescriptionColumn,
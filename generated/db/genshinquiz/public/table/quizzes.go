@@ -0,0 +1,114 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var Quizzes = newQuizzesTable("public", "quizzes", "")
+
+type quizzesTable struct {
+	postgres.Table
+
+	// Columns
+	ID                postgres.ColumnInteger
+	QuizUUID          postgres.ColumnString
+	Public            postgres.ColumnBool
+	Difficulty        postgres.ColumnString
+	TimeLimit         postgres.ColumnInteger
+	AccessCredentials postgres.ColumnString
+	CreatedBy         postgres.ColumnInteger
+	CreatedAt         postgres.ColumnTimestampz
+	UpdatedAt         postgres.ColumnTimestampz
+	Categories        postgres.ColumnStringArray
+	LikesCount        postgres.ColumnInteger
+	Shuffle           postgres.ColumnBool
+	TrendingScore     postgres.ColumnFloat
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type QuizzesTable struct {
+	quizzesTable
+
+	EXCLUDED quizzesTable
+}
+
+// AS creates new QuizzesTable with assigned alias
+func (a QuizzesTable) AS(alias string) *QuizzesTable {
+	return newQuizzesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new QuizzesTable with assigned schema name
+func (a QuizzesTable) FromSchema(schemaName string) *QuizzesTable {
+	return newQuizzesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new QuizzesTable with assigned table prefix
+func (a QuizzesTable) WithPrefix(prefix string) *QuizzesTable {
+	return newQuizzesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new QuizzesTable with assigned table suffix
+func (a QuizzesTable) WithSuffix(suffix string) *QuizzesTable {
+	return newQuizzesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newQuizzesTable(schemaName, tableName, alias string) *QuizzesTable {
+	return &QuizzesTable{
+		quizzesTable: newQuizzesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:     newQuizzesTableImpl("", "excluded", ""),
+	}
+}
+
+func newQuizzesTableImpl(schemaName, tableName, alias string) quizzesTable {
+	var (
+		IDColumn                = postgres.IntegerColumn("id")
+		QuizUUIDColumn          = postgres.StringColumn("quiz_uuid")
+		PublicColumn            = postgres.BoolColumn("public")
+		DifficultyColumn        = postgres.StringColumn("difficulty")
+		TimeLimitColumn         = postgres.IntegerColumn("time_limit")
+		AccessCredentialsColumn = postgres.StringColumn("access_credentials")
+		CreatedByColumn         = postgres.IntegerColumn("created_by")
+		CreatedAtColumn         = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn         = postgres.TimestampzColumn("updated_at")
+		CategoriesColumn        = postgres.StringArrayColumn("categories")
+		LikesCountColumn        = postgres.IntegerColumn("likes_count")
+		ShuffleColumn           = postgres.BoolColumn("shuffle")
+		TrendingScoreColumn     = postgres.FloatColumn("trending_score")
+		allColumns              = postgres.ColumnList{IDColumn, QuizUUIDColumn, PublicColumn, DifficultyColumn, TimeLimitColumn, AccessCredentialsColumn, CreatedByColumn, CreatedAtColumn, UpdatedAtColumn, CategoriesColumn, LikesCountColumn, ShuffleColumn, TrendingScoreColumn}
+		mutableColumns          = postgres.ColumnList{QuizUUIDColumn, PublicColumn, DifficultyColumn, TimeLimitColumn, AccessCredentialsColumn, CreatedByColumn, CreatedAtColumn, UpdatedAtColumn, CategoriesColumn, LikesCountColumn, ShuffleColumn, TrendingScoreColumn}
+		defaultColumns          = postgres.ColumnList{IDColumn, QuizUUIDColumn, PublicColumn, CreatedAtColumn, UpdatedAtColumn, CategoriesColumn, LikesCountColumn, ShuffleColumn, TrendingScoreColumn}
+	)
+
+	return quizzesTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:                IDColumn,
+		QuizUUID:          QuizUUIDColumn,
+		Public:            PublicColumn,
+		Difficulty:        DifficultyColumn,
+		TimeLimit:         TimeLimitColumn,
+		AccessCredentials: AccessCredentialsColumn,
+		CreatedBy:         CreatedByColumn,
+		CreatedAt:         CreatedAtColumn,
+		UpdatedAt:         UpdatedAtColumn,
+		Categories:        CategoriesColumn,
+		LikesCount:        LikesCountColumn,
+		Shuffle:           ShuffleColumn,
+		TrendingScore:     TrendingScoreColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
@@ -0,0 +1,93 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var ReportEntries = newReportEntriesTable("public", "report_entries", "")
+
+type reportEntriesTable struct {
+	postgres.Table
+
+	// Columns
+	ID         postgres.ColumnInteger
+	ReportID   postgres.ColumnInteger
+	ReporterID postgres.ColumnInteger
+	Reason     postgres.ColumnString
+	Details    postgres.ColumnString
+	CreatedAt  postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type ReportEntriesTable struct {
+	reportEntriesTable
+
+	EXCLUDED reportEntriesTable
+}
+
+// AS creates new ReportEntriesTable with assigned alias
+func (a ReportEntriesTable) AS(alias string) *ReportEntriesTable {
+	return newReportEntriesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new ReportEntriesTable with assigned schema name
+func (a ReportEntriesTable) FromSchema(schemaName string) *ReportEntriesTable {
+	return newReportEntriesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new ReportEntriesTable with assigned table prefix
+func (a ReportEntriesTable) WithPrefix(prefix string) *ReportEntriesTable {
+	return newReportEntriesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new ReportEntriesTable with assigned table suffix
+func (a ReportEntriesTable) WithSuffix(suffix string) *ReportEntriesTable {
+	return newReportEntriesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newReportEntriesTable(schemaName, tableName, alias string) *ReportEntriesTable {
+	return &ReportEntriesTable{
+		reportEntriesTable: newReportEntriesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:           newReportEntriesTableImpl("", "excluded", ""),
+	}
+}
+
+func newReportEntriesTableImpl(schemaName, tableName, alias string) reportEntriesTable {
+	var (
+		IDColumn         = postgres.IntegerColumn("id")
+		ReportIDColumn   = postgres.IntegerColumn("report_id")
+		ReporterIDColumn = postgres.IntegerColumn("reporter_id")
+		ReasonColumn     = postgres.StringColumn("reason")
+		DetailsColumn    = postgres.StringColumn("details")
+		CreatedAtColumn  = postgres.TimestampzColumn("created_at")
+		allColumns       = postgres.ColumnList{IDColumn, ReportIDColumn, ReporterIDColumn, ReasonColumn, DetailsColumn, CreatedAtColumn}
+		mutableColumns   = postgres.ColumnList{ReportIDColumn, ReporterIDColumn, ReasonColumn, DetailsColumn, CreatedAtColumn}
+		defaultColumns   = postgres.ColumnList{IDColumn, CreatedAtColumn}
+	)
+
+	return reportEntriesTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:         IDColumn,
+		ReportID:   ReportIDColumn,
+		ReporterID: ReporterIDColumn,
+		Reason:     ReasonColumn,
+		Details:    DetailsColumn,
+		CreatedAt:  CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
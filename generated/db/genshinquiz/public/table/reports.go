@@ -0,0 +1,111 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var Reports = newReportsTable("public", "reports", "")
+
+type reportsTable struct {
+	postgres.Table
+
+	// Columns
+	ID           postgres.ColumnInteger
+	ReportUUID   postgres.ColumnString
+	TargetType   postgres.ColumnString
+	TargetID     postgres.ColumnInteger
+	TargetUUID   postgres.ColumnString
+	TargetUserID postgres.ColumnInteger
+	Status       postgres.ColumnString
+	ReportCount  postgres.ColumnInteger
+	ResolvedBy   postgres.ColumnInteger
+	ResolvedAt   postgres.ColumnTimestampz
+	CreatedAt    postgres.ColumnTimestampz
+	UpdatedAt    postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type ReportsTable struct {
+	reportsTable
+
+	EXCLUDED reportsTable
+}
+
+// AS creates new ReportsTable with assigned alias
+func (a ReportsTable) AS(alias string) *ReportsTable {
+	return newReportsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new ReportsTable with assigned schema name
+func (a ReportsTable) FromSchema(schemaName string) *ReportsTable {
+	return newReportsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new ReportsTable with assigned table prefix
+func (a ReportsTable) WithPrefix(prefix string) *ReportsTable {
+	return newReportsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new ReportsTable with assigned table suffix
+func (a ReportsTable) WithSuffix(suffix string) *ReportsTable {
+	return newReportsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newReportsTable(schemaName, tableName, alias string) *ReportsTable {
+	return &ReportsTable{
+		reportsTable: newReportsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:     newReportsTableImpl("", "excluded", ""),
+	}
+}
+
+func newReportsTableImpl(schemaName, tableName, alias string) reportsTable {
+	var (
+		IDColumn           = postgres.IntegerColumn("id")
+		ReportUUIDColumn   = postgres.StringColumn("report_uuid")
+		TargetTypeColumn   = postgres.StringColumn("target_type")
+		TargetIDColumn     = postgres.IntegerColumn("target_id")
+		TargetUUIDColumn   = postgres.StringColumn("target_uuid")
+		TargetUserIDColumn = postgres.IntegerColumn("target_user_id")
+		StatusColumn       = postgres.StringColumn("status")
+		ReportCountColumn  = postgres.IntegerColumn("report_count")
+		ResolvedByColumn   = postgres.IntegerColumn("resolved_by")
+		ResolvedAtColumn   = postgres.TimestampzColumn("resolved_at")
+		CreatedAtColumn    = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn    = postgres.TimestampzColumn("updated_at")
+		allColumns         = postgres.ColumnList{IDColumn, ReportUUIDColumn, TargetTypeColumn, TargetIDColumn, TargetUUIDColumn, TargetUserIDColumn, StatusColumn, ReportCountColumn, ResolvedByColumn, ResolvedAtColumn, CreatedAtColumn, UpdatedAtColumn}
+		mutableColumns     = postgres.ColumnList{ReportUUIDColumn, TargetTypeColumn, TargetIDColumn, TargetUUIDColumn, TargetUserIDColumn, StatusColumn, ReportCountColumn, ResolvedByColumn, ResolvedAtColumn, CreatedAtColumn, UpdatedAtColumn}
+		defaultColumns     = postgres.ColumnList{IDColumn, ReportUUIDColumn, StatusColumn, ReportCountColumn, CreatedAtColumn, UpdatedAtColumn}
+	)
+
+	return reportsTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:           IDColumn,
+		ReportUUID:   ReportUUIDColumn,
+		TargetType:   TargetTypeColumn,
+		TargetID:     TargetIDColumn,
+		TargetUUID:   TargetUUIDColumn,
+		TargetUserID: TargetUserIDColumn,
+		Status:       StatusColumn,
+		ReportCount:  ReportCountColumn,
+		ResolvedBy:   ResolvedByColumn,
+		ResolvedAt:   ResolvedAtColumn,
+		CreatedAt:    CreatedAtColumn,
+		UpdatedAt:    UpdatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
@@ -10,12 +10,10 @@ package table
 // UseSchema sets a new schema name for all generated table SQL builder types. It is recommended to invoke
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
-	ExamAnswers = ExamAnswers.FromSchema(schema)
-	ExamAttempts = ExamAttempts.FromSchema(schema)
-	ExamQuestions = ExamQuestions.FromSchema(schema)
-	ExamTranslations = ExamTranslations.FromSchema(schema)
-	Exams = Exams.FromSchema(schema)
 	GooseDbVersion = GooseDbVersion.FromSchema(schema)
+	ModerationLogs = ModerationLogs.FromSchema(schema)
+	OAuthStates = OAuthStates.FromSchema(schema)
+	PollCommentReactions = PollCommentReactions.FromSchema(schema)
 	PollComments = PollComments.FromSchema(schema)
 	PollLikes = PollLikes.FromSchema(schema)
 	PollOptionTranslations = PollOptionTranslations.FromSchema(schema)
@@ -26,17 +24,32 @@ func UseSchema(schema string) {
 	QuestionLikes = QuestionLikes.FromSchema(schema)
 	QuestionOptionTranslations = QuestionOptionTranslations.FromSchema(schema)
 	QuestionOptions = QuestionOptions.FromSchema(schema)
+	QuestionRevisions = QuestionRevisions.FromSchema(schema)
 	QuestionSubmissionOptions = QuestionSubmissionOptions.FromSchema(schema)
 	QuestionSubmissions = QuestionSubmissions.FromSchema(schema)
 	QuestionTranslations = QuestionTranslations.FromSchema(schema)
 	Questions = Questions.FromSchema(schema)
+	QuizAnswers = QuizAnswers.FromSchema(schema)
+	QuizAttempts = QuizAttempts.FromSchema(schema)
+	QuizLikes = QuizLikes.FromSchema(schema)
+	QuizQuestions = QuizQuestions.FromSchema(schema)
+	QuizStats = QuizStats.FromSchema(schema)
+	QuizTranslations = QuizTranslations.FromSchema(schema)
+	Quizzes = Quizzes.FromSchema(schema)
+	ReportEntries = ReportEntries.FromSchema(schema)
+	Reports = Reports.FromSchema(schema)
 	UserCredentials = UserCredentials.FromSchema(schema)
+	UserDataExports = UserDataExports.FromSchema(schema)
 	UserGameAccounts = UserGameAccounts.FromSchema(schema)
 	UserLoginLogs = UserLoginLogs.FromSchema(schema)
 	UserPrivacies = UserPrivacies.FromSchema(schema)
 	UserProfiles = UserProfiles.FromSchema(schema)
+	UserRecoveryCodes = UserRecoveryCodes.FromSchema(schema)
+	UserRefreshTokens = UserRefreshTokens.FromSchema(schema)
+	UserSessions = UserSessions.FromSchema(schema)
 	UserStats = UserStats.FromSchema(schema)
 	UserTokens = UserTokens.FromSchema(schema)
+	UserTwoFactor = UserTwoFactor.FromSchema(schema)
 	UserVotes = UserVotes.FromSchema(schema)
 	Users = Users.FromSchema(schema)
 }
@@ -0,0 +1,105 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var UserDataExports = newUserDataExportsTable("public", "user_data_exports", "")
+
+type userDataExportsTable struct {
+	postgres.Table
+
+	// Columns
+	ID          postgres.ColumnInteger
+	ExportUUID  postgres.ColumnString
+	UserID      postgres.ColumnInteger
+	Format      postgres.ColumnString
+	Status      postgres.ColumnString
+	Data        postgres.ColumnBytea
+	Error       postgres.ColumnString
+	CreatedAt   postgres.ColumnTimestampz
+	CompletedAt postgres.ColumnTimestampz
+	ExpiresAt   postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type UserDataExportsTable struct {
+	userDataExportsTable
+
+	EXCLUDED userDataExportsTable
+}
+
+// AS creates new UserDataExportsTable with assigned alias
+func (a UserDataExportsTable) AS(alias string) *UserDataExportsTable {
+	return newUserDataExportsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new UserDataExportsTable with assigned schema name
+func (a UserDataExportsTable) FromSchema(schemaName string) *UserDataExportsTable {
+	return newUserDataExportsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new UserDataExportsTable with assigned table prefix
+func (a UserDataExportsTable) WithPrefix(prefix string) *UserDataExportsTable {
+	return newUserDataExportsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new UserDataExportsTable with assigned table suffix
+func (a UserDataExportsTable) WithSuffix(suffix string) *UserDataExportsTable {
+	return newUserDataExportsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newUserDataExportsTable(schemaName, tableName, alias string) *UserDataExportsTable {
+	return &UserDataExportsTable{
+		userDataExportsTable: newUserDataExportsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:             newUserDataExportsTableImpl("", "excluded", ""),
+	}
+}
+
+func newUserDataExportsTableImpl(schemaName, tableName, alias string) userDataExportsTable {
+	var (
+		IDColumn          = postgres.IntegerColumn("id")
+		ExportUUIDColumn  = postgres.StringColumn("export_uuid")
+		UserIDColumn      = postgres.IntegerColumn("user_id")
+		FormatColumn      = postgres.StringColumn("format")
+		StatusColumn      = postgres.StringColumn("status")
+		DataColumn        = postgres.ByteaColumn("data")
+		ErrorColumn       = postgres.StringColumn("error")
+		CreatedAtColumn   = postgres.TimestampzColumn("created_at")
+		CompletedAtColumn = postgres.TimestampzColumn("completed_at")
+		ExpiresAtColumn   = postgres.TimestampzColumn("expires_at")
+		allColumns        = postgres.ColumnList{IDColumn, ExportUUIDColumn, UserIDColumn, FormatColumn, StatusColumn, DataColumn, ErrorColumn, CreatedAtColumn, CompletedAtColumn, ExpiresAtColumn}
+		mutableColumns    = postgres.ColumnList{ExportUUIDColumn, UserIDColumn, FormatColumn, StatusColumn, DataColumn, ErrorColumn, CreatedAtColumn, CompletedAtColumn, ExpiresAtColumn}
+		defaultColumns    = postgres.ColumnList{IDColumn, StatusColumn, CreatedAtColumn}
+	)
+
+	return userDataExportsTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:          IDColumn,
+		ExportUUID:  ExportUUIDColumn,
+		UserID:      UserIDColumn,
+		Format:      FormatColumn,
+		Status:      StatusColumn,
+		Data:        DataColumn,
+		Error:       ErrorColumn,
+		CreatedAt:   CreatedAtColumn,
+		CompletedAt: CompletedAtColumn,
+		ExpiresAt:   ExpiresAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
@@ -21,9 +21,10 @@ type userLoginLogsTable struct {
 	UserID         postgres.ColumnInteger
 	IPAddress      postgres.ColumnString
 	UserAgent      postgres.ColumnString
-	CredentialType postgres.ColumnInteger // Login provider: 0=password, 1=google, 2=apple, 3=github
+	CredentialType postgres.ColumnInteger // Login provider: 0=password, 1=google, 2=apple, 3=github, 4=magic_link
 	Status         postgres.ColumnInteger // Login result: 0=success, 1=failed, 2=blocked
 	LoginAt        postgres.ColumnTimestampz
+	SecondFactor   postgres.ColumnInteger // Second factor: NULL=none, 0=totp, 1=recovery_code
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -72,8 +73,9 @@ func newUserLoginLogsTableImpl(schemaName, tableName, alias string) userLoginLog
 		CredentialTypeColumn = postgres.IntegerColumn("credential_type")
 		StatusColumn         = postgres.IntegerColumn("status")
 		LoginAtColumn        = postgres.TimestampzColumn("login_at")
-		allColumns           = postgres.ColumnList{IDColumn, UserIDColumn, IPAddressColumn, UserAgentColumn, CredentialTypeColumn, StatusColumn, LoginAtColumn}
-		mutableColumns       = postgres.ColumnList{UserIDColumn, IPAddressColumn, UserAgentColumn, CredentialTypeColumn, StatusColumn, LoginAtColumn}
+		SecondFactorColumn   = postgres.IntegerColumn("second_factor")
+		allColumns           = postgres.ColumnList{IDColumn, UserIDColumn, IPAddressColumn, UserAgentColumn, CredentialTypeColumn, StatusColumn, LoginAtColumn, SecondFactorColumn}
+		mutableColumns       = postgres.ColumnList{UserIDColumn, IPAddressColumn, UserAgentColumn, CredentialTypeColumn, StatusColumn, LoginAtColumn, SecondFactorColumn}
 		defaultColumns       = postgres.ColumnList{IDColumn, CredentialTypeColumn, StatusColumn, LoginAtColumn}
 	)
 
@@ -88,6 +90,7 @@ func newUserLoginLogsTableImpl(schemaName, tableName, alias string) userLoginLog
 		CredentialType: CredentialTypeColumn,
 		Status:         StatusColumn,
 		LoginAt:        LoginAtColumn,
+		SecondFactor:   SecondFactorColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
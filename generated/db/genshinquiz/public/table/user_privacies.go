@@ -17,13 +17,14 @@ type userPrivaciesTable struct {
 	postgres.Table
 
 	// Columns
-	UserID             postgres.ColumnInteger
-	EmailVisibility    postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
-	BirthdayVisibility postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
-	GenderVisibility   postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
-	CountryVisibility  postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
-	CreatedAt          postgres.ColumnTimestampz
-	UpdatedAt          postgres.ColumnTimestampz
+	UserID                postgres.ColumnInteger
+	EmailVisibility       postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
+	BirthdayVisibility    postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
+	GenderVisibility      postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
+	CountryVisibility     postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
+	CreatedAt             postgres.ColumnTimestampz
+	UpdatedAt             postgres.ColumnTimestampz
+	LeaderboardVisibility postgres.ColumnInteger // Visibility: 0=private, 1=public, 2=friends
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -65,29 +66,31 @@ func newUserPrivaciesTable(schemaName, tableName, alias string) *UserPrivaciesTa
 
 func newUserPrivaciesTableImpl(schemaName, tableName, alias string) userPrivaciesTable {
 	var (
-		UserIDColumn             = postgres.IntegerColumn("user_id")
-		EmailVisibilityColumn    = postgres.IntegerColumn("email_visibility")
-		BirthdayVisibilityColumn = postgres.IntegerColumn("birthday_visibility")
-		GenderVisibilityColumn   = postgres.IntegerColumn("gender_visibility")
-		CountryVisibilityColumn  = postgres.IntegerColumn("country_visibility")
-		CreatedAtColumn          = postgres.TimestampzColumn("created_at")
-		UpdatedAtColumn          = postgres.TimestampzColumn("updated_at")
-		allColumns               = postgres.ColumnList{UserIDColumn, EmailVisibilityColumn, BirthdayVisibilityColumn, GenderVisibilityColumn, CountryVisibilityColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns           = postgres.ColumnList{EmailVisibilityColumn, BirthdayVisibilityColumn, GenderVisibilityColumn, CountryVisibilityColumn, CreatedAtColumn, UpdatedAtColumn}
-		defaultColumns           = postgres.ColumnList{EmailVisibilityColumn, BirthdayVisibilityColumn, GenderVisibilityColumn, CountryVisibilityColumn, CreatedAtColumn, UpdatedAtColumn}
+		UserIDColumn                = postgres.IntegerColumn("user_id")
+		EmailVisibilityColumn       = postgres.IntegerColumn("email_visibility")
+		BirthdayVisibilityColumn    = postgres.IntegerColumn("birthday_visibility")
+		GenderVisibilityColumn      = postgres.IntegerColumn("gender_visibility")
+		CountryVisibilityColumn     = postgres.IntegerColumn("country_visibility")
+		CreatedAtColumn             = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn             = postgres.TimestampzColumn("updated_at")
+		LeaderboardVisibilityColumn = postgres.IntegerColumn("leaderboard_visibility")
+		allColumns                  = postgres.ColumnList{UserIDColumn, EmailVisibilityColumn, BirthdayVisibilityColumn, GenderVisibilityColumn, CountryVisibilityColumn, CreatedAtColumn, UpdatedAtColumn, LeaderboardVisibilityColumn}
+		mutableColumns              = postgres.ColumnList{EmailVisibilityColumn, BirthdayVisibilityColumn, GenderVisibilityColumn, CountryVisibilityColumn, CreatedAtColumn, UpdatedAtColumn, LeaderboardVisibilityColumn}
+		defaultColumns              = postgres.ColumnList{EmailVisibilityColumn, BirthdayVisibilityColumn, GenderVisibilityColumn, CountryVisibilityColumn, CreatedAtColumn, UpdatedAtColumn, LeaderboardVisibilityColumn}
 	)
 
 	return userPrivaciesTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		UserID:             UserIDColumn,
-		EmailVisibility:    EmailVisibilityColumn,
-		BirthdayVisibility: BirthdayVisibilityColumn,
-		GenderVisibility:   GenderVisibilityColumn,
-		CountryVisibility:  CountryVisibilityColumn,
-		CreatedAt:          CreatedAtColumn,
-		UpdatedAt:          UpdatedAtColumn,
+		UserID:                UserIDColumn,
+		EmailVisibility:       EmailVisibilityColumn,
+		BirthdayVisibility:    BirthdayVisibilityColumn,
+		GenderVisibility:      GenderVisibilityColumn,
+		CountryVisibility:     CountryVisibilityColumn,
+		CreatedAt:             CreatedAtColumn,
+		UpdatedAt:             UpdatedAtColumn,
+		LeaderboardVisibility: LeaderboardVisibilityColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -0,0 +1,90 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var UserRecoveryCodes = newUserRecoveryCodesTable("public", "user_recovery_codes", "")
+
+type userRecoveryCodesTable struct {
+	postgres.Table
+
+	// Columns
+	ID        postgres.ColumnInteger
+	UserID    postgres.ColumnInteger
+	CodeHash  postgres.ColumnString
+	UsedAt    postgres.ColumnTimestampz
+	CreatedAt postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type UserRecoveryCodesTable struct {
+	userRecoveryCodesTable
+
+	EXCLUDED userRecoveryCodesTable
+}
+
+// AS creates new UserRecoveryCodesTable with assigned alias
+func (a UserRecoveryCodesTable) AS(alias string) *UserRecoveryCodesTable {
+	return newUserRecoveryCodesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new UserRecoveryCodesTable with assigned schema name
+func (a UserRecoveryCodesTable) FromSchema(schemaName string) *UserRecoveryCodesTable {
+	return newUserRecoveryCodesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new UserRecoveryCodesTable with assigned table prefix
+func (a UserRecoveryCodesTable) WithPrefix(prefix string) *UserRecoveryCodesTable {
+	return newUserRecoveryCodesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new UserRecoveryCodesTable with assigned table suffix
+func (a UserRecoveryCodesTable) WithSuffix(suffix string) *UserRecoveryCodesTable {
+	return newUserRecoveryCodesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newUserRecoveryCodesTable(schemaName, tableName, alias string) *UserRecoveryCodesTable {
+	return &UserRecoveryCodesTable{
+		userRecoveryCodesTable: newUserRecoveryCodesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:               newUserRecoveryCodesTableImpl("", "excluded", ""),
+	}
+}
+
+func newUserRecoveryCodesTableImpl(schemaName, tableName, alias string) userRecoveryCodesTable {
+	var (
+		IDColumn        = postgres.IntegerColumn("id")
+		UserIDColumn    = postgres.IntegerColumn("user_id")
+		CodeHashColumn  = postgres.StringColumn("code_hash")
+		UsedAtColumn    = postgres.TimestampzColumn("used_at")
+		CreatedAtColumn = postgres.TimestampzColumn("created_at")
+		allColumns      = postgres.ColumnList{IDColumn, UserIDColumn, CodeHashColumn, UsedAtColumn, CreatedAtColumn}
+		mutableColumns  = postgres.ColumnList{UserIDColumn, CodeHashColumn, UsedAtColumn, CreatedAtColumn}
+		defaultColumns  = postgres.ColumnList{IDColumn, CreatedAtColumn}
+	)
+
+	return userRecoveryCodesTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		UserID:    UserIDColumn,
+		CodeHash:  CodeHashColumn,
+		UsedAt:    UsedAtColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
@@ -0,0 +1,90 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var UserRefreshTokens = newUserRefreshTokensTable("public", "user_refresh_tokens", "")
+
+type userRefreshTokensTable struct {
+	postgres.Table
+
+	// Columns
+	ID        postgres.ColumnInteger
+	SessionID postgres.ColumnInteger
+	TokenHash postgres.ColumnString
+	CreatedAt postgres.ColumnTimestampz
+	UsedAt    postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type UserRefreshTokensTable struct {
+	userRefreshTokensTable
+
+	EXCLUDED userRefreshTokensTable
+}
+
+// AS creates new UserRefreshTokensTable with assigned alias
+func (a UserRefreshTokensTable) AS(alias string) *UserRefreshTokensTable {
+	return newUserRefreshTokensTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new UserRefreshTokensTable with assigned schema name
+func (a UserRefreshTokensTable) FromSchema(schemaName string) *UserRefreshTokensTable {
+	return newUserRefreshTokensTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new UserRefreshTokensTable with assigned table prefix
+func (a UserRefreshTokensTable) WithPrefix(prefix string) *UserRefreshTokensTable {
+	return newUserRefreshTokensTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new UserRefreshTokensTable with assigned table suffix
+func (a UserRefreshTokensTable) WithSuffix(suffix string) *UserRefreshTokensTable {
+	return newUserRefreshTokensTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newUserRefreshTokensTable(schemaName, tableName, alias string) *UserRefreshTokensTable {
+	return &UserRefreshTokensTable{
+		userRefreshTokensTable: newUserRefreshTokensTableImpl(schemaName, tableName, alias),
+		EXCLUDED:               newUserRefreshTokensTableImpl("", "excluded", ""),
+	}
+}
+
+func newUserRefreshTokensTableImpl(schemaName, tableName, alias string) userRefreshTokensTable {
+	var (
+		IDColumn        = postgres.IntegerColumn("id")
+		SessionIDColumn = postgres.IntegerColumn("session_id")
+		TokenHashColumn = postgres.StringColumn("token_hash")
+		CreatedAtColumn = postgres.TimestampzColumn("created_at")
+		UsedAtColumn    = postgres.TimestampzColumn("used_at")
+		allColumns      = postgres.ColumnList{IDColumn, SessionIDColumn, TokenHashColumn, CreatedAtColumn, UsedAtColumn}
+		mutableColumns  = postgres.ColumnList{SessionIDColumn, TokenHashColumn, CreatedAtColumn, UsedAtColumn}
+		defaultColumns  = postgres.ColumnList{IDColumn, CreatedAtColumn}
+	)
+
+	return userRefreshTokensTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		SessionID: SessionIDColumn,
+		TokenHash: TokenHashColumn,
+		CreatedAt: CreatedAtColumn,
+		UsedAt:    UsedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
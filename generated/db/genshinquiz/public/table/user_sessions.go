@@ -0,0 +1,105 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var UserSessions = newUserSessionsTable("public", "user_sessions", "")
+
+type userSessionsTable struct {
+	postgres.Table
+
+	// Columns
+	ID                postgres.ColumnInteger
+	SessionUUID       postgres.ColumnString
+	UserID            postgres.ColumnInteger
+	LoginLogID        postgres.ColumnInteger
+	CreatedAt         postgres.ColumnTimestampz
+	LastUsedAt        postgres.ColumnTimestampz
+	ExpiresAt         postgres.ColumnTimestampz
+	RevokedAt         postgres.ColumnTimestampz
+	RevokeReason      postgres.ColumnString
+	TwoFactorVerified postgres.ColumnBool
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type UserSessionsTable struct {
+	userSessionsTable
+
+	EXCLUDED userSessionsTable
+}
+
+// AS creates new UserSessionsTable with assigned alias
+func (a UserSessionsTable) AS(alias string) *UserSessionsTable {
+	return newUserSessionsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new UserSessionsTable with assigned schema name
+func (a UserSessionsTable) FromSchema(schemaName string) *UserSessionsTable {
+	return newUserSessionsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new UserSessionsTable with assigned table prefix
+func (a UserSessionsTable) WithPrefix(prefix string) *UserSessionsTable {
+	return newUserSessionsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new UserSessionsTable with assigned table suffix
+func (a UserSessionsTable) WithSuffix(suffix string) *UserSessionsTable {
+	return newUserSessionsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newUserSessionsTable(schemaName, tableName, alias string) *UserSessionsTable {
+	return &UserSessionsTable{
+		userSessionsTable: newUserSessionsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:          newUserSessionsTableImpl("", "excluded", ""),
+	}
+}
+
+func newUserSessionsTableImpl(schemaName, tableName, alias string) userSessionsTable {
+	var (
+		IDColumn                = postgres.IntegerColumn("id")
+		SessionUUIDColumn       = postgres.StringColumn("session_uuid")
+		UserIDColumn            = postgres.IntegerColumn("user_id")
+		LoginLogIDColumn        = postgres.IntegerColumn("login_log_id")
+		CreatedAtColumn         = postgres.TimestampzColumn("created_at")
+		LastUsedAtColumn        = postgres.TimestampzColumn("last_used_at")
+		ExpiresAtColumn         = postgres.TimestampzColumn("expires_at")
+		RevokedAtColumn         = postgres.TimestampzColumn("revoked_at")
+		RevokeReasonColumn      = postgres.StringColumn("revoke_reason")
+		TwoFactorVerifiedColumn = postgres.BoolColumn("two_factor_verified")
+		allColumns              = postgres.ColumnList{IDColumn, SessionUUIDColumn, UserIDColumn, LoginLogIDColumn, CreatedAtColumn, LastUsedAtColumn, ExpiresAtColumn, RevokedAtColumn, RevokeReasonColumn, TwoFactorVerifiedColumn}
+		mutableColumns          = postgres.ColumnList{SessionUUIDColumn, UserIDColumn, LoginLogIDColumn, CreatedAtColumn, LastUsedAtColumn, ExpiresAtColumn, RevokedAtColumn, RevokeReasonColumn, TwoFactorVerifiedColumn}
+		defaultColumns          = postgres.ColumnList{IDColumn, CreatedAtColumn, LastUsedAtColumn, TwoFactorVerifiedColumn}
+	)
+
+	return userSessionsTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:                IDColumn,
+		SessionUUID:       SessionUUIDColumn,
+		UserID:            UserIDColumn,
+		LoginLogID:        LoginLogIDColumn,
+		CreatedAt:         CreatedAtColumn,
+		LastUsedAt:        LastUsedAtColumn,
+		ExpiresAt:         ExpiresAtColumn,
+		RevokedAt:         RevokedAtColumn,
+		RevokeReason:      RevokeReasonColumn,
+		TwoFactorVerified: TwoFactorVerifiedColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
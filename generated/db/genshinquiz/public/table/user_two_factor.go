@@ -0,0 +1,93 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var UserTwoFactor = newUserTwoFactorTable("public", "user_two_factor", "")
+
+type userTwoFactorTable struct {
+	postgres.Table
+
+	// Columns
+	UserID       postgres.ColumnInteger
+	Secret       postgres.ColumnString
+	EnabledAt    postgres.ColumnTimestampz
+	LastUsedStep postgres.ColumnInteger
+	CreatedAt    postgres.ColumnTimestampz
+	UpdatedAt    postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+	DefaultColumns postgres.ColumnList
+}
+
+type UserTwoFactorTable struct {
+	userTwoFactorTable
+
+	EXCLUDED userTwoFactorTable
+}
+
+// AS creates new UserTwoFactorTable with assigned alias
+func (a UserTwoFactorTable) AS(alias string) *UserTwoFactorTable {
+	return newUserTwoFactorTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new UserTwoFactorTable with assigned schema name
+func (a UserTwoFactorTable) FromSchema(schemaName string) *UserTwoFactorTable {
+	return newUserTwoFactorTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new UserTwoFactorTable with assigned table prefix
+func (a UserTwoFactorTable) WithPrefix(prefix string) *UserTwoFactorTable {
+	return newUserTwoFactorTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new UserTwoFactorTable with assigned table suffix
+func (a UserTwoFactorTable) WithSuffix(suffix string) *UserTwoFactorTable {
+	return newUserTwoFactorTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newUserTwoFactorTable(schemaName, tableName, alias string) *UserTwoFactorTable {
+	return &UserTwoFactorTable{
+		userTwoFactorTable: newUserTwoFactorTableImpl(schemaName, tableName, alias),
+		EXCLUDED:           newUserTwoFactorTableImpl("", "excluded", ""),
+	}
+}
+
+func newUserTwoFactorTableImpl(schemaName, tableName, alias string) userTwoFactorTable {
+	var (
+		UserIDColumn       = postgres.IntegerColumn("user_id")
+		SecretColumn       = postgres.StringColumn("secret")
+		EnabledAtColumn    = postgres.TimestampzColumn("enabled_at")
+		LastUsedStepColumn = postgres.IntegerColumn("last_used_step")
+		CreatedAtColumn    = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn    = postgres.TimestampzColumn("updated_at")
+		allColumns         = postgres.ColumnList{UserIDColumn, SecretColumn, EnabledAtColumn, LastUsedStepColumn, CreatedAtColumn, UpdatedAtColumn}
+		mutableColumns     = postgres.ColumnList{SecretColumn, EnabledAtColumn, LastUsedStepColumn, CreatedAtColumn, UpdatedAtColumn}
+		defaultColumns     = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn}
+	)
+
+	return userTwoFactorTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		UserID:       UserIDColumn,
+		Secret:       SecretColumn,
+		EnabledAt:    EnabledAtColumn,
+		LastUsedStep: LastUsedStepColumn,
+		CreatedAt:    CreatedAtColumn,
+		UpdatedAt:    UpdatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+		DefaultColumns: defaultColumns,
+	}
+}
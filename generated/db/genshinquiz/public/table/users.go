@@ -17,20 +17,24 @@ type usersTable struct {
 	postgres.Table
 
 	// Columns
-	ID            postgres.ColumnInteger
-	UserUUID      postgres.ColumnString
-	Email         postgres.ColumnString
-	Nickname      postgres.ColumnString
-	AvatarURL     postgres.ColumnString
-	Biography     postgres.ColumnString
-	Language      postgres.ColumnString  // IETF BCP 47 language tag
-	UserRole      postgres.ColumnInteger // 0=regular user, 1=admin, 2=moderator
-	EmailVerified postgres.ColumnBool
-	Status        postgres.ColumnInteger // 0=active, 1=suspended, 2=deleted
-	DeletedAt     postgres.ColumnTimestampz
-	CreatedIP     postgres.ColumnString
-	CreatedAt     postgres.ColumnTimestampz
-	UpdatedAt     postgres.ColumnTimestampz
+	ID                  postgres.ColumnInteger
+	UserUUID            postgres.ColumnString
+	Email               postgres.ColumnString
+	Nickname            postgres.ColumnString
+	AvatarURL           postgres.ColumnString
+	Biography           postgres.ColumnString
+	Language            postgres.ColumnString  // IETF BCP 47 language tag
+	UserRole            postgres.ColumnInteger // 0=regular user, 1=admin, 2=moderator
+	EmailVerified       postgres.ColumnBool
+	Status              postgres.ColumnInteger // 0=active, 1=suspended, 2=deleted
+	DeletedAt           postgres.ColumnTimestampz
+	CreatedIP           postgres.ColumnString
+	CreatedAt           postgres.ColumnTimestampz
+	UpdatedAt           postgres.ColumnTimestampz
+	SuspendedUntil      postgres.ColumnTimestampz
+	SuspensionReason    postgres.ColumnString
+	DeletionRequestedAt postgres.ColumnTimestampz
+	DeletionScheduledAt postgres.ColumnTimestampz
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -72,43 +76,51 @@ func newUsersTable(schemaName, tableName, alias string) *UsersTable {
 
 func newUsersTableImpl(schemaName, tableName, alias string) usersTable {
 	var (
-		IDColumn            = postgres.IntegerColumn("id")
-		UserUUIDColumn      = postgres.StringColumn("user_uuid")
-		EmailColumn         = postgres.StringColumn("email")
-		NicknameColumn      = postgres.StringColumn("nickname")
-		AvatarURLColumn     = postgres.StringColumn("avatar_url")
-		BiographyColumn     = postgres.StringColumn("biography")
-		LanguageColumn      = postgres.StringColumn("language")
-		UserRoleColumn      = postgres.IntegerColumn("user_role")
-		EmailVerifiedColumn = postgres.BoolColumn("email_verified")
-		StatusColumn        = postgres.IntegerColumn("status")
-		DeletedAtColumn     = postgres.TimestampzColumn("deleted_at")
-		CreatedIPColumn     = postgres.StringColumn("created_ip")
-		CreatedAtColumn     = postgres.TimestampzColumn("created_at")
-		UpdatedAtColumn     = postgres.TimestampzColumn("updated_at")
-		allColumns          = postgres.ColumnList{IDColumn, UserUUIDColumn, EmailColumn, NicknameColumn, AvatarURLColumn, BiographyColumn, LanguageColumn, UserRoleColumn, EmailVerifiedColumn, StatusColumn, DeletedAtColumn, CreatedIPColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns      = postgres.ColumnList{UserUUIDColumn, EmailColumn, NicknameColumn, AvatarURLColumn, BiographyColumn, LanguageColumn, UserRoleColumn, EmailVerifiedColumn, StatusColumn, DeletedAtColumn, CreatedIPColumn, CreatedAtColumn, UpdatedAtColumn}
-		defaultColumns      = postgres.ColumnList{IDColumn, UserUUIDColumn, LanguageColumn, UserRoleColumn, EmailVerifiedColumn, StatusColumn, CreatedAtColumn, UpdatedAtColumn}
+		IDColumn                  = postgres.IntegerColumn("id")
+		UserUUIDColumn            = postgres.StringColumn("user_uuid")
+		EmailColumn               = postgres.StringColumn("email")
+		NicknameColumn            = postgres.StringColumn("nickname")
+		AvatarURLColumn           = postgres.StringColumn("avatar_url")
+		BiographyColumn           = postgres.StringColumn("biography")
+		LanguageColumn            = postgres.StringColumn("language")
+		UserRoleColumn            = postgres.IntegerColumn("user_role")
+		EmailVerifiedColumn       = postgres.BoolColumn("email_verified")
+		StatusColumn              = postgres.IntegerColumn("status")
+		DeletedAtColumn           = postgres.TimestampzColumn("deleted_at")
+		CreatedIPColumn           = postgres.StringColumn("created_ip")
+		CreatedAtColumn           = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn           = postgres.TimestampzColumn("updated_at")
+		SuspendedUntilColumn      = postgres.TimestampzColumn("suspended_until")
+		SuspensionReasonColumn    = postgres.StringColumn("suspension_reason")
+		DeletionRequestedAtColumn = postgres.TimestampzColumn("deletion_requested_at")
+		DeletionScheduledAtColumn = postgres.TimestampzColumn("deletion_scheduled_at")
+		allColumns                = postgres.ColumnList{IDColumn, UserUUIDColumn, EmailColumn, NicknameColumn, AvatarURLColumn, BiographyColumn, LanguageColumn, UserRoleColumn, EmailVerifiedColumn, StatusColumn, DeletedAtColumn, CreatedIPColumn, CreatedAtColumn, UpdatedAtColumn, SuspendedUntilColumn, SuspensionReasonColumn, DeletionRequestedAtColumn, DeletionScheduledAtColumn}
+		mutableColumns            = postgres.ColumnList{UserUUIDColumn, EmailColumn, NicknameColumn, AvatarURLColumn, BiographyColumn, LanguageColumn, UserRoleColumn, EmailVerifiedColumn, StatusColumn, DeletedAtColumn, CreatedIPColumn, CreatedAtColumn, UpdatedAtColumn, SuspendedUntilColumn, SuspensionReasonColumn, DeletionRequestedAtColumn, DeletionScheduledAtColumn}
+		defaultColumns            = postgres.ColumnList{IDColumn, UserUUIDColumn, LanguageColumn, UserRoleColumn, EmailVerifiedColumn, StatusColumn, CreatedAtColumn, UpdatedAtColumn}
 	)
 
 	return usersTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:            IDColumn,
-		UserUUID:      UserUUIDColumn,
-		Email:         EmailColumn,
-		Nickname:      NicknameColumn,
-		AvatarURL:     AvatarURLColumn,
-		Biography:     BiographyColumn,
-		Language:      LanguageColumn,
-		UserRole:      UserRoleColumn,
-		EmailVerified: EmailVerifiedColumn,
-		Status:        StatusColumn,
-		DeletedAt:     DeletedAtColumn,
-		CreatedIP:     CreatedIPColumn,
-		CreatedAt:     CreatedAtColumn,
-		UpdatedAt:     UpdatedAtColumn,
+		ID:                  IDColumn,
+		UserUUID:            UserUUIDColumn,
+		Email:               EmailColumn,
+		Nickname:            NicknameColumn,
+		AvatarURL:           AvatarURLColumn,
+		Biography:           BiographyColumn,
+		Language:            LanguageColumn,
+		UserRole:            UserRoleColumn,
+		EmailVerified:       EmailVerifiedColumn,
+		Status:              StatusColumn,
+		DeletedAt:           DeletedAtColumn,
+		CreatedIP:           CreatedIPColumn,
+		CreatedAt:           CreatedAtColumn,
+		UpdatedAt:           UpdatedAtColumn,
+		SuspendedUntil:      SuspendedUntilColumn,
+		SuspensionReason:    SuspensionReasonColumn,
+		DeletionRequestedAt: DeletionRequestedAtColumn,
+		DeletionScheduledAt: DeletionScheduledAtColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
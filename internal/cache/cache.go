@@ -0,0 +1,218 @@
+// Package cache 读多写少接口的响应缓存，数据存放在 Redis.
+//
+// 缓存条目挂在若干标签下，写操作通过递增标签版本号使相关条目失效.
+// 匿名请求与登录用户的响应包含的个人数据不同（是否点赞、是否作答等），缓存键按访问者隔离.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"genshin-quiz/internal/webserver/middleware"
+	"genshin-quiz/logger"
+
+	"github.com/go-errors/errors"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	keyPrefix  = "cache:"
+	tagPrefix  = "cache_tag:"
+	lockSuffix = ":lock"
+
+	// 未命中时只有一个请求回源，其余请求等待其写入缓存
+	lockTTL      = 5 * time.Second
+	lockWait     = 2 * time.Second
+	lockPollStep = 50 * time.Millisecond
+)
+
+// 缓存标签，写操作按标签失效.
+const (
+	TagQuestions   = "questions"
+	TagPolls       = "polls"
+	TagExams       = "exams"
+	TagLeaderboard = "leaderboard"
+)
+
+// UserTag 用户个人的标签，登录用户读取的条目都挂在自己的标签下.
+// 只改变个人状态（是否作答等）的写操作失效该标签，不影响其他访问者的缓存.
+func UserTag(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+// UserContentTags 用户封禁、解封或注销时，其发布的内容和排行榜都需要失效.
+var UserContentTags = []string{TagQuestions, TagPolls, TagExams, TagLeaderboard}
+
+// Key 生成缓存键，params 为影响结果的全部参数（语言、筛选、分页等）.
+// 键中包含访问者范围，匿名响应与登录用户的响应不会共用.
+func Key(ctx context.Context, name string, params any) string {
+	data, err := json.Marshal(params)
+	if err != nil {
+		// 参数无法序列化时退化为按访问者隔离的固定键，不影响正确性只影响命中率
+		data = []byte(err.Error())
+	}
+	sum := sha256.Sum256(data)
+	return keyPrefix + name + ":" + viewerScope(ctx) + ":" + hex.EncodeToString(sum[:12])
+}
+
+// viewerScope 访问者范围，登录用户按用户 ID 区分.
+func viewerScope(ctx context.Context) string {
+	if userClaims, ok := middleware.GetUserFromContextOnly(ctx); ok {
+		return "u" + strconv.FormatInt(userClaims.UserID, 10)
+	}
+	return "anon"
+}
+
+// GetOrLoad 读取缓存，未命中时调用 load 回源并写入缓存.
+// Redis 未配置或出错时直接回源，缓存故障不影响接口可用性.
+// 同一进程内相同的未命中请求合并为一次回源，跨实例通过 Redis 锁保证只有一个实例回源.
+func GetOrLoad[T any](
+	ctx context.Context,
+	rdb *redis.Client,
+	key string,
+	tags []string,
+	ttl time.Duration,
+	load func(ctx context.Context) (T, error),
+) (T, error) {
+	if rdb == nil {
+		return singleflight(ctx, key, load)
+	}
+	if userClaims, ok := middleware.GetUserFromContextOnly(ctx); ok {
+		tags = append(slices.Clone(tags), UserTag(userClaims.UserID))
+	}
+
+	versionedKey, err := withTagVersions(ctx, rdb, key, tags)
+	if err != nil {
+		logger.L.Warn("Read cache tag versions failed", zap.String("key", key), zap.Error(err))
+		return load(ctx)
+	}
+
+	if value, ok := get[T](ctx, rdb, versionedKey); ok {
+		return value, nil
+	}
+
+	return singleflight(ctx, versionedKey, func(ctx context.Context) (T, error) {
+		return fill(ctx, rdb, versionedKey, ttl, load)
+	})
+}
+
+// Invalidate 使标签下的全部缓存失效，旧条目随 TTL 自然过期.
+// 写操作已经完成，失效失败只记录日志，由 TTL 兜底.
+func Invalidate(ctx context.Context, rdb *redis.Client, tags ...string) {
+	if rdb == nil || len(tags) == 0 {
+		return
+	}
+	pipe := rdb.Pipeline()
+	for _, tag := range tags {
+		pipe.Incr(ctx, tagPrefix+tag)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.L.Warn("Invalidate cache tags failed", zap.Strings("tags", tags), zap.Error(err))
+	}
+}
+
+// withTagVersions 在缓存键后拼接各标签的当前版本号，标签递增后旧键不再被读取.
+func withTagVersions(ctx context.Context, rdb *redis.Client, key string, tags []string) (string, error) {
+	if len(tags) == 0 {
+		return key, nil
+	}
+	tagKeys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagKeys = append(tagKeys, tagPrefix+tag)
+	}
+	versions, err := rdb.MGet(ctx, tagKeys...).Result()
+	if err != nil {
+		return "", errors.WrapPrefix(err, "get cache tag versions failed", 0)
+	}
+
+	parts := make([]string, 0, len(versions))
+	for _, version := range versions {
+		if s, ok := version.(string); ok {
+			parts = append(parts, s)
+		} else {
+			parts = append(parts, "0")
+		}
+	}
+	return key + ":v" + strings.Join(parts, "."), nil
+}
+
+// fill 回源并写入缓存.
+// 拿不到锁说明其他实例正在回源，短暂等待其结果，超时后自行回源.
+func fill[T any](
+	ctx context.Context,
+	rdb *redis.Client,
+	key string,
+	ttl time.Duration,
+	load func(ctx context.Context) (T, error),
+) (T, error) {
+	lockKey := key + lockSuffix
+	locked, err := rdb.SetNX(ctx, lockKey, 1, lockTTL).Result()
+	if err != nil {
+		logger.L.Warn("Acquire cache lock failed", zap.String("key", key), zap.Error(err))
+		return load(ctx)
+	}
+	if !locked {
+		if value, ok := waitFor[T](ctx, rdb, key); ok {
+			return value, nil
+		}
+		return load(ctx)
+	}
+	defer rdb.Del(context.WithoutCancel(ctx), lockKey)
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		logger.L.Warn("Encode cache value failed", zap.String("key", key), zap.Error(err))
+		return value, nil
+	}
+	if err := rdb.Set(ctx, key, data, ttl).Err(); err != nil {
+		logger.L.Warn("Write cache failed", zap.String("key", key), zap.Error(err))
+	}
+	return value, nil
+}
+
+func waitFor[T any](ctx context.Context, rdb *redis.Client, key string) (T, bool) {
+	var zero T
+	deadline := time.Now().Add(lockWait)
+	ticker := time.NewTicker(lockPollStep)
+	defer ticker.Stop()
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return zero, false
+		case <-ticker.C:
+		}
+		if value, ok := get[T](ctx, rdb, key); ok {
+			return value, true
+		}
+	}
+	return zero, false
+}
+
+// get 读取并解码缓存，未命中或出错均视为未命中.
+func get[T any](ctx context.Context, rdb *redis.Client, key string) (T, bool) {
+	var value T
+	data, err := rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			logger.L.Warn("Read cache failed", zap.String("key", key), zap.Error(err))
+		}
+		return value, false
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		logger.L.Warn("Decode cache value failed", zap.String("key", key), zap.Error(err))
+		return value, false
+	}
+	return value, true
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-errors/errors"
+)
+
+type call struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+//nolint:gochecknoglobals // 进程内的回源合并状态
+var (
+	inflightMu sync.Mutex
+	inflight   = map[string]*call{}
+)
+
+// singleflight 合并同一进程内相同键的并发回源，只有第一个请求执行 load.
+// load 使用不可取消的 ctx，避免第一个请求断开导致其他等待者一起失败.
+func singleflight[T any](
+	ctx context.Context,
+	key string,
+	load func(ctx context.Context) (T, error),
+) (T, error) {
+	inflightMu.Lock()
+	if c, exists := inflight[key]; exists {
+		inflightMu.Unlock()
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		value, _ := c.value.(T)
+		return value, c.err
+	}
+	c := &call{done: make(chan struct{}), err: errors.New("cache load did not complete")}
+	inflight[key] = c
+	inflightMu.Unlock()
+
+	// load panic 时也要唤醒等待者
+	defer func() {
+		inflightMu.Lock()
+		delete(inflight, key)
+		inflightMu.Unlock()
+		close(c.done)
+	}()
+
+	value, err := load(context.WithoutCancel(ctx))
+	c.value, c.err = value, err
+	return value, err
+}
@@ -30,6 +30,13 @@ func NewUnauthorizedError(message string) *APIError {
 	}
 }
 
+func NewForbiddenError(message string) *APIError {
+	return &APIError{
+		Code:    http.StatusForbidden,
+		Message: message,
+	}
+}
+
 func NewNotFoundError(message string) *APIError {
 	return &APIError{
 		Code:    http.StatusNotFound,
@@ -37,6 +44,13 @@ func NewNotFoundError(message string) *APIError {
 	}
 }
 
+func NewTooManyRequestsError(message string) *APIError {
+	return &APIError{
+		Code:    http.StatusTooManyRequests,
+		Message: message,
+	}
+}
+
 func NewInternalServerError(message string) *APIError {
 	return &APIError{
 		Code:    http.StatusInternalServerError,
@@ -50,16 +64,36 @@ var (
 	ErrUserNotFound     = NewNotFoundError("用户不存在")
 	ErrQuestionNotFound = NewNotFoundError("问题未找到")
 	ErrPollNotFound     = NewNotFoundError("投票未找到")
+	ErrExamNotFound     = NewNotFoundError("测验未找到")
+	ErrAttemptNotFound  = NewNotFoundError("作答不存在或已过期")
+	ErrRevisionNotFound = NewNotFoundError("修订版本不存在")
+	ErrCommentNotFound  = NewNotFoundError("评论不存在")
+	ErrReportNotFound   = NewNotFoundError("举报不存在")
+	ErrExportNotFound   = NewNotFoundError("导出记录不存在")
+	ErrSessionNotFound  = NewNotFoundError("会话不存在或已失效")
 	// 表单提交错误.
 	ErrUserAlreadyExists    = NewBadRequestError("用户已存在")
 	ErrInvalidLoginProvider = NewBadRequestError("invalid login provider")
+	ErrInvalidExamQuestions = NewBadRequestError("测验题目无效")
+	ErrAttemptExpired       = NewBadRequestError("作答已超时")
+	ErrAttemptSubmitted     = NewBadRequestError("作答已提交")
+	ErrInvalidExamPassword  = NewBadRequestError("测验密码错误")
+	ErrNotEnoughQuestions   = NewBadRequestError("题库中符合条件的题目不足")
 	// 授权类错误.
 	ErrInvalidCredentials = NewUnauthorizedError("邮箱或密码错误")
 	ErrInvalidToken       = NewUnauthorizedError("Invalid or expired token")
 	ErrInvalidTokenFormat = NewUnauthorizedError("Invalid authorization header format")
 	ErrUserNotInContext   = NewUnauthorizedError("用户未登录或认证失败")
 	ErrUserAuthError      = NewUnauthorizedError("用户权限错误")
-	ErrAdminAuthError     = NewUnauthorizedError("Admin access required")
+	ErrPermissionDenied   = NewForbiddenError("无权限操作")
+	ErrExamAccessRequired = NewForbiddenError("需要验证测验密码")
+	ErrAttemptInProgress  = NewForbiddenError("作答尚未结束")
+	ErrAccountSuspended   = NewForbiddenError("账号已被封禁")
+	ErrAccountDeleted     = NewForbiddenError("账号已注销")
+	// 频率限制.
+	ErrTooManyAttempts = NewTooManyRequestsError("尝试次数过多，请稍后再试")
+	ErrRateLimited     = NewTooManyRequestsError("请求过于频繁，请稍后再试")
 	// 服务器错误.
-	ErrDatabaseError = NewInternalServerError("Database error")
+	ErrDatabaseError    = NewInternalServerError("Database error")
+	ErrRedisUnavailable = NewInternalServerError("Redis unavailable")
 )
@@ -5,8 +5,15 @@ import (
 	"time"
 
 	"genshin-quiz/config"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/metrics"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	poll_repo "genshin-quiz/internal/repository/poll"
 	question_repo "genshin-quiz/internal/repository/question"
 	user_repo "genshin-quiz/internal/repository/user"
+	user_services "genshin-quiz/internal/services/user"
+
+	"go.uber.org/zap"
 )
 
 type Cronjob struct {
@@ -19,8 +26,16 @@ func NewCronjob(app *config.App) *Cronjob {
 	}
 }
 
+// Run 执行任务并记录耗时和结果.
+func (c *Cronjob) Run(name string, job func() error) error {
+	start := time.Now()
+	err := job()
+	metrics.ObserveCronJob(name, time.Since(start), err)
+	return err
+}
+
 func (c *Cronjob) RecalibrateUserStats() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
 	defer cancel()
 
 	c.app.Logger.Info("Starting user statistics recalibration...")
@@ -36,7 +51,7 @@ func (c *Cronjob) RecalibrateUserStats() error {
 }
 
 func (c *Cronjob) RecalibrateQuestionStats() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
 	defer cancel()
 
 	c.app.Logger.Info("Starting question statistics recalibration...")
@@ -58,3 +73,156 @@ func (c *Cronjob) RecalibrateQuestionStats() error {
 	c.app.Logger.Info("Question statistics recalibration completed successfully")
 	return nil
 }
+
+func (c *Cronjob) RecalibrateExamStats() error {
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
+	defer cancel()
+
+	c.app.Logger.Info("Starting exam statistics recalibration...")
+
+	// 更新成绩统计（最高分、最短用时、平均分、通过率等）
+	err := exam_repo.RecalculateAllExamStats(ctx, c.app.DB)
+	if err != nil {
+		c.app.Logger.Error("Failed to recalibrate exam stats: " + err.Error())
+		return err
+	}
+
+	c.app.Logger.Info("Exam statistics recalibration completed successfully")
+	return nil
+}
+
+func (c *Cronjob) RecalculateTrendingScores() error {
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
+	defer cancel()
+
+	c.app.Logger.Info("Starting trending score recalculation...")
+
+	// 清零和写入放在同一事务中，首页不会读到中间状态
+	tx, err := c.app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.app.Logger.Error("Failed to begin transaction: " + err.Error())
+		return err
+	}
+	defer tx.Rollback()
+
+	err = exam_repo.RecalculateExamTrendingScores(ctx, tx)
+	if err != nil {
+		c.app.Logger.Error("Failed to recalculate exam trending scores: " + err.Error())
+		return err
+	}
+
+	err = poll_repo.RecalculatePollTrendingScores(ctx, tx)
+	if err != nil {
+		c.app.Logger.Error("Failed to recalculate poll trending scores: " + err.Error())
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.app.Logger.Error("Failed to commit trending scores: " + err.Error())
+		return err
+	}
+	// 热门排序变化
+	cache.Invalidate(ctx, c.app.Redis, cache.TagExams, cache.TagPolls)
+
+	c.app.Logger.Info("Trending score recalculation completed successfully")
+	return nil
+}
+
+// PublishScheduledQuestions 发布审核通过且到达定时发布时间的题目.
+func (c *Cronjob) PublishScheduledQuestions() error {
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
+	defer cancel()
+
+	c.app.Logger.Info("Starting scheduled question publishing...")
+
+	published, err := question_repo.PublishDueQuestions(ctx, c.app.DB, time.Now())
+	if err != nil {
+		c.app.Logger.Error("Failed to publish scheduled questions: " + err.Error())
+		return err
+	}
+	if published > 0 {
+		cache.Invalidate(ctx, c.app.Redis, cache.TagQuestions)
+	}
+
+	c.app.Logger.Info("Scheduled question publishing completed", zap.Int64("published", published))
+	return nil
+}
+
+// LiftExpiredSuspensions 解除已到期的封禁，用户内容重新出现在列表中.
+func (c *Cronjob) LiftExpiredSuspensions() error {
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
+	defer cancel()
+
+	c.app.Logger.Info("Starting expired suspension lifting...")
+
+	lifted, err := user_repo.LiftExpiredSuspensions(ctx, c.app.DB, time.Now())
+	if err != nil {
+		c.app.Logger.Error("Failed to lift expired suspensions: " + err.Error())
+		return err
+	}
+	if lifted > 0 {
+		cache.Invalidate(ctx, c.app.Redis, cache.UserContentTags...)
+	}
+
+	c.app.Logger.Info("Expired suspension lifting completed", zap.Int64("lifted", lifted))
+	return nil
+}
+
+// ProcessAccountDeletions 匿名化冷静期已结束的注销账号.
+func (c *Cronjob) ProcessAccountDeletions() error {
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
+	defer cancel()
+
+	c.app.Logger.Info("Starting account deletion processing...")
+
+	deleted, err := user_services.ProcessAccountDeletions(ctx, c.app, time.Now())
+	if err != nil {
+		c.app.Logger.Error("Failed to process account deletions: " + err.Error())
+		return err
+	}
+
+	c.app.Logger.Info("Account deletion processing completed", zap.Int("deleted", deleted))
+	return nil
+}
+
+// BuildDataExports 生成用户申请的个人数据导出，并清理过期的导出文件.
+func (c *Cronjob) BuildDataExports() error {
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
+	defer cancel()
+
+	c.app.Logger.Info("Starting data export building...")
+
+	built, err := user_services.BuildPendingDataExports(ctx, c.app, time.Now())
+	if err != nil {
+		c.app.Logger.Error("Failed to build data exports: " + err.Error())
+		return err
+	}
+
+	c.app.Logger.Info("Data export building completed", zap.Int("built", built))
+	return nil
+}
+
+// PurgeExpiredSessions 删除过期或已注销超过一天的会话及其刷新令牌，以及过期的第三方授权请求.
+func (c *Cronjob) PurgeExpiredSessions() error {
+	ctx, cancel := context.WithTimeout(c.app.Lifecycle.Context(), 30*time.Minute)
+	defer cancel()
+
+	c.app.Logger.Info("Starting expired session purging...")
+
+	purged, err := user_repo.DeleteExpiredSessions(ctx, c.app.DB, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		c.app.Logger.Error("Failed to purge expired sessions: " + err.Error())
+		return err
+	}
+	purgedStates, err := user_repo.DeleteExpiredOAuthStates(ctx, c.app.DB, time.Now())
+	if err != nil {
+		c.app.Logger.Error("Failed to purge expired oauth states: " + err.Error())
+		return err
+	}
+
+	c.app.Logger.Info("Expired session purging completed",
+		zap.Int64("purged", purged),
+		zap.Int64("oauth_states", purgedStates),
+	)
+	return nil
+}
@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SimpleExam struct {
+	Quiz model.Quizzes
+	User model.Users
+}
+
+type DetailedExam struct {
+	Quiz        model.Quizzes
+	User        model.Users
+	Translation []model.QuizTranslations
+	Questions   []ExamQuestionRow
+}
+
+// ExamQuestionRow 测验中的题目（带题目 UUID）.
+type ExamQuestionRow struct {
+	QuizQuestion model.QuizQuestions
+	QuestionUUID uuid.UUID
+}
+
+type ExamListParams struct {
+	Page       int              // 页码，从1开始
+	NumPerPage int              // 每页数量
+	IsPublic   *bool            // 是否公开
+	Author     *int64           // 创建者
+	Category   *oapi.Category   // 分类过滤
+	Difficulty *oapi.Difficulty // 难度过滤
+	Query      *string          // 关键字搜索（标题）
+	SortBy     string           // 排序方式
+	SortDesc   bool             // 是否降序排列
+}
+
+type ExamListResult struct {
+	Exams []SimpleExam
+	Total int
+}
+
+// ExamAttemptSession 作答会话，保存在 Redis 中，TTL 为测验时限加提交宽限.
+type ExamAttemptSession struct {
+	AttemptUUID uuid.UUID  `json:"attempt_uuid"`
+	QuizID      int64      `json:"quiz_id"`
+	UserID      int64      `json:"user_id"`
+	StartedAt   time.Time  `json:"started_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	QuestionIDs []int64    `json:"question_ids"` // 下发给用户的题目顺序
+	// 打乱用的种子，nil 表示未打乱
+	ShuffleSeed *int64 `json:"shuffle_seed,omitempty"`
+	// 每道题下发给用户的选项顺序
+	OptionOrder map[int64][]int64 `json:"option_order,omitempty"`
+}
+
+// ExamAttemptReviewQuestion 作答回顾中的一道题，Options 按作答时的顺序排列.
+type ExamAttemptReviewQuestion struct {
+	Question           model.Questions
+	Translation        []model.QuestionTranslations
+	Options            []model.QuestionOptions
+	OptionTranslations map[int64]oapi.LocalizedText
+	Answer             *model.QuizAnswers // 超时未提交时为 nil
+	MaxPoints          int32
+}
+
+type ExamLeaderboardRow struct {
+	Rank     int
+	Attempt  model.QuizAttempts
+	User     model.Users
+	Duration int32 // 用时（秒）
+}
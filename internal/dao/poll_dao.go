@@ -37,3 +37,17 @@ type PollListResult struct {
 	Polls []SimplePoll
 	Total int
 }
+
+// PollComment 投票评论及其作者，作者注销后为空.
+type PollComment struct {
+	Comment model.PollComments
+	User    *model.Users
+}
+
+// PollCommentReaction 评论某种表情的回应数，Reacted 表示当前用户是否已回应.
+type PollCommentReaction struct {
+	CommentID int64  `alias:"poll_comment_reactions.comment_id"`
+	Reaction  string `alias:"poll_comment_reactions.reaction"`
+	Count     int64  `alias:"count"`
+	Reacted   bool   `alias:"reacted"`
+}
@@ -23,17 +23,18 @@ type DetailedQuestion struct {
 }
 
 type QuestionListParams struct {
-	Page        int                // 页码，从1开始
-	NumPerPage  int                // 每页数量
-	IsPublic    *bool              // 是否公开
-	IsPublished *bool              // 是否已公开
-	Author      *int64             // 创建者
-	Category    *oapi.Category     // 分类过滤，空字符串表示不过滤
-	Difficulty  *[]oapi.Difficulty // 难度过滤，空字符串表示不过滤
-	Query       *string            // 关键字搜索，空字符串表示不搜索
-	Language    *[]string          // 支持语言，默认 'zh-CN'
-	SortBy      string             // 排序方式
-	SortDesc    bool               // 是否降序排列，默认false（升序）
+	Page        int                   // 页码，从1开始
+	NumPerPage  int                   // 每页数量
+	IsPublic    *bool                 // 是否公开
+	IsPublished *bool                 // 是否已公开
+	Status      *model.QuestionStatus // 审核状态
+	Author      *int64                // 创建者
+	Category    *oapi.Category        // 分类过滤，空字符串表示不过滤
+	Difficulty  *[]oapi.Difficulty    // 难度过滤，空字符串表示不过滤
+	Query       *string               // 关键字搜索，空字符串表示不搜索
+	Language    *[]string             // 支持语言，默认 'zh-CN'
+	SortBy      string                // 排序方式
+	SortDesc    bool                  // 是否降序排列，默认false（升序）
 }
 
 type QuestionListResult struct {
@@ -48,6 +49,14 @@ type SubmissionWithUserName struct {
 	TimeTaken *int32
 	UserName  string
 	UserID    uuid.UUID
+	Revision  *int32 // 作答时的修订版本
+}
+
+// QuestionRevision 题目修订版本，Snapshot 为该版本的完整内容.
+type QuestionRevision struct {
+	Revision model.QuestionRevisions
+	Author   model.Users
+	Snapshot oapi.CreateQuestionRequest
 }
 
 // const (
@@ -56,3 +65,9 @@ type SubmissionWithUserName struct {
 // 	SortByLikes      SortBy = "likes"
 // 	SortByText       SortBy = "text"
 // )
+
+// QuestionComment 评论及其作者.
+type QuestionComment struct {
+	Comment model.QuestionComments
+	User    model.Users
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+)
+
+// Report 举报及被举报内容的作者，举报用户时作者即用户本人.
+type Report struct {
+	Report     model.Reports
+	TargetUser *model.Users
+}
+
+// ReportReasonCount 举报中各理由的次数.
+type ReportReasonCount struct {
+	Reason model.ReportReason
+	Count  int64
+}
@@ -0,0 +1,119 @@
+package transformer
+
+import (
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/dao"
+
+	"github.com/google/uuid"
+)
+
+// DeletedCommentContent 已删除评论的占位内容，回复仍然保留.
+const DeletedCommentContent = "[deleted]"
+
+// ToQuestionComment 转换单条评论，回复在 BuildCommentTree 中组装.
+// 已删除的评论只返回占位内容，不返回作者；隐藏的评论不返回内容.
+func ToQuestionComment(
+	row dao.QuestionComment,
+	parentUUID *uuid.UUID,
+	hidden bool,
+) oapi.Comment {
+	dto := oapi.Comment{
+		Id:        row.Comment.CommentUUID,
+		ParentId:  parentUUID,
+		Spoiler:   row.Comment.IsSpoiler,
+		Deleted:   row.Comment.DeletedAt != nil,
+		Edited:    row.Comment.UpdatedAt.After(row.Comment.CreatedAt),
+		CreatedAt: row.Comment.CreatedAt,
+		Replies:   []oapi.Comment{},
+	}
+	if dto.Deleted {
+		dto.Content = DeletedCommentContent
+		return dto
+	}
+
+	user := ToUserBase(row.User)
+	dto.User = &user
+	if dto.Edited {
+		dto.UpdatedAt = &row.Comment.UpdatedAt
+	}
+	if hidden {
+		dto.Hidden = true
+	} else {
+		dto.Content = row.Comment.Comment
+	}
+	return dto
+}
+
+// ToPollComment 转换单条投票评论，作者注销后不返回作者.
+// reactions 为该评论的表情回应，已删除的评论不返回.
+func ToPollComment(
+	row dao.PollComment,
+	parentUUID *uuid.UUID,
+	reactions []dao.PollCommentReaction,
+) oapi.Comment {
+	dto := oapi.Comment{
+		Id:        row.Comment.CommentUUID,
+		ParentId:  parentUUID,
+		Deleted:   row.Comment.DeletedAt != nil,
+		Edited:    row.Comment.UpdatedAt.After(row.Comment.CreatedAt),
+		CreatedAt: row.Comment.CreatedAt,
+		Replies:   []oapi.Comment{},
+	}
+	if dto.Deleted {
+		dto.Content = DeletedCommentContent
+		return dto
+	}
+
+	if row.User != nil {
+		user := ToUserBase(*row.User)
+		dto.User = &user
+	}
+	if dto.Edited {
+		dto.UpdatedAt = &row.Comment.UpdatedAt
+	}
+	dto.Content = row.Comment.Content
+
+	reactionDTOs := make([]oapi.CommentReaction, 0, len(reactions))
+	for _, r := range reactions {
+		reactionDTOs = append(reactionDTOs, oapi.CommentReaction{
+			Reaction: oapi.CommentReactionType(r.Reaction),
+			Count:    int(r.Count),
+			Reacted:  r.Reacted,
+		})
+	}
+	dto.Reactions = &reactionDTOs
+	return dto
+}
+
+// BuildCommentTree 按 parent_id 组装楼中楼，同一层级保持输入顺序.
+// 已删除且没有回复的评论不返回.
+func BuildCommentTree(comments []oapi.Comment) []oapi.Comment {
+	present := make(map[uuid.UUID]bool, len(comments))
+	for _, c := range comments {
+		present[c.Id] = true
+	}
+	children := make(map[uuid.UUID][]oapi.Comment)
+	roots := make([]oapi.Comment, 0)
+	for _, c := range comments {
+		if c.ParentId != nil && present[*c.ParentId] {
+			children[*c.ParentId] = append(children[*c.ParentId], c)
+			continue
+		}
+		roots = append(roots, c)
+	}
+
+	var attach func(nodes []oapi.Comment) []oapi.Comment
+	attach = func(nodes []oapi.Comment) []oapi.Comment {
+		result := make([]oapi.Comment, 0, len(nodes))
+		for _, node := range nodes {
+			node.Replies = attach(children[node.Id])
+			if node.Deleted && len(node.Replies) == 0 {
+				continue
+			}
+			result = append(result, node)
+		}
+		return result
+	}
+
+	return attach(roots)
+}
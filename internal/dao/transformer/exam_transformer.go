@@ -0,0 +1,214 @@
+package transformer
+
+import (
+	"sort"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/dao"
+
+	"github.com/google/uuid"
+)
+
+// ConvertSimpleToExam 将 SimpleExam（不包含题目）转换为 OAPI.
+func ConvertSimpleToExam(
+	res dao.SimpleExam,
+	trans []model.QuizTranslations,
+	userLikeStatus int16,
+) oapi.Exam {
+	title, description := buildExamTexts(trans)
+	passwordProtected := res.Quiz.AccessCredentials != nil
+	shuffle := res.Quiz.Shuffle
+
+	return oapi.Exam{
+		Categories:        toCategories(res.Quiz.Categories),
+		CreatedAt:         res.Quiz.CreatedAt,
+		CreatedBy:         res.User.UserUUID,
+		Description:       &description,
+		Difficulty:        oapi.Difficulty(res.Quiz.Difficulty),
+		Id:                res.Quiz.QuizUUID,
+		LikeStatus:        oapi.LikeStatus(userLikeStatus),
+		LikesCount:        int(res.Quiz.LikesCount),
+		Public:            res.Quiz.Public,
+		PasswordProtected: &passwordProtected,
+		Questions:         nil, // 简单模式不返回题目
+		Shuffle:           &shuffle,
+		TimeLimit:         timeLimitOrZero(res.Quiz.TimeLimit),
+		Title:             title,
+		UpdatedAt:         res.Quiz.UpdatedAt,
+	}
+}
+
+func ConvertDetailToExam(
+	res dao.DetailedExam,
+	userLikeStatus int16,
+) oapi.Exam {
+	title, description := buildExamTexts(res.Translation)
+	passwordProtected := res.Quiz.AccessCredentials != nil
+	shuffle := res.Quiz.Shuffle
+
+	questions := make([]oapi.ExamQuestion, 0, len(res.Questions))
+	for _, q := range res.Questions {
+		questions = append(questions, ToExamQuestion(q))
+	}
+
+	return oapi.Exam{
+		Categories:        toCategories(res.Quiz.Categories),
+		CreatedAt:         res.Quiz.CreatedAt,
+		CreatedBy:         res.User.UserUUID,
+		Description:       &description,
+		Difficulty:        oapi.Difficulty(res.Quiz.Difficulty),
+		Id:                res.Quiz.QuizUUID,
+		LikeStatus:        oapi.LikeStatus(userLikeStatus),
+		LikesCount:        int(res.Quiz.LikesCount),
+		Public:            res.Quiz.Public,
+		PasswordProtected: &passwordProtected,
+		Questions:         questions,
+		Shuffle:           &shuffle,
+		TimeLimit:         timeLimitOrZero(res.Quiz.TimeLimit),
+		Title:             title,
+		UpdatedAt:         res.Quiz.UpdatedAt,
+	}
+}
+
+func ToExamQuestion(row dao.ExamQuestionRow) oapi.ExamQuestion {
+	order := float32(row.QuizQuestion.QuestionOrder)
+	points := float32(row.QuizQuestion.Points)
+	questionID := row.QuestionUUID
+
+	return oapi.ExamQuestion{
+		OrderIndex: &order,
+		Points:     &points,
+		QuestionId: &questionID,
+	}
+}
+
+func buildExamTexts(trans []model.QuizTranslations) (oapi.LocalizedText, oapi.LocalizedText) {
+	title := make(oapi.LocalizedText)
+	description := make(oapi.LocalizedText)
+	for _, t := range trans {
+		title[t.Language] = t.Title
+		if t.Description != nil {
+			description[t.Language] = *t.Description
+		}
+	}
+	return title, description
+}
+
+func toCategories(values []string) []oapi.Category {
+	categories := make([]oapi.Category, 0, len(values))
+	for _, v := range values {
+		categories = append(categories, oapi.Category(v))
+	}
+	return categories
+}
+
+// timeLimitOrZero 0 表示不限时.
+func timeLimitOrZero(limit *int32) int {
+	if limit == nil {
+		return 0
+	}
+	return int(*limit)
+}
+
+// ToExamLeaderboardEntry 关闭排行榜可见性的用户已在查询时排除.
+func ToExamLeaderboardEntry(row dao.ExamLeaderboardRow) oapi.ExamLeaderboardEntry {
+	return oapi.ExamLeaderboardEntry{
+		Rank:        row.Rank,
+		User:        ToUserBase(row.User),
+		TotalScore:  int(row.Attempt.TotalScore),
+		MaxScore:    int(row.Attempt.MaxScore),
+		TimeSpent:   int(row.Duration),
+		SubmittedAt: row.Attempt.CreatedAt,
+	}
+}
+
+// ToExamAttemptReviewQuestion 作答回顾，文本只返回指定语言（缺失时回退到其他语言）.
+func ToExamAttemptReviewQuestion(
+	row dao.ExamAttemptReviewQuestion,
+	language string,
+) oapi.ExamAttemptReviewQuestion {
+	questionText := make(oapi.LocalizedText)
+	explanation := make(oapi.LocalizedText)
+	for _, t := range row.Translation {
+		questionText[t.Language] = t.QuestionText
+		if t.Explanation != nil {
+			explanation[t.Language] = *t.Explanation
+		}
+	}
+
+	// 已提交的作答以判分时记录的正确选项为准，超时未提交的按当前答案展示
+	var gradedCorrect map[int64]bool
+	if row.Answer != nil && row.Answer.CorrectOptionIds != nil {
+		gradedCorrect = make(map[int64]bool, len(*row.Answer.CorrectOptionIds))
+		for _, id := range *row.Answer.CorrectOptionIds {
+			gradedCorrect[id] = true
+		}
+	}
+
+	optionUUIDs := make(map[int64]uuid.UUID, len(row.Options))
+	options := make([]oapi.ExamAttemptReviewOption, 0, len(row.Options))
+	correctIDs := make([]uuid.UUID, 0)
+	for _, opt := range row.Options {
+		optionUUIDs[opt.ID] = opt.OptionUUID
+		options = append(options, oapi.ExamAttemptReviewOption{
+			Id:       opt.OptionUUID,
+			Text:     pickLanguage(row.OptionTranslations[opt.ID], language),
+			MediaUrl: opt.ImgURL,
+		})
+		isAnswer := opt.IsAnswer
+		if gradedCorrect != nil {
+			isAnswer = gradedCorrect[opt.ID]
+		}
+		if isAnswer {
+			correctIDs = append(correctIDs, opt.OptionUUID)
+		}
+	}
+
+	dto := oapi.ExamAttemptReviewQuestion{
+		QuestionId:        row.Question.QuestionUUID,
+		QuestionType:      oapi.QuestionType(row.Question.QuestionType),
+		QuestionText:      pickLanguage(questionText, language),
+		Options:           options,
+		SelectedOptionIds: []uuid.UUID{},
+		CorrectOptionIds:  correctIDs,
+		MaxPoints:         int(row.MaxPoints),
+	}
+	if text := pickLanguage(explanation, language); text != "" {
+		dto.Explanation = &text
+	}
+
+	if row.Answer != nil {
+		dto.Correct = row.Answer.IsCorrect
+		dto.Points = int(row.Answer.Points)
+		if row.Answer.TimeTaken != nil {
+			timeTaken := int(*row.Answer.TimeTaken)
+			dto.TimeTaken = &timeTaken
+		}
+		if row.Answer.SelectedOptionIds != nil {
+			for _, id := range *row.Answer.SelectedOptionIds {
+				if optionUUID, ok := optionUUIDs[id]; ok {
+					dto.SelectedOptionIds = append(dto.SelectedOptionIds, optionUUID)
+				}
+			}
+		}
+	}
+
+	return dto
+}
+
+// pickLanguage 取指定语言的文本，缺失时按语言代码排序取第一个.
+func pickLanguage(texts oapi.LocalizedText, language string) string {
+	if text, ok := texts[language]; ok {
+		return text
+	}
+	languages := make([]string, 0, len(texts))
+	for lang := range texts {
+		languages = append(languages, lang)
+	}
+	if len(languages) == 0 {
+		return ""
+	}
+	sort.Strings(languages)
+	return texts[languages[0]]
+}
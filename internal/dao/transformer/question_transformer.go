@@ -16,6 +16,8 @@ func ConvertSimpleToQuestion(
 	correct := int(res.Question.CorrectCount)
 	likes := int(res.Question.Likes)
 	likeStatus := oapi.LikeStatus(userLikeStatus)
+	revision := int(res.Question.CurrentRevision)
+	status := oapi.QuestionStatus(res.Question.Status)
 
 	mappedStr := oapi.LocalizedText{}
 	for _, q := range trans {
@@ -37,7 +39,10 @@ func ConvertSimpleToQuestion(
 		Public:              res.Question.Public,
 		QuestionText:        mappedStr,
 		QuestionType:        oapi.QuestionType(res.Question.QuestionType),
+		Revision:            &revision,
+		ReviewReason:        res.Question.ReviewReason,
 		Solved:              solved,
+		Status:              &status,
 	}
 }
 
@@ -50,6 +55,8 @@ func ConvertDetailToQuestion(
 	correct := int(res.Question.CorrectCount)
 	likes := int(res.Question.Likes)
 	likeStatus := oapi.LikeStatus(userLikeStatus)
+	revision := int(res.Question.CurrentRevision)
+	status := oapi.QuestionStatus(res.Question.Status)
 
 	// 构建标题
 	questionText := make(oapi.LocalizedText)
@@ -86,7 +93,10 @@ func ConvertDetailToQuestion(
 		Public:              res.Question.Public,
 		QuestionText:        questionText,
 		QuestionType:        oapi.QuestionType(res.Question.QuestionType),
+		Revision:            &revision,
+		ReviewReason:        res.Question.ReviewReason,
 		Solved:              solved,
+		Status:              &status,
 	}
 }
 
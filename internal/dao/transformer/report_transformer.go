@@ -0,0 +1,33 @@
+package transformer
+
+import (
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/dao"
+)
+
+func ToReport(row dao.Report, reasons []dao.ReportReasonCount) oapi.Report {
+	reasonDTOs := make([]oapi.ReportReasonCount, 0, len(reasons))
+	for _, r := range reasons {
+		reasonDTOs = append(reasonDTOs, oapi.ReportReasonCount{
+			Reason: oapi.ReportReason(r.Reason),
+			Count:  int(r.Count),
+		})
+	}
+
+	dto := oapi.Report{
+		Id:          row.Report.ReportUUID,
+		TargetType:  oapi.ReportTargetType(row.Report.TargetType),
+		TargetId:    row.Report.TargetUUID,
+		Status:      oapi.ReportStatus(row.Report.Status),
+		ReportCount: int(row.Report.ReportCount),
+		Reasons:     reasonDTOs,
+		CreatedAt:   row.Report.CreatedAt,
+		UpdatedAt:   row.Report.UpdatedAt,
+		ResolvedAt:  row.Report.ResolvedAt,
+	}
+	if row.TargetUser != nil {
+		user := ToUserBase(*row.TargetUser)
+		dto.TargetUser = &user
+	}
+	return dto
+}
@@ -1,6 +1,8 @@
 package transformer
 
 import (
+	"encoding/json"
+
 	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/oapi"
 	"genshin-quiz/logger"
@@ -74,6 +76,8 @@ func UserModelToPrivate(
 		birthday = &types.Date{Time: *profile.Birthday}
 	}
 
+	leaderboardVisibility := visibilityToDTO(privacies.LeaderboardVisibility)
+
 	return oapi.UserPrivate{
 		Uuid:         user.UserUUID,
 		Nickname:     nickName,
@@ -94,6 +98,8 @@ func UserModelToPrivate(
 		EmailVisibility:    visibilityToDTO(privacies.EmailVisibility),
 		GenderVisibility:   visibilityToDTO(privacies.GenderVisibility),
 
+		LeaderboardVisibility: &leaderboardVisibility,
+
 		EmailVerified: user.EmailVerified,
 
 		QuestionsCreated: int(stats.QuestionsCreated),
@@ -101,6 +107,8 @@ func UserModelToPrivate(
 		CorrectAnswers:   int(stats.CorrectSubmissions),
 		PollsCreated:     int(stats.PollsCreated),
 		LikesReceived:    int(stats.LikesReceived),
+
+		DeletionScheduledAt: user.DeletionScheduledAt,
 	}
 }
 
@@ -152,3 +160,55 @@ func UserModelToPublic(
 		LikesReceived:    int(stats.LikesReceived),
 	}
 }
+
+// ToUserBase 评论、排行榜等处展示的用户基本信息.
+func ToUserBase(user model.Users) oapi.UserBase {
+	avatarURL := ""
+	if user.AvatarURL != nil {
+		avatarURL = *user.AvatarURL
+	}
+	bio := ""
+	if user.Biography != nil {
+		bio = *user.Biography
+	}
+	return oapi.UserBase{
+		Uuid:         user.UserUUID,
+		Nickname:     user.Nickname,
+		AvatarUrl:    avatarURL,
+		Bio:          bio,
+		RegisteredAt: user.CreatedAt,
+	}
+}
+
+func ToDataExport(export model.UserDataExports) oapi.DataExport {
+	return oapi.DataExport{
+		Id:          export.ExportUUID,
+		Format:      oapi.DataExportFormat(export.Format),
+		Status:      oapi.DataExportStatus(export.Status),
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+}
+
+// ToLinkedIdentity 第三方账号的邮箱取自保存的资料，密码登录的标识即邮箱.
+func ToLinkedIdentity(cred model.UserCredentials) oapi.LinkedIdentity {
+	identity := oapi.LinkedIdentity{
+		Provider:   cred.IdentityType,
+		Identifier: cred.Identifier,
+		LinkedAt:   cred.CreatedAt,
+	}
+	if cred.IdentityType == "password" {
+		identity.Email = &cred.Identifier
+		return identity
+	}
+	if cred.ExtraData != nil {
+		var profile struct {
+			Email string `json:"email"`
+		}
+		if err := json.Unmarshal([]byte(*cred.ExtraData), &profile); err == nil && profile.Email != "" {
+			identity.Email = &profile.Email
+		}
+	}
+	return identity
+}
@@ -27,6 +27,8 @@ type UpdateUserPrivaciesParams struct {
 	BirthdayVisibility *int16
 	GenderVisibility   *int16
 	CountryVisibility  *int16
+	// 测验排行榜中是否显示
+	LeaderboardVisibility *int16
 }
 
 type UpdateUserParams struct {
@@ -49,3 +51,15 @@ type LeaderboardRow struct {
 	Stats   model.UserStats
 	Total   int
 }
+
+// RefreshTokenSession 刷新令牌及其所属会话.
+type RefreshTokenSession struct {
+	Token   model.UserRefreshTokens
+	Session model.UserSessions
+}
+
+// UserSession 会话及登录时记录的 IP 和 User-Agent.
+type UserSession struct {
+	Session  model.UserSessions
+	LoginLog *model.UserLoginLogs
+}
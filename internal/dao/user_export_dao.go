@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDataExport 个人数据导出内容，ZIP 格式下每个字段对应一个文件.
+// 不包含密码哈希、第三方凭证等认证信息.
+type UserDataExport struct {
+	Profile      ExportProfile       `json:"profile"`
+	Submissions  []ExportSubmission  `json:"submissions"`
+	Votes        []ExportVote        `json:"votes"`
+	Likes        []ExportLike        `json:"likes"`
+	Comments     []ExportComment     `json:"comments"`
+	LoginHistory []ExportLoginRecord `json:"login_history"`
+}
+
+type ExportProfile struct {
+	UUID          uuid.UUID  `json:"uuid"`
+	Email         string     `json:"email"`
+	Nickname      string     `json:"nickname"`
+	AvatarURL     *string    `json:"avatar_url,omitempty"`
+	Biography     *string    `json:"bio,omitempty"`
+	Language      string     `json:"language"`
+	EmailVerified bool       `json:"email_verified"`
+	RegisteredIP  *string    `json:"registered_ip,omitempty"`
+	RegisteredAt  time.Time  `json:"registered_at"`
+	Gender        int16      `json:"gender"`
+	Country       *string    `json:"country,omitempty"`
+	Timezone      *string    `json:"timezone,omitempty"`
+	Birthday      *time.Time `json:"birthday,omitempty"`
+	Website       *string    `json:"website,omitempty"`
+	Twitter       *string    `json:"twitter,omitempty"`
+	Discord       *string    `json:"discord,omitempty"`
+	// 隐私设置
+	EmailVisibility       int16 `json:"email_visibility"`
+	BirthdayVisibility    int16 `json:"birthday_visibility"`
+	GenderVisibility      int16 `json:"gender_visibility"`
+	CountryVisibility     int16 `json:"country_visibility"`
+	LeaderboardVisibility int16 `json:"leaderboard_visibility"`
+}
+
+type ExportSubmission struct {
+	QuestionUUID uuid.UUID `alias:"questions.question_uuid" json:"question_id"`
+	IsCorrect    bool      `alias:"question_submissions.is_correct" json:"is_correct"`
+	IsPractice   bool      `alias:"question_submissions.is_practice" json:"is_practice"`
+	TimeTaken    *int32    `alias:"question_submissions.time_taken" json:"time_taken,omitempty"`
+	CreatedAt    time.Time `alias:"question_submissions.created_at" json:"created_at"`
+}
+
+type ExportVote struct {
+	PollUUID   uuid.UUID `alias:"polls.poll_uuid" json:"poll_id"`
+	OptionUUID uuid.UUID `alias:"poll_options.option_uuid" json:"option_id"`
+	VoteCount  int32     `alias:"user_votes.vote_count" json:"vote_count"`
+	CreatedAt  time.Time `alias:"user_votes.created_at" json:"created_at"`
+}
+
+// ExportLike 点赞或点踩记录，TargetType 为 question、poll 或 exam.
+type ExportLike struct {
+	TargetType string    `json:"target_type"`
+	TargetUUID uuid.UUID `json:"target_id"`
+	Value      int16     `json:"value"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExportComment 评论记录，TargetType 为 question 或 poll，已删除的评论也会导出.
+type ExportComment struct {
+	TargetType  string     `json:"target_type"`
+	TargetUUID  uuid.UUID  `json:"target_id"`
+	CommentUUID uuid.UUID  `json:"comment_id"`
+	Content     string     `json:"content"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+type ExportLoginRecord struct {
+	IPAddress string    `json:"ip_address"`
+	UserAgent *string   `json:"user_agent,omitempty"`
+	Provider  string    `json:"provider"`
+	Status    string    `json:"status"`
+	LoginAt   time.Time `json:"login_at"`
+}
@@ -26,6 +26,55 @@ func (t Environment) String() string {
 	return string(t)
 }
 
+type UserRole int16
+
+const (
+	UserRoleUser      UserRole = 0
+	UserRoleAdmin     UserRole = 1
+	UserRoleModerator UserRole = 2
+)
+
+// Permission 权限，由角色映射得到，格式为 资源:操作.
+type Permission string
+
+const (
+	PermQuestionEdit      Permission = "question:edit_any"
+	PermQuestionDelete    Permission = "question:delete_any"
+	PermQuestionPurge     Permission = "question:purge"
+	PermQuestionPublish   Permission = "question:publish"
+	PermCommentModerate   Permission = "comment:moderate"
+	PermPollDelete        Permission = "poll:delete_any"
+	PermReportReview      Permission = "report:review"
+	PermUserSuspend       Permission = "user:suspend"
+	PermExamAttemptReview Permission = "exam:review_any_attempt"
+)
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+type UserStatus int16
+
+const (
+	UserStatusActive    UserStatus = 0
+	UserStatusSuspended UserStatus = 1
+	UserStatusDeleted   UserStatus = 2
+)
+
+// DeletedContentPolicy 注销账号后其发布的题目和投票如何处理.
+type DeletedContentPolicy string
+
+const (
+	// DeletedContentKeep 保留内容，作者改为占位账号.
+	DeletedContentKeep DeletedContentPolicy = "keep"
+	// DeletedContentRemove 题目软删除，投票隐藏.
+	DeletedContentRemove DeletedContentPolicy = "remove"
+)
+
+func (t DeletedContentPolicy) String() string {
+	return string(t)
+}
+
 type LoginStatus int16
 
 const (
@@ -34,10 +83,22 @@ const (
 	LoginStatusBlocked LoginStatus = 2
 )
 
+// SecondFactor 两步验证使用的方式，记录在登录日志中.
+type SecondFactor int16
+
+const (
+	SecondFactorTOTP         SecondFactor = 0
+	SecondFactorRecoveryCode SecondFactor = 1
+)
+
 type LoginProvider string
 
 const (
-	LoginPassword LoginProvider = "password"
+	LoginPassword  LoginProvider = "password"
+	LoginGoogle    LoginProvider = "google"
+	LoginApple     LoginProvider = "apple"
+	LoginGithub    LoginProvider = "github"
+	LoginMagicLink LoginProvider = "magic_link"
 )
 
 type LeaderboardSortBy string
@@ -0,0 +1,222 @@
+// Package health 就绪检查，逐项检查依赖并汇总为一份报告.
+package health
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"genshin-quiz/config"
+
+	"github.com/go-errors/errors"
+)
+
+// 组件及整体状态.
+const (
+	StatusOK           = "ok"
+	StatusFail         = "fail"
+	StatusDegraded     = "degraded"
+	StatusDisabled     = "disabled"
+	StatusShuttingDown = "shutting_down"
+)
+
+const (
+	// 探针频繁调用时复用最近一次结果，避免压垮数据库
+	reportTTL    = 2 * time.Second
+	checkTimeout = 2 * time.Second
+)
+
+// currentMigrationVersionQuery 与 goose 的 GetDBVersion 一致：goose 回滚时追加 is_applied = false 的记录，
+// 每个版本以最新一条记录为准，取仍处于已执行状态的最大版本.
+const currentMigrationVersionQuery = `
+SELECT COALESCE(MAX(version_id), 0)
+FROM (
+	SELECT DISTINCT ON (version_id) version_id, is_applied
+	FROM goose_db_version
+	ORDER BY version_id, id DESC
+) latest
+WHERE is_applied`
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_.+\.(sql|go)$`)
+
+type Component struct {
+	Status    string         `json:"status"`
+	LatencyMs float64        `json:"latency_ms"`
+	Error     string         `json:"error,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+	// 关键组件失败时整体不可用，其余组件失败只降级
+	critical bool
+}
+
+type Report struct {
+	Status     string               `json:"status"`
+	Version    string               `json:"version"`
+	Timestamp  time.Time            `json:"timestamp"`
+	Components map[string]Component `json:"components,omitempty"`
+}
+
+type Checker struct {
+	app *config.App
+
+	mu       sync.Mutex
+	cached   *Report
+	cachedAt time.Time
+}
+
+func NewChecker(app *config.App) *Checker {
+	return &Checker{app: app}
+}
+
+// Ready 返回就绪报告，reportTTL 内复用上次结果.
+// 检查过程中持有锁，并发的探针等待同一次检查的结果，单个探针断开不影响这次检查.
+func (c *Checker) Ready(ctx context.Context) *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cached != nil && time.Since(c.cachedAt) < reportTTL {
+		return c.cached
+	}
+
+	components := map[string]Component{}
+	var wg sync.WaitGroup
+	var resultMu sync.Mutex
+	checks := map[string]func(ctx context.Context) Component{
+		"database":     c.checkDatabase,
+		"migrations":   c.checkMigrations,
+		"redis":        c.checkRedis,
+		"email":        c.checkEmail,
+		"blob_storage": c.checkBlobStorage,
+	}
+	for name, check := range checks {
+		wg.Go(func() {
+			result := timed(context.WithoutCancel(ctx), check)
+			resultMu.Lock()
+			components[name] = result
+			resultMu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	c.cached = &Report{
+		Status:     overallStatus(components),
+		Version:    c.app.Config.Version,
+		Timestamp:  time.Now(),
+		Components: components,
+	}
+	c.cachedAt = time.Now()
+	return c.cached
+}
+
+func overallStatus(components map[string]Component) string {
+	status := StatusOK
+	for _, component := range components {
+		if component.Status != StatusFail {
+			continue
+		}
+		if component.critical {
+			return StatusFail
+		}
+		status = StatusDegraded
+	}
+	return status
+}
+
+// timed 在超时限制内执行检查并记录耗时.
+func timed(ctx context.Context, check func(ctx context.Context) Component) Component {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result := check(ctx)
+	result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	return result
+}
+
+func failed(err error, critical bool) Component {
+	return Component{Status: StatusFail, Error: err.Error(), critical: critical}
+}
+
+func (c *Checker) checkDatabase(ctx context.Context) Component {
+	if err := c.app.DB.PingContext(ctx); err != nil {
+		return failed(err, true)
+	}
+	return Component{Status: StatusOK, critical: true}
+}
+
+// checkMigrations 比较数据库中 goose 记录的版本与部署包中最新的迁移文件，
+// 迁移尚未执行完时不接收流量.
+func (c *Checker) checkMigrations(ctx context.Context) Component {
+	expected, err := latestMigrationVersion(c.app.Config.MigrationsDir)
+	if err != nil {
+		return failed(err, true)
+	}
+
+	var current int64
+	err = c.app.DB.QueryRowContext(ctx, currentMigrationVersionQuery).Scan(&current)
+	if err != nil {
+		return failed(errors.WrapPrefix(err, "query migration version failed", 0), true)
+	}
+
+	details := map[string]any{"current": current, "expected": expected}
+	if current < expected {
+		return Component{
+			Status:   StatusFail,
+			Error:    "database migrations are pending",
+			Details:  details,
+			critical: true,
+		}
+	}
+	return Component{Status: StatusOK, Details: details, critical: true}
+}
+
+// checkRedis 未配置 Redis 时相关功能已降级运行，不影响就绪.
+func (c *Checker) checkRedis(ctx context.Context) Component {
+	if c.app.Redis == nil {
+		return Component{Status: StatusDisabled}
+	}
+	if err := c.app.CheckRedis(ctx); err != nil {
+		return failed(err, false)
+	}
+	return Component{Status: StatusOK}
+}
+
+// checkEmail 只检查是否配置，不实际调用邮件服务.
+func (c *Checker) checkEmail(context.Context) Component {
+	if c.app.Resend == nil {
+		return Component{Status: StatusDisabled}
+	}
+	return Component{Status: StatusOK}
+}
+
+// checkBlobStorage 只检查是否配置，不实际访问存储.
+func (c *Checker) checkBlobStorage(context.Context) Component {
+	if c.app.Azure.StorageAccount == "" || c.app.Azure.StorageKey == "" {
+		return Component{Status: StatusDisabled}
+	}
+	return Component{Status: StatusOK}
+}
+
+// latestMigrationVersion 迁移目录中最大的版本号.
+func latestMigrationVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "read migrations dir failed", 0)
+	}
+
+	var latest int64
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
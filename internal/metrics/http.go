@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"genshin-quiz/config"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// Handler 输出全部指标，token 非空时要求 Authorization: Bearer <token>.
+func Handler(token string) http.Handler {
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			got := []byte(r.Header.Get("Authorization"))
+			want := []byte("Bearer " + token)
+			if subtle.ConstantTimeCompare(got, want) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Setup 注册连接池指标，配置了 MetricsAddr 时在独立的管理端口上提供 /metrics.
+// 未配置管理端口时由调用方决定是否在主端口暴露（需要 MetricsToken）.
+func Setup(app *config.App) {
+	RegisterDBStats(app.DB)
+	if app.Redis != nil {
+		RegisterRedisStats(app.Redis)
+	}
+
+	if app.Config.MetricsAddr == "" {
+		return
+	}
+	ln, err := net.Listen("tcp", app.Config.MetricsAddr)
+	if err != nil {
+		app.Logger.Error("Failed to listen on metrics address", zap.Error(err))
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", Handler(app.Config.MetricsToken))
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	app.Lifecycle.OnShutdown("metrics server", func(ctx context.Context) error {
+		return srv.Shutdown(ctx)
+	})
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			app.Logger.Error("Metrics server stopped unexpectedly", zap.Error(err))
+		}
+	}()
+	app.Logger.Info("Metrics server started", zap.String("addr", app.Config.MetricsAddr))
+}
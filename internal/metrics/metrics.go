@@ -0,0 +1,177 @@
+// Package metrics Prometheus 指标，包括 HTTP 请求、连接池、定时任务和业务计数.
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"genshin-quiz/internal/enum"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+//nolint:gochecknoglobals // 进程级指标，与 Prometheus 客户端的用法一致
+var (
+	// 使用独立的 registry，只输出这里定义的指标
+	registry = prometheus.NewRegistry()
+	factory  = promauto.With(registry)
+
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by oapi operation, method and status code.",
+	}, []string{"operation", "method", "status"})
+	httpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by oapi operation and status code.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"operation", "status"})
+
+	cronRuns = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "cron_job_runs_total",
+		Help: "Cron job runs by job and result.",
+	}, []string{"job", "result"})
+	cronDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cron_job_duration_seconds",
+		Help:    "Cron job duration by job.",
+		Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 1800},
+	}, []string{"job"})
+	cronLastSuccess = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cron_job_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful run by job.",
+	}, []string{"job"})
+
+	// 正确率通过 result="correct" 与全部提交的比值计算
+	answersSubmitted = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "quiz_answers_submitted_total",
+		Help: "Question answers submitted by result.",
+	}, []string{"result"})
+	votesCast = factory.NewCounter(prometheus.CounterOpts{
+		Name: "poll_votes_cast_total",
+		Help: "Poll votes cast.",
+	})
+	registrations = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_registrations_total",
+		Help: "User registrations by login provider.",
+	}, []string{"provider"})
+	logins = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_logins_total",
+		Help: "Login attempts by login provider and login status.",
+	}, []string{"provider", "status"})
+)
+
+// operationOther 非 oapi 接口（健康检查、404 等）统一归为一类，避免标签过多.
+const operationOther = "other"
+
+// ObserveHTTPRequest 记录一次 HTTP 请求，operation 为空时归为 other.
+func ObserveHTTPRequest(operation, method string, status int, duration time.Duration) {
+	if operation == "" {
+		operation = operationOther
+	}
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(operation, method, code).Inc()
+	httpDuration.WithLabelValues(operation, code).Observe(duration.Seconds())
+}
+
+// ObserveCronJob 记录一次定时任务执行.
+func ObserveCronJob(job string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	} else {
+		cronLastSuccess.WithLabelValues(job).SetToCurrentTime()
+	}
+	cronRuns.WithLabelValues(job, result).Inc()
+	cronDuration.WithLabelValues(job).Observe(duration.Seconds())
+}
+
+func RecordAnswer(correct bool) {
+	result := "incorrect"
+	if correct {
+		result = "correct"
+	}
+	answersSubmitted.WithLabelValues(result).Inc()
+}
+
+func RecordVote() {
+	votesCast.Inc()
+}
+
+func RecordRegistration(provider enum.LoginProvider) {
+	registrations.WithLabelValues(string(provider)).Inc()
+}
+
+func RecordLogin(provider enum.LoginProvider, status enum.LoginStatus) {
+	logins.WithLabelValues(string(provider), loginStatusLabel(status)).Inc()
+}
+
+func loginStatusLabel(status enum.LoginStatus) string {
+	switch status {
+	case enum.LoginStatusSuccess:
+		return "success"
+	case enum.LoginStatusFailed:
+		return "failed"
+	case enum.LoginStatusBlocked:
+		return "blocked"
+	default:
+		return "unknown"
+	}
+}
+
+// RegisterDBStats 注册数据库连接池指标，抓取时读取 sql.DB.Stats.
+func RegisterDBStats(db *sql.DB) {
+	gauge := func(name, help string, fn func(s sql.DBStats) float64) {
+		factory.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, func() float64 { return fn(db.Stats()) })
+	}
+	counter := func(name, help string, fn func(s sql.DBStats) float64) {
+		factory.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, func() float64 { return fn(db.Stats()) })
+	}
+	gauge("db_max_open_connections", "Maximum number of open connections to the database.",
+		func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) })
+	gauge("db_open_connections", "Established connections, both in use and idle.",
+		func(s sql.DBStats) float64 { return float64(s.OpenConnections) })
+	gauge("db_in_use_connections", "Connections currently in use.",
+		func(s sql.DBStats) float64 { return float64(s.InUse) })
+	gauge("db_idle_connections", "Idle connections.",
+		func(s sql.DBStats) float64 { return float64(s.Idle) })
+	counter("db_wait_count_total", "Connections waited for.",
+		func(s sql.DBStats) float64 { return float64(s.WaitCount) })
+	counter("db_wait_duration_seconds_total", "Time blocked waiting for a new connection.",
+		func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() })
+	counter("db_max_idle_closed_total", "Connections closed due to SetMaxIdleConns.",
+		func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) })
+	counter("db_max_idle_time_closed_total", "Connections closed due to SetConnMaxIdleTime.",
+		func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) })
+	counter("db_max_lifetime_closed_total", "Connections closed due to SetConnMaxLifetime.",
+		func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) })
+}
+
+// RegisterRedisStats 注册 Redis 连接池指标，抓取时读取 PoolStats.
+func RegisterRedisStats(rdb *redis.Client) {
+	gauge := func(name, help string, fn func(s *redis.PoolStats) float64) {
+		factory.NewGaugeFunc(
+			prometheus.GaugeOpts{Name: name, Help: help},
+			func() float64 { return fn(rdb.PoolStats()) },
+		)
+	}
+	counter := func(name, help string, fn func(s *redis.PoolStats) float64) {
+		factory.NewCounterFunc(
+			prometheus.CounterOpts{Name: name, Help: help},
+			func() float64 { return fn(rdb.PoolStats()) },
+		)
+	}
+	counter("redis_pool_hits_total", "Free connections found in the pool.",
+		func(s *redis.PoolStats) float64 { return float64(s.Hits) })
+	counter("redis_pool_misses_total", "Free connections not found in the pool.",
+		func(s *redis.PoolStats) float64 { return float64(s.Misses) })
+	counter("redis_pool_timeouts_total", "Waits for a connection that timed out.",
+		func(s *redis.PoolStats) float64 { return float64(s.Timeouts) })
+	gauge("redis_pool_total_connections", "Connections in the pool.",
+		func(s *redis.PoolStats) float64 { return float64(s.TotalConns) })
+	gauge("redis_pool_idle_connections", "Idle connections in the pool.",
+		func(s *redis.PoolStats) float64 { return float64(s.IdleConns) })
+	counter("redis_pool_stale_connections_total", "Stale connections removed from the pool.",
+		func(s *redis.PoolStats) float64 { return float64(s.StaleConns) })
+}
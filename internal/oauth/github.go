@@ -0,0 +1,93 @@
+package oauth
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"genshin-quiz/config"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+)
+
+// githubProvider GitHub 不支持 OIDC，通过 REST API 读取用户信息.
+// 授权、令牌和 API 地址从配置读取，测试时指向 mock 服务.
+type githubProvider struct {
+	cfg config.OAuthProviderConfig
+}
+
+func (p *githubProvider) Name() enum.LoginProvider {
+	return enum.LoginGithub
+}
+
+func (p *githubProvider) AuthCodeURL(redirectURI, state, codeChallenge, _ string) string {
+	query := url.Values{
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {"read:user user:email"},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	return p.cfg.AuthURL + "?" + query.Encode()
+}
+
+func (p *githubProvider) Exchange(
+	ctx context.Context,
+	redirectURI, code, codeVerifier, _ string,
+) (*Identity, error) {
+	var tokenRes struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	err := postForm(ctx, p.cfg.TokenURL, url.Values{
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}, &tokenRes)
+	if err != nil {
+		return nil, err
+	}
+	// GitHub 授权码无效时同样返回 200，错误放在 error 字段
+	if tokenRes.AccessToken == "" {
+		return nil, common.NewUnauthorizedError("第三方授权码无效或已过期")
+	}
+
+	apiURL := strings.TrimSuffix(p.cfg.APIURL, "/")
+	profile := map[string]any{}
+	if err := getJSON(ctx, apiURL+"/user", tokenRes.AccessToken, &profile); err != nil {
+		return nil, err
+	}
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, apiURL+"/user/emails", tokenRes.AccessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{Raw: profile}
+	if id, ok := profile["id"].(float64); ok {
+		identity.Subject = strconv.FormatInt(int64(id), 10)
+	}
+	identity.Name, _ = profile["name"].(string)
+	if identity.Name == "" {
+		identity.Name, _ = profile["login"].(string)
+	}
+	identity.AvatarURL, _ = profile["avatar_url"].(string)
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email = e.Email
+			identity.EmailVerified = e.Verified
+			break
+		}
+	}
+	if identity.Subject == "" {
+		return nil, common.NewUnauthorizedError("第三方登录凭证无效")
+	}
+	return identity, nil
+}
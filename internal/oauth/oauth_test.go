@@ -0,0 +1,280 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/internal/enum"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID     = "client-id"
+	testClientSecret = "client-secret"
+	testRedirectURI  = "http://localhost:3000/auth/callback"
+	testCode         = "auth-code"
+	testVerifier     = "code-verifier"
+	testNonce        = "nonce"
+)
+
+// mockIssuer 模拟 OIDC 服务，token 端点校验授权码和 PKCE 后返回 claims 生成的 ID Token.
+type mockIssuer struct {
+	server *httptest.Server
+	claims jwt.MapClaims
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+	m := &mockIssuer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("grant_type") != "authorization_code" ||
+			r.PostForm.Get("code") != testCode ||
+			r.PostForm.Get("code_verifier") != testVerifier ||
+			r.PostForm.Get("redirect_uri") != testRedirectURI ||
+			r.PostForm.Get("client_id") != testClientID ||
+			r.PostForm.Get("client_secret") != testClientSecret {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, m.claims).SignedString([]byte("test"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]string{"access_token": "access-token", "id_token": idToken})
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	m.claims = jwt.MapClaims{
+		"iss":            m.server.URL,
+		"aud":            testClientID,
+		"sub":            "oidc-subject",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          testNonce,
+		"email":          "traveler@example.com",
+		"email_verified": true,
+		"name":           "Traveler",
+		"picture":        "https://example.com/avatar.png",
+	}
+	return m
+}
+
+func (m *mockIssuer) provider(t *testing.T) Provider {
+	t.Helper()
+	provider, err := NewProvider(config.OAuthConfig{
+		Google: config.OAuthProviderConfig{
+			ClientID:     testClientID,
+			ClientSecret: testClientSecret,
+			Issuer:       m.server.URL,
+		},
+	}, enum.LoginGoogle)
+	if err != nil {
+		t.Fatalf("new provider: %v", err)
+	}
+	return provider
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestOIDCAuthCodeURL(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	authURL := issuer.provider(t).AuthCodeURL(testRedirectURI, "state", CodeChallenge(testVerifier), testNonce)
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+	if got := parsed.Scheme + "://" + parsed.Host + parsed.Path; got != issuer.server.URL+"/authorize" {
+		t.Errorf("authorization endpoint = %s, want discovered endpoint", got)
+	}
+	query := parsed.Query()
+	want := map[string]string{
+		"client_id":             testClientID,
+		"redirect_uri":          testRedirectURI,
+		"state":                 "state",
+		"nonce":                 testNonce,
+		"code_challenge":        CodeChallenge(testVerifier),
+		"code_challenge_method": "S256",
+	}
+	for key, value := range want {
+		if query.Get(key) != value {
+			t.Errorf("%s = %q, want %q", key, query.Get(key), value)
+		}
+	}
+}
+
+func TestOIDCExchange(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	identity, err := issuer.provider(t).Exchange(
+		context.Background(), testRedirectURI, testCode, testVerifier, testNonce,
+	)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if identity.Subject != "oidc-subject" || identity.Email != "traveler@example.com" || !identity.EmailVerified {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+	if identity.Name != "Traveler" || identity.AvatarURL != "https://example.com/avatar.png" {
+		t.Errorf("unexpected profile: %+v", identity)
+	}
+}
+
+func TestOIDCExchangeRejectsInvalidToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(claims jwt.MapClaims)
+		code   string
+		nonce  string
+	}{
+		{name: "nonce mismatch", nonce: "other-nonce"},
+		{name: "missing nonce", mutate: func(c jwt.MapClaims) { delete(c, "nonce") }},
+		{name: "wrong issuer", mutate: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{name: "wrong audience", mutate: func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{name: "expired", mutate: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }},
+		{name: "missing subject", mutate: func(c jwt.MapClaims) { delete(c, "sub") }},
+		{name: "invalid code", code: "wrong-code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newMockIssuer(t)
+			if tt.mutate != nil {
+				tt.mutate(issuer.claims)
+			}
+			code := testCode
+			if tt.code != "" {
+				code = tt.code
+			}
+			nonce := testNonce
+			if tt.nonce != "" {
+				nonce = tt.nonce
+			}
+
+			identity, err := issuer.provider(t).Exchange(
+				context.Background(), testRedirectURI, code, testVerifier, nonce,
+			)
+			if err == nil {
+				t.Fatalf("exchange succeeded with identity %+v, want error", identity)
+			}
+		})
+	}
+}
+
+func newMockGitHub(t *testing.T) (*httptest.Server, Provider) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// GitHub 授权码无效时同样返回 200
+		if r.PostForm.Get("code") != testCode || r.PostForm.Get("code_verifier") != testVerifier {
+			writeJSON(w, map[string]string{"error": "bad_verification_code"})
+			return
+		}
+		writeJSON(w, map[string]string{"access_token": "gh-token"})
+	})
+	requireToken := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer gh-token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next(w, r)
+		}
+	}
+	mux.HandleFunc("/api/user", requireToken(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"id": 42, "login": "paimon", "avatar_url": "https://example.com/p.png"})
+	}))
+	mux.HandleFunc("/api/user/emails", requireToken(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, []map[string]any{
+			{"email": "secondary@example.com", "primary": false, "verified": true},
+			{"email": "paimon@example.com", "primary": true, "verified": true},
+		})
+	}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	provider, err := NewProvider(config.OAuthConfig{
+		GitHub: config.OAuthProviderConfig{
+			ClientID:     testClientID,
+			ClientSecret: testClientSecret,
+			AuthURL:      server.URL + "/login/oauth/authorize",
+			TokenURL:     server.URL + "/login/oauth/access_token",
+			APIURL:       server.URL + "/api",
+		},
+	}, enum.LoginGithub)
+	if err != nil {
+		t.Fatalf("new provider: %v", err)
+	}
+	return server, provider
+}
+
+func TestGitHubExchange(t *testing.T) {
+	server, provider := newMockGitHub(t)
+
+	authURL := provider.AuthCodeURL(testRedirectURI, "state", CodeChallenge(testVerifier), "")
+	if !strings.HasPrefix(authURL, server.URL+"/login/oauth/authorize?") {
+		t.Errorf("auth url = %s, want configured endpoint", authURL)
+	}
+
+	identity, err := provider.Exchange(context.Background(), testRedirectURI, testCode, testVerifier, "")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if identity.Subject != "42" || identity.Name != "paimon" {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+	if identity.Email != "paimon@example.com" || !identity.EmailVerified {
+		t.Errorf("primary email not used: %+v", identity)
+	}
+}
+
+func TestGitHubExchangeRejectsInvalidCode(t *testing.T) {
+	_, provider := newMockGitHub(t)
+
+	_, err := provider.Exchange(context.Background(), testRedirectURI, "wrong-code", testVerifier, "")
+	if err == nil {
+		t.Fatal("exchange succeeded with invalid code, want error")
+	}
+}
+
+func TestNewProviderRequiresClientID(t *testing.T) {
+	if _, err := NewProvider(config.OAuthConfig{}, enum.LoginGoogle); err == nil {
+		t.Error("provider without client id should be unsupported")
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// S256 为 SHA-256 摘要的 base64url 编码，不带填充；SHA-256("abc") = ba7816bf...f20015ad
+	got := CodeChallenge("abc")
+	if want := "ungWv48Bz-pBQUDeXa4iI7ADYaOWF3qctBD_YfIAFa0"; got != want {
+		t.Errorf("CodeChallenge = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// discovery 文档按 issuer 缓存.
+var discoveryCache sync.Map
+
+// oidcProvider 标准 OpenID Connect 登录，端点从 issuer 的 discovery 文档读取.
+type oidcProvider struct {
+	name enum.LoginProvider
+	cfg  config.OAuthProviderConfig
+}
+
+func (p *oidcProvider) Name() enum.LoginProvider {
+	return p.name
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	if cached, ok := discoveryCache.Load(p.cfg.Issuer); ok {
+		return cached.(*oidcDiscovery), nil
+	}
+
+	var doc oidcDiscovery
+	endpoint := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, endpoint, "", &doc); err != nil {
+		return nil, err
+	}
+	discoveryCache.Store(p.cfg.Issuer, &doc)
+	return &doc, nil
+}
+
+func (p *oidcProvider) AuthCodeURL(redirectURI, state, codeChallenge, nonce string) string {
+	// discovery 失败时退回 issuer 下的默认路径，回调阶段会再次报错
+	authEndpoint := strings.TrimSuffix(p.cfg.Issuer, "/") + "/authorize"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if doc, err := p.discover(ctx); err == nil {
+		authEndpoint = doc.AuthorizationEndpoint
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {"openid email profile"},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	return authEndpoint + "?" + query.Encode()
+}
+
+// Exchange ID Token 直接从 token 端点经 TLS 获取，按 OIDC Core 3.1.3.7 校验 iss、aud、exp 和 nonce.
+func (p *oidcProvider) Exchange(
+	ctx context.Context,
+	redirectURI, code, codeVerifier, nonce string,
+) (*Identity, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenRes struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+	}
+	err = postForm(ctx, doc.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}, &tokenRes)
+	if err != nil {
+		return nil, err
+	}
+	if tokenRes.IDToken == "" {
+		return nil, fmt.Errorf("oidc token response missing id_token")
+	}
+
+	claims := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(tokenRes.IDToken, claims)
+	if err != nil {
+		return nil, common.NewUnauthorizedError("第三方登录凭证无效")
+	}
+	if err := p.validateClaims(doc, claims, nonce); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{Raw: claims}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	identity.AvatarURL, _ = claims["picture"].(string)
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = v
+	case string:
+		identity.EmailVerified = v == "true"
+	}
+	if identity.Subject == "" {
+		return nil, common.NewUnauthorizedError("第三方登录凭证无效")
+	}
+	return identity, nil
+}
+
+func (p *oidcProvider) validateClaims(doc *oidcDiscovery, claims jwt.MapClaims, nonce string) error {
+	invalid := common.NewUnauthorizedError("第三方登录凭证无效")
+
+	issuer, _ := claims.GetIssuer()
+	if issuer != doc.Issuer {
+		return invalid
+	}
+	audience, err := claims.GetAudience()
+	if err != nil {
+		return invalid
+	}
+	matched := false
+	for _, aud := range audience {
+		if aud == p.cfg.ClientID {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return invalid
+	}
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil || !expiresAt.After(time.Now()) {
+		return invalid
+	}
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return invalid
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	goerrors "github.com/go-errors/errors"
+)
+
+// RandomString 生成 URL 安全的随机字符串，用于 state、nonce 和 code_verifier.
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", goerrors.WrapPrefix(err, "generate random string failed", 0)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge 按 S256 方式计算 PKCE code_challenge.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
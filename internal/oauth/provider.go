@@ -0,0 +1,99 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+
+	goerrors "github.com/go-errors/errors"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Provider 第三方登录，授权码模式 + PKCE.
+type Provider interface {
+	Name() enum.LoginProvider
+	// AuthCodeURL 生成授权地址，nonce 仅 OIDC 使用
+	AuthCodeURL(redirectURI, state, codeChallenge, nonce string) string
+	// Exchange 用授权码换取令牌并读取用户信息
+	Exchange(ctx context.Context, redirectURI, code, codeVerifier, nonce string) (*Identity, error)
+}
+
+// Identity 第三方返回的用户信息，Raw 原样保存到 user_credentials.extra_data.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	AvatarURL     string
+	Raw           map[string]any
+}
+
+// NewProvider 按名称创建登录方式，未配置 ClientID 时视为不支持.
+func NewProvider(cfg config.OAuthConfig, name enum.LoginProvider) (Provider, error) {
+	switch name {
+	case enum.LoginGoogle:
+		if cfg.Google.ClientID == "" {
+			break
+		}
+		return &oidcProvider{name: name, cfg: cfg.Google}, nil
+	case enum.LoginGithub:
+		if cfg.GitHub.ClientID == "" {
+			break
+		}
+		return &githubProvider{cfg: cfg.GitHub}, nil
+	}
+	return nil, common.NewBadRequestError("不支持的登录方式")
+}
+
+// postForm 提交表单并解析 JSON 响应.
+func postForm(ctx context.Context, endpoint string, form url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return goerrors.WrapPrefix(err, "build oauth request failed", 0)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	return doJSON(req, out)
+}
+
+func getJSON(ctx context.Context, endpoint, accessToken string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return goerrors.WrapPrefix(err, "build oauth request failed", 0)
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	return doJSON(req, out)
+}
+
+func doJSON(req *http.Request, out any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return goerrors.WrapPrefix(err, "oauth request failed", 0)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return goerrors.WrapPrefix(err, "read oauth response failed", 0)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oauth request %s returned %d: %s", req.URL.Path, resp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return goerrors.WrapPrefix(err, "decode oauth response failed", 0)
+	}
+	return nil
+}
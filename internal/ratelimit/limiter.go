@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Limit 窗口期内允许的请求次数.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Result 一次限流检查的结果.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter 窗口内最早的请求过期、腾出额度所需的时间
+	ResetAfter time.Duration
+}
+
+// Limiter 滑动窗口限流，窗口内记录每次请求的时间.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+	// Reset 清空 key 的计数，例如密码验证成功后
+	Reset(ctx context.Context, key string) error
+}
+
+// New 配置了 Redis 时多实例共享计数，否则退回单实例内存计数.
+func New(rdb *redis.Client) Limiter {
+	if rdb == nil {
+		return NewMemoryLimiter()
+	}
+	return NewRedisLimiter(rdb)
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval 定期清理已过期的 key，避免内存持续增长.
+const memorySweepInterval = time.Minute
+
+type memoryLimiter struct {
+	mu        sync.Mutex
+	requests  map[string][]time.Time
+	windows   map[string]time.Duration
+	lastSweep time.Time
+}
+
+// NewMemoryLimiter 进程内计数，仅适用于单实例或未配置 Redis 的环境.
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		requests:  make(map[string][]time.Time),
+		windows:   make(map[string]time.Duration),
+		lastSweep: time.Now(),
+	}
+}
+
+func (l *memoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > memorySweepInterval {
+		l.sweep(now)
+	}
+
+	timestamps := pruneBefore(l.requests[key], now.Add(-limit.Window))
+	allowed := len(timestamps) < limit.Requests
+	if allowed {
+		timestamps = append(timestamps, now)
+	}
+	l.requests[key] = timestamps
+	l.windows[key] = limit.Window
+
+	resetAfter := limit.Window
+	if len(timestamps) > 0 {
+		resetAfter = timestamps[0].Add(limit.Window).Sub(now)
+	}
+	return Result{
+		Allowed:    allowed,
+		Limit:      limit.Requests,
+		Remaining:  max(limit.Requests-len(timestamps), 0),
+		ResetAfter: resetAfter,
+	}, nil
+}
+
+func (l *memoryLimiter) Reset(_ context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.requests, key)
+	delete(l.windows, key)
+	return nil
+}
+
+func (l *memoryLimiter) sweep(now time.Time) {
+	for key, timestamps := range l.requests {
+		timestamps = pruneBefore(timestamps, now.Add(-l.windows[key]))
+		if len(timestamps) == 0 {
+			delete(l.requests, key)
+			delete(l.windows, key)
+			continue
+		}
+		l.requests[key] = timestamps
+	}
+	l.lastSweep = now
+}
+
+// pruneBefore 时间按先后排列，去掉 cutoff 及之前的记录.
+func pruneBefore(timestamps []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(timestamps) && !timestamps[i].After(cutoff) {
+		i++
+	}
+	return timestamps[i:]
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "ratelimit:"
+
+// slidingWindowScript 清理窗口外的记录，未超限时记录本次请求.
+// 返回 {是否允许, 剩余次数, 距离腾出额度的毫秒数}.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+  redis.call('ZADD', key, now, ARGV[4])
+  redis.call('PEXPIRE', key, window)
+  count = count + 1
+  allowed = 1
+end
+
+local reset = window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+  reset = window - (now - tonumber(oldest[2]))
+end
+return {allowed, limit - count, reset}
+`)
+
+type redisLimiter struct {
+	rdb *redis.Client
+}
+
+func NewRedisLimiter(rdb *redis.Client) Limiter {
+	return &redisLimiter{rdb: rdb}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now().UnixMilli()
+	values, err := slidingWindowScript.Run(
+		ctx,
+		l.rdb,
+		[]string{redisKeyPrefix + key},
+		now,
+		limit.Window.Milliseconds(),
+		limit.Requests,
+		// 同一毫秒内的请求需要不同的成员
+		fmt.Sprintf("%d-%s", now, uuid.NewString()),
+	).Int64Slice()
+	if err != nil {
+		return Result{}, errors.WrapPrefix(err, "rate limit script failed", 0)
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(max(values[1], 0)),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
+
+func (l *redisLimiter) Reset(ctx context.Context, key string) error {
+	if err := l.rdb.Del(ctx, redisKeyPrefix+key).Err(); err != nil {
+		return errors.WrapPrefix(err, "reset rate limit failed", 0)
+	}
+	return nil
+}
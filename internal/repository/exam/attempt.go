@@ -0,0 +1,192 @@
+package exam_repo
+
+import (
+	"context"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/common"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+)
+
+// CheckAttemptExists 检查作答记录是否已提交.
+func CheckAttemptExists(
+	ctx context.Context,
+	db qrm.DB,
+	attemptUUID uuid.UUID,
+) (bool, error) {
+	tbl := table.QuizAttempts
+
+	stmt := pg.SELECT(pg.COUNT(pg.STAR).AS("count")).
+		FROM(tbl).
+		WHERE(tbl.AttemptUUID.EQ(pg.UUID(attemptUUID)))
+
+	var result struct {
+		Count int64 `alias:"count"`
+	}
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return false, errors.WrapPrefix(err, "check attempt exists failed", 0)
+	}
+
+	return result.Count > 0, nil
+}
+
+// InsertExamAttempt 写入作答记录，attempt_uuid 已存在时返回 ErrAttemptSubmitted.
+// 依靠唯一约束判重，并发重复提交时只有一次能成功.
+func InsertExamAttempt(
+	ctx context.Context,
+	db qrm.DB,
+	attempt model.QuizAttempts,
+) (*model.QuizAttempts, error) {
+	tbl := table.QuizAttempts
+	insertStmt := tbl.INSERT(
+		tbl.AttemptUUID,
+		tbl.QuizID,
+		tbl.UserID,
+		tbl.StartedAt,
+		tbl.TotalScore,
+		tbl.MaxScore,
+		tbl.ShuffleSeed,
+		tbl.CreatedAt,
+	).MODEL(attempt).
+		ON_CONFLICT(tbl.AttemptUUID).DO_NOTHING().
+		RETURNING(tbl.AllColumns)
+
+	var result model.QuizAttempts
+	err := insertStmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, common.ErrAttemptSubmitted
+		}
+		return nil, errors.WrapPrefix(err, "insert exam attempt failed", 0)
+	}
+
+	return &result, nil
+}
+
+func InsertExamAnswers(
+	ctx context.Context,
+	db qrm.DB,
+	answers []model.QuizAnswers,
+) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	tbl := table.QuizAnswers
+	insertStmt := tbl.INSERT(
+		tbl.AttemptID,
+		tbl.QuestionID,
+		tbl.SelectedOptionIds,
+		tbl.TimeTaken,
+		tbl.IsCorrect,
+		tbl.Points,
+		tbl.DisplayOrder,
+		tbl.OptionOrder,
+		tbl.CorrectOptionIds,
+		tbl.CreatedAt,
+	).MODELS(answers)
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "insert exam answers failed", 0)
+	}
+	return nil
+}
+
+func GetExamAttemptByUUID(
+	ctx context.Context,
+	db qrm.DB,
+	attemptUUID uuid.UUID,
+) (*model.QuizAttempts, error) {
+	tbl := table.QuizAttempts
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.AttemptUUID.EQ(pg.UUID(attemptUUID)))
+
+	var result model.QuizAttempts
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, common.ErrAttemptNotFound
+		}
+		return nil, errors.WrapPrefix(err, "get exam attempt failed", 0)
+	}
+
+	return &result, nil
+}
+
+// GetExamAnswersByAttemptID 按作答时的题目顺序返回答案.
+func GetExamAnswersByAttemptID(
+	ctx context.Context,
+	db qrm.DB,
+	attemptID int64,
+) ([]model.QuizAnswers, error) {
+	tbl := table.QuizAnswers
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.AttemptID.EQ(pg.Int64(attemptID))).
+		ORDER_BY(tbl.DisplayOrder.ASC(), tbl.ID.ASC())
+
+	var results []model.QuizAnswers
+	err := stmt.QueryContext(ctx, db, &results)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get exam answers failed", 0)
+	}
+
+	return results, nil
+}
+
+// DeductQuestionFromAttempts 彻底删除题目前，从包含该题的作答中扣除该题的得分和满分，返回受影响的测验 ID.
+// 满分按测验中该题当前的分值扣除，题目已移出测验时只扣得分.
+func DeductQuestionFromAttempts(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+) ([]int64, error) {
+	attempts := table.QuizAttempts
+	answers := table.QuizAnswers
+	quizQuestions := table.QuizQuestions
+
+	questionPoints := pg.SELECT(quizQuestions.Points).
+		FROM(quizQuestions).
+		WHERE(
+			quizQuestions.QuizID.EQ(attempts.QuizID).
+				AND(quizQuestions.QuestionID.EQ(pg.Int64(questionID))),
+		)
+
+	updateStmt := attempts.UPDATE().
+		SET(
+			attempts.TotalScore.SET(attempts.TotalScore.SUB(answers.Points)),
+			attempts.MaxScore.SET(attempts.MaxScore.SUB(pg.IntExp(pg.COALESCE(questionPoints, pg.Int32(0))))),
+		).
+		FROM(answers).
+		WHERE(
+			answers.AttemptID.EQ(attempts.ID).
+				AND(answers.QuestionID.EQ(pg.Int64(questionID))),
+		).
+		RETURNING(attempts.QuizID)
+
+	var rows []model.QuizAttempts
+	err := updateStmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "deduct question from attempts failed", 0)
+	}
+
+	seen := make(map[int64]bool, len(rows))
+	examIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		if !seen[row.QuizID] {
+			seen[row.QuizID] = true
+			examIDs = append(examIDs, row.QuizID)
+		}
+	}
+	return examIDs, nil
+}
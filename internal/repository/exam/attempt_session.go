@@ -0,0 +1,117 @@
+package exam_repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"genshin-quiz/internal/dao"
+
+	"github.com/go-errors/errors"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	examAttemptKeyPrefix       = "exam_attempt:"
+	examAttemptReviewKeyPrefix = "exam_attempt_review:"
+)
+
+func examAttemptKey(attemptUUID uuid.UUID) string {
+	return examAttemptKeyPrefix + attemptUUID.String()
+}
+
+func examAttemptReviewKey(attemptUUID uuid.UUID) string {
+	return examAttemptReviewKeyPrefix + attemptUUID.String()
+}
+
+// SaveAttemptSession 将作答会话写入 Redis，过期后无法再提交.
+func SaveAttemptSession(
+	ctx context.Context,
+	rdb *redis.Client,
+	session dao.ExamAttemptSession,
+	ttl time.Duration,
+) error {
+	return saveSession(ctx, rdb, examAttemptKey(session.AttemptUUID), session, ttl)
+}
+
+// SaveAttemptReview 另存一份作答会话，供超时未提交的作答回顾，不能用于提交.
+func SaveAttemptReview(
+	ctx context.Context,
+	rdb *redis.Client,
+	session dao.ExamAttemptSession,
+	ttl time.Duration,
+) error {
+	return saveSession(ctx, rdb, examAttemptReviewKey(session.AttemptUUID), session, ttl)
+}
+
+// GetAttemptSession 读取作答会话，不存在（已过期或已提交）时返回 nil.
+func GetAttemptSession(
+	ctx context.Context,
+	rdb *redis.Client,
+	attemptUUID uuid.UUID,
+) (*dao.ExamAttemptSession, error) {
+	return getSession(ctx, rdb, examAttemptKey(attemptUUID))
+}
+
+// GetAttemptReview 读取超时作答的回顾数据，不存在（已提交或超过保留时间）时返回 nil.
+func GetAttemptReview(
+	ctx context.Context,
+	rdb *redis.Client,
+	attemptUUID uuid.UUID,
+) (*dao.ExamAttemptSession, error) {
+	return getSession(ctx, rdb, examAttemptReviewKey(attemptUUID))
+}
+
+func saveSession(
+	ctx context.Context,
+	rdb *redis.Client,
+	key string,
+	session dao.ExamAttemptSession,
+	ttl time.Duration,
+) error {
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return errors.WrapPrefix(err, "marshal attempt session failed", 0)
+	}
+
+	err = rdb.Set(ctx, key, payload, ttl).Err()
+	if err != nil {
+		return errors.WrapPrefix(err, "save attempt session failed", 0)
+	}
+	return nil
+}
+
+func getSession(
+	ctx context.Context,
+	rdb *redis.Client,
+	key string,
+) (*dao.ExamAttemptSession, error) {
+	payload, err := rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, errors.WrapPrefix(err, "get attempt session failed", 0)
+	}
+
+	var session dao.ExamAttemptSession
+	if err := json.Unmarshal(payload, &session); err != nil {
+		return nil, errors.WrapPrefix(err, "unmarshal attempt session failed", 0)
+	}
+
+	return &session, nil
+}
+
+// DeleteAttemptSession 提交成功后删除会话和回顾数据，防止重复提交，已提交的作答从数据库回顾.
+func DeleteAttemptSession(
+	ctx context.Context,
+	rdb *redis.Client,
+	attemptUUID uuid.UUID,
+) error {
+	err := rdb.Del(ctx, examAttemptKey(attemptUUID), examAttemptReviewKey(attemptUUID)).Err()
+	if err != nil {
+		return errors.WrapPrefix(err, "delete attempt session failed", 0)
+	}
+	return nil
+}
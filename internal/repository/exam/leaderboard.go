@@ -0,0 +1,125 @@
+package exam_repo
+
+import (
+	"context"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/enum"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+const (
+	defaultLeaderboardLimit = 20
+	maxLeaderboardLimit     = 100
+
+	// user_privacies 中 1 为公开
+	leaderboardVisibilityPublic = 1
+)
+
+// GetExamLeaderboard 测验排行榜：每个用户只取最佳一次，按分数降序、用时升序、提交时间升序排名.
+// 只包含排行榜可见性为公开的用户，viewerID 非空时本人总是包含在内，名次按可见的记录计算.
+func GetExamLeaderboard(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+	viewerID *int64,
+	limit int,
+	offset int,
+) ([]dao.ExamLeaderboardRow, int, error) {
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+	if limit > maxLeaderboardLimit {
+		limit = maxLeaderboardLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	attempts := table.QuizAttempts
+	users := table.Users
+	privacies := table.UserPrivacies
+
+	// 每个用户的作答按同样的规则排序，rn = 1 即最佳成绩
+	ranked := pg.SELECT(
+		attempts.AllColumns,
+		attemptDuration.AS("duration"),
+		pg.ROW_NUMBER().OVER(
+			pg.PARTITION_BY(attempts.UserID).
+				ORDER_BY(
+					attempts.TotalScore.DESC(),
+					attemptDuration.ASC(),
+					attempts.CreatedAt.ASC(),
+					attempts.ID.ASC(),
+				),
+		).AS("rn"),
+	).FROM(
+		attempts,
+	).WHERE(
+		attempts.QuizID.EQ(pg.Int64(examID)),
+	).AsTable("ranked")
+
+	rankedUserID := attempts.UserID.From(ranked)
+	rankedScore := attempts.TotalScore.From(ranked)
+	rankedCreatedAt := attempts.CreatedAt.From(ranked)
+	rankedID := attempts.ID.From(ranked)
+	rankedDuration := pg.IntegerColumn("duration").From(ranked)
+	rankedRn := pg.IntegerColumn("rn").From(ranked)
+
+	visible := privacies.LeaderboardVisibility.EQ(pg.Int16(leaderboardVisibilityPublic))
+	if viewerID != nil {
+		visible = visible.OR(rankedUserID.EQ(pg.Int64(*viewerID)))
+	}
+
+	stmt := pg.SELECT(
+		attempts.AllColumns.From(ranked),
+		rankedDuration,
+		users.AllColumns,
+		pg.RawInt("COUNT(*) OVER()").AS("total_count"),
+	).FROM(
+		ranked.
+			INNER_JOIN(users, users.ID.EQ(rankedUserID)).
+			INNER_JOIN(privacies, privacies.UserID.EQ(rankedUserID)),
+	).WHERE(
+		rankedRn.EQ(pg.Int(1)).
+			AND(users.Status.EQ(pg.Int16(int16(enum.UserStatusActive)))).
+			AND(visible),
+	).ORDER_BY(
+		rankedScore.DESC(),
+		rankedDuration.ASC(),
+		rankedCreatedAt.ASC(),
+		rankedID.ASC(), // 稳定 tie-breaker，避免分页错乱
+	).LIMIT(int64(limit)).
+		OFFSET(int64(offset))
+
+	var rawResults []struct {
+		model.QuizAttempts
+		model.Users
+		Duration   int32 `alias:"duration"`
+		TotalCount int   `alias:"total_count"`
+	}
+
+	err := stmt.QueryContext(ctx, db, &rawResults)
+	if err != nil {
+		return nil, 0, errors.WrapPrefix(err, "query exam leaderboard failed", 0)
+	}
+
+	total := 0
+	rows := make([]dao.ExamLeaderboardRow, 0, len(rawResults))
+	for i, r := range rawResults {
+		total = r.TotalCount
+		rows = append(rows, dao.ExamLeaderboardRow{
+			Rank:     offset + i + 1,
+			Attempt:  r.QuizAttempts,
+			User:     r.Users,
+			Duration: r.Duration,
+		})
+	}
+
+	return rows, total, nil
+}
@@ -0,0 +1,171 @@
+package exam_repo
+
+import (
+	"context"
+
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/util"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// GetExamLikeStatus 获取用户对指定测验的点赞状态.
+func GetExamLikeStatus(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	examID int64,
+) (*int16, error) {
+	likesTbl := table.QuizLikes
+
+	stmt := pg.SELECT(
+		likesTbl.Value,
+	).FROM(
+		likesTbl,
+	).WHERE(
+		likesTbl.UserID.EQ(pg.Int64(userID)).
+			AND(likesTbl.QuizID.EQ(pg.Int64(examID))),
+	)
+
+	var results []struct {
+		Value int16 `alias:"quiz_likes.value"`
+	}
+	err := stmt.QueryContext(ctx, db, &results)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get exam like status failed", 0)
+	}
+
+	// 如果没有找到记录，返回默认值 0 表示未点赞
+	if len(results) == 0 {
+		defaultValue := int16(0)
+		return &defaultValue, nil
+	}
+
+	return &results[0].Value, nil
+}
+
+// GetExamsLikeStatusByUser 批量获取用户对多个测验的点赞状态.
+func GetExamsLikeStatusByUser(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	examIDs []int64,
+) (map[int64]int16, error) {
+	if len(examIDs) == 0 {
+		return make(map[int64]int16), nil
+	}
+
+	likesTbl := table.QuizLikes
+
+	stmt := pg.SELECT(
+		likesTbl.QuizID,
+		likesTbl.Value,
+	).FROM(
+		likesTbl,
+	).WHERE(
+		likesTbl.UserID.EQ(pg.Int64(userID)).
+			AND(likesTbl.QuizID.IN(util.BuildInt64Expressions(examIDs)...)),
+	)
+
+	var results []struct {
+		QuizID int64 `alias:"quiz_likes.quiz_id"`
+		Value  int16 `alias:"quiz_likes.value"`
+	}
+	err := stmt.QueryContext(ctx, db, &results)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get multi exam like status failed", 0)
+	}
+
+	likeStatusMap := make(map[int64]int16, len(results))
+	for _, result := range results {
+		likeStatusMap[result.QuizID] = result.Value
+	}
+
+	return likeStatusMap, nil
+}
+
+// UpsertExamLike 插入或更新用户对测验的点赞状态.
+func UpsertExamLike(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	examID int64,
+	value int16, // 1=点赞, -1=点踩, 0=取消
+) error {
+	likesTbl := table.QuizLikes
+
+	if value == 0 {
+		// 删除点赞记录
+		deleteStmt := likesTbl.DELETE().WHERE(
+			likesTbl.QuizID.EQ(pg.Int64(examID)).
+				AND(likesTbl.UserID.EQ(pg.Int64(userID))),
+		)
+		_, err := deleteStmt.ExecContext(ctx, db)
+		if err != nil {
+			return errors.WrapPrefix(err, "delete exam like failed", 0)
+		}
+		return nil
+	}
+
+	// 插入或更新点赞记录
+	now := pg.NOW()
+	upsertStmt := likesTbl.INSERT(
+		likesTbl.QuizID,
+		likesTbl.UserID,
+		likesTbl.Value,
+		likesTbl.CreatedAt,
+		likesTbl.UpdatedAt,
+	).VALUES(
+		examID,
+		userID,
+		value,
+		now,
+		now,
+	).ON_CONFLICT(likesTbl.QuizID, likesTbl.UserID).DO_UPDATE(
+		pg.SET(
+			likesTbl.Value.SET(pg.Int16(value)),
+			likesTbl.UpdatedAt.SET(now),
+		),
+	)
+
+	_, err := upsertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "upsert exam like failed", 0)
+	}
+
+	return nil
+}
+
+// UpdateExamLikeCount 根据 quiz_likes 重新统计测验的点赞数.
+func UpdateExamLikeCount(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+) error {
+	tbl := table.Quizzes
+	likesTbl := table.QuizLikes
+
+	countStmt := pg.SELECT(pg.COUNT(pg.STAR)).
+		FROM(likesTbl).
+		WHERE(
+			likesTbl.QuizID.EQ(pg.Int64(examID)).
+				AND(likesTbl.Value.EQ(pg.Int16(1))),
+		)
+
+	var countResult struct {
+		Count int64 `alias:"count"`
+	}
+	err := countStmt.QueryContext(ctx, db, &countResult)
+	if err != nil {
+		return err
+	}
+
+	updateStmt := tbl.UPDATE().
+		SET(tbl.LikesCount.SET(pg.Int32(int32(countResult.Count)))).
+		WHERE(tbl.ID.EQ(pg.Int64(examID)))
+
+	_, err = updateStmt.ExecContext(ctx, db)
+	return err
+}
@@ -0,0 +1,147 @@
+package exam_repo
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/util"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func InsertExam(
+	ctx context.Context,
+	db qrm.DB,
+	insertModel model.Quizzes,
+) (*model.Quizzes, error) {
+	tbl := table.Quizzes
+	insertStmt := tbl.INSERT(
+		tbl.QuizUUID,
+		tbl.Public,
+		tbl.Difficulty,
+		tbl.Categories,
+		tbl.TimeLimit,
+		tbl.AccessCredentials,
+		tbl.Shuffle,
+		tbl.CreatedBy,
+		tbl.CreatedAt,
+		tbl.UpdatedAt,
+	).MODEL(insertModel).
+		RETURNING(tbl.AllColumns)
+
+	var exam model.Quizzes
+	err := insertStmt.QueryContext(ctx, db, &exam)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "insert exam failed", 0)
+	}
+
+	return &exam, nil
+}
+
+func InsertExamTranslations(
+	ctx context.Context,
+	db qrm.DB,
+	translations []model.QuizTranslations,
+) error {
+	if len(translations) == 0 {
+		return nil
+	}
+
+	tbl := table.QuizTranslations
+	insertStmt := tbl.INSERT(
+		tbl.QuizID,
+		tbl.Language,
+		tbl.Title,
+		tbl.Description,
+		tbl.CreatedAt,
+		tbl.UpdatedAt,
+	).MODELS(translations)
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	return err
+}
+
+func InsertExamQuestions(
+	ctx context.Context,
+	db qrm.DB,
+	questions []model.QuizQuestions,
+) error {
+	if len(questions) == 0 {
+		return nil
+	}
+
+	tbl := table.QuizQuestions
+	insertStmt := tbl.INSERT(
+		tbl.QuizID,
+		tbl.QuestionID,
+		tbl.QuestionOrder,
+		tbl.Points,
+		tbl.CreatedAt,
+	).MODELS(questions)
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	return err
+}
+
+// InsertExamStats 创建测验时初始化统计记录.
+func InsertExamStats(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+) error {
+	tbl := table.QuizStats
+	insertStmt := tbl.INSERT(tbl.QuizID).
+		VALUES(examID).
+		ON_CONFLICT(tbl.QuizID).DO_NOTHING()
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	return err
+}
+
+// DeleteExam 删除测验，翻译/题目/统计/点赞随外键级联删除.
+func DeleteExam(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+) error {
+	tbl := table.Quizzes
+	deleteStmt := tbl.DELETE().WHERE(
+		tbl.ID.EQ(pg.Int64(examID)),
+	)
+
+	_, err := deleteStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "delete exam failed", 0)
+	}
+	return nil
+}
+
+// TouchExams 更新测验的修改时间，题目被删除或恢复时调用.
+func TouchExams(
+	ctx context.Context,
+	db qrm.DB,
+	examIDs []int64,
+	now time.Time,
+) error {
+	if len(examIDs) == 0 {
+		return nil
+	}
+
+	tbl := table.Quizzes
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).WHERE(
+		tbl.ID.IN(util.BuildInt64Expressions(examIDs)...),
+	)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "touch exams failed", 0)
+	}
+	return nil
+}
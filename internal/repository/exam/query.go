@@ -0,0 +1,340 @@
+package exam_repo
+
+import (
+	"context"
+	"strings"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+)
+
+func GetExams(
+	ctx context.Context,
+	db qrm.DB,
+	params dao.ExamListParams,
+) (*dao.ExamListResult, error) {
+	tbl := table.Quizzes
+	userTbl := table.Users
+
+	offset := (params.Page - 1) * params.NumPerPage
+	if offset < 0 {
+		offset = 0
+	}
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.
+			LEFT_JOIN(userTbl, tbl.CreatedBy.EQ(userTbl.ID)),
+	).
+		WHERE(
+			buildExamCondition(params),
+		).
+		ORDER_BY(buildExamOrder(params), tbl.ID.DESC()). // 排序值相同时按 ID 稳定排序
+		LIMIT(int64(params.NumPerPage)).
+		OFFSET(int64(offset))
+
+	// 先获取总数
+	countStmt := pg.SELECT(pg.COUNT(pg.STAR)).
+		FROM(tbl).
+		WHERE(buildExamCondition(params))
+	var countResult struct {
+		Count int64 `alias:"count"`
+	}
+	err := countStmt.QueryContext(ctx, db, &countResult)
+	if err != nil {
+		return nil, err
+	}
+
+	var exams []dao.SimpleExam
+	err = stmt.QueryContext(ctx, db, &exams)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dao.ExamListResult{
+		Exams: exams,
+		Total: int(countResult.Count),
+	}, nil
+}
+
+func buildExamCondition(params dao.ExamListParams) pg.BoolExpression {
+	tbl := table.Quizzes
+	transTbl := table.QuizTranslations
+
+	condition := pg.Bool(true)
+
+	if params.IsPublic != nil {
+		if *params.IsPublic {
+			condition = condition.AND(tbl.Public.IS_TRUE())
+		} else {
+			condition = condition.AND(tbl.Public.IS_FALSE())
+		}
+	}
+
+	// 添加创建者过滤
+	if params.Author != nil {
+		userID := *params.Author
+		condition = condition.AND(tbl.CreatedBy.EQ(pg.Int64(userID)))
+	}
+
+	// 添加分类过滤（categories 数组中包含该分类）
+	if params.Category != nil {
+		cat := string(*params.Category)
+		condition = condition.AND(pg.NewEnumValue(cat).EQ(pg.ANY(tbl.Categories)))
+	}
+
+	// 添加难度过滤
+	if params.Difficulty != nil {
+		diff := string(*params.Difficulty)
+		condition = condition.AND(tbl.Difficulty.EQ(pg.NewEnumValue(diff)))
+	}
+
+	// 添加关键字搜索（在翻译表的 title 中搜索）
+	if params.Query != nil && *params.Query != "" {
+		searchTerm := "%" + strings.ToLower(*params.Query) + "%"
+
+		condition = condition.AND(
+			pg.EXISTS(
+				pg.SELECT(pg.Int(1)).
+					FROM(transTbl).
+					WHERE(
+						transTbl.QuizID.EQ(tbl.ID).
+							AND(
+								pg.LOWER(transTbl.Title).LIKE(pg.String(searchTerm)),
+							),
+					),
+			),
+		)
+	}
+
+	return condition
+}
+
+func buildExamOrder(params dao.ExamListParams) pg.OrderByClause {
+	tbl := table.Quizzes
+	var orderExpr pg.Expression
+
+	switch params.SortBy {
+	case "updated_at":
+		orderExpr = tbl.UpdatedAt
+	case "difficulty":
+		// 难度排序：easy < medium < hard
+		orderExpr = pg.CASE().
+			WHEN(tbl.Difficulty.EQ(pg.String("easy"))).THEN(pg.Int(1)).
+			WHEN(tbl.Difficulty.EQ(pg.String("medium"))).THEN(pg.Int(2)).
+			WHEN(tbl.Difficulty.EQ(pg.String("hard"))).THEN(pg.Int(3)).
+			ELSE(pg.Int(0))
+	case "likes":
+		orderExpr = tbl.LikesCount
+	case "time_limit":
+		orderExpr = tbl.TimeLimit
+	case "trending": // 热度，由定时任务更新
+		orderExpr = tbl.TrendingScore
+	default: // created_at
+		orderExpr = tbl.CreatedAt
+	}
+
+	if params.SortDesc {
+		return orderExpr.DESC()
+	}
+	return orderExpr.ASC()
+}
+
+func GetExamByUUID(
+	ctx context.Context,
+	db qrm.DB,
+	examUUID uuid.UUID,
+) (*dao.SimpleExam, error) {
+	tbl := table.Quizzes
+	userTbl := table.Users
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.LEFT_JOIN(userTbl, tbl.CreatedBy.EQ(userTbl.ID)),
+	).WHERE(
+		tbl.QuizUUID.EQ(pg.UUID(examUUID)),
+	)
+
+	var result []dao.SimpleExam
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "query exam by uuid failed", 0)
+	}
+
+	if len(result) == 0 {
+		return nil, common.ErrExamNotFound
+	}
+
+	return &result[0], nil
+}
+
+// GetExamQuestions 获取测验的题目列表（按题目顺序），不包含已删除的题目.
+func GetExamQuestions(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+) ([]dao.ExamQuestionRow, error) {
+	return getExamQuestions(ctx, db, examID, false)
+}
+
+// GetExamQuestionsWithDeleted 包含已删除的题目，用于提交和回顾已开始的作答.
+func GetExamQuestionsWithDeleted(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+) ([]dao.ExamQuestionRow, error) {
+	return getExamQuestions(ctx, db, examID, true)
+}
+
+func getExamQuestions(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+	includeDeleted bool,
+) ([]dao.ExamQuestionRow, error) {
+	tbl := table.QuizQuestions
+	questionTbl := table.Questions
+
+	condition := tbl.QuizID.EQ(pg.Int64(examID))
+	if !includeDeleted {
+		condition = condition.AND(questionTbl.DeletedAt.IS_NULL())
+	}
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		questionTbl.QuestionUUID.AS("question_uuid"),
+	).FROM(
+		tbl.INNER_JOIN(questionTbl, tbl.QuestionID.EQ(questionTbl.ID)),
+	).WHERE(
+		condition,
+	).ORDER_BY(
+		tbl.QuestionOrder.ASC(),
+	)
+
+	var rows []struct {
+		QuizQuestion model.QuizQuestions
+		QuestionUUID uuid.UUID `alias:"question_uuid"`
+	}
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get exam questions failed", 0)
+	}
+
+	result := make([]dao.ExamQuestionRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, dao.ExamQuestionRow{
+			QuizQuestion: row.QuizQuestion,
+			QuestionUUID: row.QuestionUUID,
+		})
+	}
+
+	return result, nil
+}
+
+// GetExamIDsByQuestion 获取包含指定题目的测验 ID.
+func GetExamIDsByQuestion(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+) ([]int64, error) {
+	tbl := table.QuizQuestions
+
+	stmt := pg.SELECT(tbl.QuizID).
+		DISTINCT().
+		FROM(tbl).
+		WHERE(tbl.QuestionID.EQ(pg.Int64(questionID)))
+
+	var rows []model.QuizQuestions
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get exam ids by question failed", 0)
+	}
+
+	examIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		examIDs = append(examIDs, row.QuizID)
+	}
+	return examIDs, nil
+}
+
+// GetExamIDsByQuestions 获取包含任一题目的测验 ID.
+func GetExamIDsByQuestions(
+	ctx context.Context,
+	db qrm.DB,
+	questionIDs []int64,
+) ([]int64, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+	tbl := table.QuizQuestions
+
+	stmt := pg.SELECT(tbl.QuizID).
+		DISTINCT().
+		FROM(tbl).
+		WHERE(tbl.QuestionID.IN(util.BuildInt64Expressions(questionIDs)...))
+
+	var rows []model.QuizQuestions
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get exam ids by questions failed", 0)
+	}
+
+	examIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		examIDs = append(examIDs, row.QuizID)
+	}
+	return examIDs, nil
+}
+
+func BuildExamsWithLike(
+	ctx context.Context,
+	db qrm.DB,
+	result *dao.ExamListResult,
+) ([]oapi.Exam, error) {
+	examIDs := make([]int64, 0, len(result.Exams))
+	for _, e := range result.Exams {
+		examIDs = append(examIDs, e.Quiz.ID)
+	}
+	// 获取翻译
+	trans, err := GetExamTransByIDs(ctx, db, examIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var likedMap map[int64]int16
+	// 如果用户已登录，获取点赞状态
+	if userClaims, ok := middleware.GetUserFromContextOnly(ctx); ok {
+		likedMap, err = GetExamsLikeStatusByUser(
+			ctx,
+			db,
+			userClaims.UserID,
+			examIDs,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dtos := make([]oapi.Exam, 0, len(result.Exams))
+	for _, e := range result.Exams {
+		id := e.Quiz.ID
+		dtos = append(dtos, transformer.ConvertSimpleToExam(e, trans[id], likedMap[id]))
+	}
+
+	return dtos, nil
+}
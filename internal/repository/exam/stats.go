@@ -0,0 +1,184 @@
+package exam_repo
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/shopspring/decimal"
+)
+
+// 得分率达到该百分比视为通过
+const examPassPercent = 60
+
+// attemptDuration 作答用时（秒），提交时间 - 开始时间
+var attemptDuration = pg.RawInt(
+	"EXTRACT(EPOCH FROM (quiz_attempts.created_at - quiz_attempts.started_at))::INTEGER",
+)
+
+// RecalculateExamStats 重新统计单个测验，提交作答以及删除、恢复题目时在同一事务内调用.
+func RecalculateExamStats(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+) error {
+	// 统计行在创建测验时插入，这里兜底并加行锁，保证并发提交时统计串行
+	err := InsertExamStats(ctx, db, examID)
+	if err != nil {
+		return errors.WrapPrefix(err, "ensure exam stats failed", 0)
+	}
+
+	tbl := table.QuizStats
+	lockStmt := pg.SELECT(tbl.QuizID).
+		FROM(tbl).
+		WHERE(tbl.QuizID.EQ(pg.Int64(examID))).
+		FOR(pg.UPDATE())
+
+	var locked model.QuizStats
+	err = lockStmt.QueryContext(ctx, db, &locked)
+	if err != nil {
+		return errors.WrapPrefix(err, "lock exam stats failed", 0)
+	}
+
+	return recalculateExamStats(ctx, db, table.Quizzes.ID.EQ(pg.Int64(examID)))
+}
+
+// RecalculateAllExamStats 重新统计所有测验，供定时任务校准.
+func RecalculateAllExamStats(
+	ctx context.Context,
+	db qrm.DB,
+) error {
+	return recalculateExamStats(ctx, db, pg.Bool(true))
+}
+
+func recalculateExamStats(
+	ctx context.Context,
+	db qrm.DB,
+	condition pg.BoolExpression,
+) error {
+	quizzesTbl := table.Quizzes
+	attemptsTbl := table.QuizAttempts
+	answersTbl := table.QuizAnswers
+	questionsTbl := table.Questions
+
+	// 成绩统计（没有作答记录的测验也会被重置为 0）
+	attemptStmt := pg.SELECT(
+		quizzesTbl.ID,
+		pg.COUNT(attemptsTbl.ID).AS("attempts_count"),
+		pg.MAXi(attemptsTbl.TotalScore).AS("highest_score"),
+		pg.MINi(attemptDuration).AS("shortest_time"),
+		pg.RawFloat(
+			"COALESCE(ROUND(AVG(quiz_attempts.total_score * 100.0 / NULLIF(quiz_attempts.max_score, 0)), 2), 0)",
+		).AS("average_score"),
+		pg.RawFloat(
+			"COALESCE(ROUND(AVG(CASE WHEN quiz_attempts.total_score * 100 >= quiz_attempts.max_score * #pass "+
+				"THEN 100.0 ELSE 0 END) FILTER (WHERE quiz_attempts.id IS NOT NULL), 2), 0)",
+			pg.RawArgs{"#pass": examPassPercent},
+		).AS("pass_rate"),
+	).FROM(
+		quizzesTbl.
+			LEFT_JOIN(attemptsTbl, attemptsTbl.QuizID.EQ(quizzesTbl.ID)),
+	).WHERE(
+		condition,
+	).GROUP_BY(
+		quizzesTbl.ID,
+	)
+
+	var attemptResults []struct {
+		QuizID        int64           `alias:"quizzes.id"`
+		AttemptsCount int64           `alias:"attempts_count"`
+		HighestScore  *int32          `alias:"highest_score"`
+		ShortestTime  *int32          `alias:"shortest_time"`
+		AverageScore  decimal.Decimal `alias:"average_score"`
+		PassRate      decimal.Decimal `alias:"pass_rate"`
+	}
+	err := attemptStmt.QueryContext(ctx, db, &attemptResults)
+	if err != nil {
+		return errors.WrapPrefix(err, "query exam attempt stats failed", 0)
+	}
+	if len(attemptResults) == 0 {
+		return nil
+	}
+
+	// 答对题数，已软删除的题目不计入，删除或恢复题目后需要重新统计
+	correctStmt := pg.SELECT(
+		attemptsTbl.QuizID,
+		pg.COUNT(pg.STAR).AS("total_correct_answers"),
+	).FROM(
+		answersTbl.
+			INNER_JOIN(attemptsTbl, attemptsTbl.ID.EQ(answersTbl.AttemptID)).
+			INNER_JOIN(quizzesTbl, quizzesTbl.ID.EQ(attemptsTbl.QuizID)).
+			INNER_JOIN(questionsTbl, questionsTbl.ID.EQ(answersTbl.QuestionID)),
+	).WHERE(
+		condition.
+			AND(answersTbl.IsCorrect.IS_TRUE()).
+			AND(questionsTbl.DeletedAt.IS_NULL()),
+	).GROUP_BY(
+		attemptsTbl.QuizID,
+	)
+
+	var correctResults []struct {
+		QuizID              int64 `alias:"quiz_attempts.quiz_id"`
+		TotalCorrectAnswers int64 `alias:"total_correct_answers"`
+	}
+	err = correctStmt.QueryContext(ctx, db, &correctResults)
+	if err != nil {
+		return errors.WrapPrefix(err, "query exam correct answers failed", 0)
+	}
+	correctMap := make(map[int64]int64, len(correctResults))
+	for _, r := range correctResults {
+		correctMap[r.QuizID] = r.TotalCorrectAnswers
+	}
+
+	now := time.Now()
+	stats := make([]model.QuizStats, 0, len(attemptResults))
+	for _, r := range attemptResults {
+		highestScore := int32(0)
+		if r.HighestScore != nil {
+			highestScore = *r.HighestScore
+		}
+		stats = append(stats, model.QuizStats{
+			QuizID:              r.QuizID,
+			AttemptsCount:       r.AttemptsCount,
+			TotalCorrectAnswers: correctMap[r.QuizID],
+			HighestScore:        highestScore,
+			ShortestTime:        r.ShortestTime,
+			AverageScore:        r.AverageScore,
+			PassRate:            r.PassRate,
+			UpdatedAt:           now,
+		})
+	}
+
+	tbl := table.QuizStats
+	upsertStmt := tbl.INSERT(
+		tbl.QuizID,
+		tbl.AttemptsCount,
+		tbl.TotalCorrectAnswers,
+		tbl.HighestScore,
+		tbl.ShortestTime,
+		tbl.AverageScore,
+		tbl.PassRate,
+		tbl.UpdatedAt,
+	).MODELS(stats).
+		ON_CONFLICT(tbl.QuizID).
+		DO_UPDATE(pg.SET(
+			tbl.AttemptsCount.SET(tbl.EXCLUDED.AttemptsCount),
+			tbl.TotalCorrectAnswers.SET(tbl.EXCLUDED.TotalCorrectAnswers),
+			tbl.HighestScore.SET(tbl.EXCLUDED.HighestScore),
+			tbl.ShortestTime.SET(tbl.EXCLUDED.ShortestTime),
+			tbl.AverageScore.SET(tbl.EXCLUDED.AverageScore),
+			tbl.PassRate.SET(tbl.EXCLUDED.PassRate),
+			tbl.UpdatedAt.SET(tbl.EXCLUDED.UpdatedAt),
+		))
+
+	_, err = upsertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "upsert exam stats failed", 0)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package exam_repo
+
+import (
+	"context"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/util"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+func GetExamTransByID(
+	ctx context.Context,
+	db qrm.DB,
+	examID int64,
+) ([]model.QuizTranslations, error) {
+	tbl := table.QuizTranslations
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.QuizID.EQ(pg.Int64(examID)))
+
+	var rows []model.QuizTranslations
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get exam translations failed", 0)
+	}
+
+	return rows, nil
+}
+
+func GetExamTransByIDs(
+	ctx context.Context,
+	db qrm.DB,
+	examIDs []int64,
+) (map[int64][]model.QuizTranslations, error) {
+	if len(examIDs) == 0 {
+		return make(map[int64][]model.QuizTranslations), nil
+	}
+
+	tbl := table.QuizTranslations
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+	).FROM(
+		tbl,
+	).WHERE(
+		tbl.QuizID.IN(util.BuildInt64Expressions(examIDs)...),
+	)
+
+	var rows []model.QuizTranslations
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get multi exam translations failed", 0)
+	}
+
+	result := make(map[int64][]model.QuizTranslations, len(examIDs))
+	for _, row := range rows {
+		result[row.QuizID] = append(result[row.QuizID], row)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package exam_repo
+
+import (
+	"context"
+
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/util"
+
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// 热度权重：作答次数 < 参与人数 < 点赞
+const (
+	trendingAttemptWeight     = 1.0
+	trendingParticipantWeight = 2.0
+	trendingLikeWeight        = 3.0
+)
+
+// RecalculateExamTrendingScores 按时间衰减重新计算测验热度，由定时任务调用.
+func RecalculateExamTrendingScores(
+	ctx context.Context,
+	db qrm.DB,
+) error {
+	attempts := table.QuizAttempts
+	likes := table.QuizLikes
+	tbl := table.Quizzes
+
+	return util.RecalculateTrendingScores(ctx, db, util.TrendingSpec{
+		Name: "exam",
+		Target: util.TrendingTarget{
+			Table: tbl,
+			ID:    tbl.ID,
+			Score: tbl.TrendingScore,
+		},
+		Activity: util.TrendingActivity{
+			Table:     attempts,
+			ID:        attempts.QuizID,
+			UserID:    attempts.UserID,
+			CreatedAt: attempts.CreatedAt,
+		},
+		Likes: util.TrendingLikes{
+			Table:     likes,
+			ID:        likes.QuizID,
+			Value:     likes.Value,
+			UpdatedAt: likes.UpdatedAt,
+		},
+		ActivityWeight:    trendingAttemptWeight,
+		ParticipantWeight: trendingParticipantWeight,
+		LikeWeight:        trendingLikeWeight,
+	})
+}
@@ -2,71 +2,345 @@ package poll_repo
 
 import (
 	"context"
+	"time"
 
 	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
 
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
 )
 
-// GetPollComments 获取投票的评论列表（分页）。
+// buildPollCommentCondition 评论列表条件，已删除的评论只在仍有未删除回复时展示.
+// 楼层模式下只取顶层评论，平铺模式下取全部评论.
+func buildPollCommentCondition(pollID int64, rootsOnly bool) pg.BoolExpression {
+	tbl := table.PollComments
+	replyTbl := table.PollComments.AS("replies")
+
+	var replyCondition pg.BoolExpression
+	condition := tbl.PollID.EQ(pg.Int64(pollID))
+	if rootsOnly {
+		condition = condition.AND(tbl.RootID.IS_NULL())
+		replyCondition = replyTbl.RootID.EQ(tbl.ID)
+	} else {
+		replyCondition = replyTbl.ParentID.EQ(tbl.ID)
+	}
+
+	return condition.AND(
+		tbl.DeletedAt.IS_NULL().OR(
+			pg.EXISTS(
+				pg.SELECT(pg.Int(1)).
+					FROM(replyTbl).
+					WHERE(replyCondition.AND(replyTbl.DeletedAt.IS_NULL())),
+			),
+		),
+	)
+}
+
+// GetPollComments 获取投票的评论列表（分页）.
+// 楼层模式最新的楼层在前，平铺模式按时间先后.
 func GetPollComments(
 	ctx context.Context,
 	db qrm.DB,
 	pollID int64,
+	rootsOnly bool,
 	limit int,
 	offset int,
-) (*[]model.PollComments, error) {
-	// TODO: 实现评论列表获取
-	panic("not implemented")
+) ([]dao.PollComment, error) {
+	tbl := table.PollComments
+	userTbl := table.Users
+
+	orderBy := []pg.OrderByClause{tbl.CreatedAt.ASC(), tbl.ID.ASC()}
+	if rootsOnly {
+		orderBy = []pg.OrderByClause{tbl.CreatedAt.DESC(), tbl.ID.DESC()}
+	}
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.LEFT_JOIN(userTbl, userTbl.ID.EQ(tbl.UserID)),
+	).WHERE(
+		buildPollCommentCondition(pollID, rootsOnly),
+	).ORDER_BY(
+		orderBy...,
+	).LIMIT(int64(limit)).OFFSET(int64(offset))
+
+	var comments []dao.PollComment
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get poll comments failed", 0)
+	}
+	return comments, nil
 }
 
-// GetPollCommentCount 获取投票的评论总数。
+// GetPollCommentCount 获取投票的评论总数，条件与 GetPollComments 一致.
 func GetPollCommentCount(
 	ctx context.Context,
 	db qrm.DB,
 	pollID int64,
+	rootsOnly bool,
 ) (int64, error) {
-	// TODO: 实现评论计数
-	panic("not implemented")
+	tbl := table.PollComments
+
+	stmt := pg.SELECT(pg.COUNT(pg.STAR)).
+		FROM(tbl).
+		WHERE(buildPollCommentCondition(pollID, rootsOnly))
+
+	var result struct {
+		Count int64 `alias:"count"`
+	}
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "get poll comment count failed", 0)
+	}
+	return result.Count, nil
+}
+
+// GetPollCommentReplies 获取楼层下的全部回复，按时间先后.
+func GetPollCommentReplies(
+	ctx context.Context,
+	db qrm.DB,
+	rootIDs []int64,
+) ([]dao.PollComment, error) {
+	if len(rootIDs) == 0 {
+		return []dao.PollComment{}, nil
+	}
+
+	tbl := table.PollComments
+	userTbl := table.Users
+
+	idList := make([]pg.Expression, 0, len(rootIDs))
+	for _, id := range rootIDs {
+		idList = append(idList, pg.Int64(id))
+	}
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.LEFT_JOIN(userTbl, userTbl.ID.EQ(tbl.UserID)),
+	).WHERE(
+		tbl.RootID.IN(idList...),
+	).ORDER_BY(
+		tbl.CreatedAt.ASC(),
+		tbl.ID.ASC(),
+	)
+
+	var comments []dao.PollComment
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get poll comment replies failed", 0)
+	}
+	return comments, nil
+}
+
+// GetPollCommentsByIDs 批量获取评论，用于查找平铺模式下回复的上级评论.
+func GetPollCommentsByIDs(
+	ctx context.Context,
+	db qrm.DB,
+	commentIDs []int64,
+) ([]model.PollComments, error) {
+	if len(commentIDs) == 0 {
+		return []model.PollComments{}, nil
+	}
+
+	tbl := table.PollComments
+
+	idList := make([]pg.Expression, 0, len(commentIDs))
+	for _, id := range commentIDs {
+		idList = append(idList, pg.Int64(id))
+	}
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.ID.IN(idList...))
+
+	var comments []model.PollComments
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get poll comments by ids failed", 0)
+	}
+	return comments, nil
+}
+
+// GetPollCommentByUUID 获取投票下的指定评论，包含已删除的评论.
+func GetPollCommentByUUID(
+	ctx context.Context,
+	db qrm.DB,
+	pollID int64,
+	commentUUID uuid.UUID,
+) (*dao.PollComment, error) {
+	tbl := table.PollComments
+	userTbl := table.Users
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.LEFT_JOIN(userTbl, userTbl.ID.EQ(tbl.UserID)),
+	).WHERE(
+		tbl.CommentUUID.EQ(pg.UUID(commentUUID)).
+			AND(tbl.PollID.EQ(pg.Int64(pollID))),
+	)
+
+	var comments []dao.PollComment
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get poll comment failed", 0)
+	}
+	if len(comments) == 0 {
+		return nil, common.ErrCommentNotFound
+	}
+	return &comments[0], nil
+}
+
+// GetPollCommentByCommentUUID 不限定投票，根据 UUID 获取评论，用于举报.
+func GetPollCommentByCommentUUID(
+	ctx context.Context,
+	db qrm.DB,
+	commentUUID uuid.UUID,
+) (*model.PollComments, error) {
+	tbl := table.PollComments
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.CommentUUID.EQ(pg.UUID(commentUUID)))
+
+	var comments []model.PollComments
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get poll comment failed", 0)
+	}
+	if len(comments) == 0 {
+		return nil, common.ErrCommentNotFound
+	}
+	return &comments[0], nil
 }
 
-// GetMultiplePollsCommentsCount 批量获取多个投票的评论数（避免N+1查询）。
+// GetMultiplePollsCommentsCount 批量获取多个投票的评论数（避免N+1查询），不含已删除的评论.
 func GetMultiplePollsCommentsCount(
 	ctx context.Context,
 	db qrm.DB,
 	pollIDs []int64,
 ) (map[int64]int64, error) {
-	// TODO: 实现批量评论计数
-	panic("not implemented")
+	countMap := make(map[int64]int64)
+	if len(pollIDs) == 0 {
+		return countMap, nil
+	}
+
+	tbl := table.PollComments
+
+	idList := make([]pg.Expression, 0, len(pollIDs))
+	for _, id := range pollIDs {
+		idList = append(idList, pg.Int64(id))
+	}
+
+	stmt := pg.SELECT(
+		tbl.PollID,
+		pg.COUNT(pg.STAR).AS("count"),
+	).FROM(
+		tbl,
+	).WHERE(
+		tbl.PollID.IN(idList...).
+			AND(tbl.DeletedAt.IS_NULL()),
+	).GROUP_BY(
+		tbl.PollID,
+	)
+
+	var results []struct {
+		PollID int64 `alias:"poll_comments.poll_id"`
+		Count  int64 `alias:"count"`
+	}
+	err := stmt.QueryContext(ctx, db, &results)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get multi poll comment count failed", 0)
+	}
+
+	for _, result := range results {
+		countMap[result.PollID] = result.Count
+	}
+	return countMap, nil
 }
 
-// InsertPollComment 添加投票评论。
+// InsertPollComment 添加投票评论.
 func InsertPollComment(
 	ctx context.Context,
 	db qrm.DB,
 	comment model.PollComments,
 ) (*model.PollComments, error) {
-	// TODO: 实现评论插入
-	panic("not implemented")
+	tbl := table.PollComments
+
+	insertStmt := tbl.INSERT(
+		tbl.CommentUUID,
+		tbl.PollID,
+		tbl.UserID,
+		tbl.ParentID,
+		tbl.RootID,
+		tbl.Content,
+		tbl.CreatedAt,
+		tbl.UpdatedAt,
+	).MODEL(comment).RETURNING(tbl.AllColumns)
+
+	var inserted model.PollComments
+	err := insertStmt.QueryContext(ctx, db, &inserted)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "insert poll comment failed", 0)
+	}
+	return &inserted, nil
 }
 
-// UpdatePollComment 编辑投票评论。
+// UpdatePollComment 编辑投票评论.
 func UpdatePollComment(
 	ctx context.Context,
 	db qrm.DB,
 	commentID int64,
 	commentText string,
-) error {
-	// TODO: 实现评论更新
-	panic("not implemented")
+	now time.Time,
+) (*model.PollComments, error) {
+	tbl := table.PollComments
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Content.SET(pg.String(commentText)),
+			tbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(commentID)),
+	).RETURNING(tbl.AllColumns)
+
+	var updated model.PollComments
+	err := updateStmt.QueryContext(ctx, db, &updated)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "update poll comment failed", 0)
+	}
+	return &updated, nil
 }
 
-// DeletePollComment 删除投票评论。
+// DeletePollComment 删除投票评论，保留记录以便回复显示为 [deleted].
 func DeletePollComment(
 	ctx context.Context,
 	db qrm.DB,
 	commentID int64,
+	deletedBy int64,
+	now time.Time,
 ) error {
-	// TODO: 实现评论删除
-	panic("not implemented")
+	tbl := table.PollComments
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.DeletedAt.SET(pg.TimestampzT(now)),
+			tbl.DeletedBy.SET(pg.Int64(deletedBy)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(commentID)),
+	)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "delete poll comment failed", 0)
+	}
+	return nil
 }
@@ -173,18 +173,6 @@ func GetMultiplePollsLikesCount(
 	return likesCountMap, nil
 }
 
-// ============================================
-// 投票评论功能预留
-// ============================================
-// 以下函数为投票评论功能预留，表结构已在迁移文件中创建（poll_comments）
-// 实现时需要添加：
-// - GetPollComments: 获取投票的评论列表
-// - GetPollCommentCount: 获取投票的评论总数
-// - InsertPollComment: 添加投票评论
-// - UpdatePollComment: 编辑投票评论
-// - DeletePollComment: 删除投票评论
-// - GetMultiplePollsCommentsCount: 批量获取多个投票的评论数（避免N+1查询）
-
 // UpdatePollLikeCount 更新投票的总点赞数。
 func UpdatePollLikeCount(
 	ctx context.Context,
@@ -2,6 +2,7 @@ package poll_repo
 
 import (
 	"context"
+	"time"
 
 	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/db/genshinquiz/public/table"
@@ -165,3 +166,74 @@ func UpsertUserVote(
 	}
 	return nil
 }
+
+// HidePoll 版主隐藏投票，投票不再出现在列表和详情中.
+func HidePoll(
+	ctx context.Context,
+	db qrm.DB,
+	pollID int64,
+	hiddenBy int64,
+	now time.Time,
+) error {
+	tbl := table.Polls
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.HiddenAt.SET(pg.TimestampzT(now)),
+			tbl.HiddenBy.SET(pg.Int64(hiddenBy)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(pollID)),
+	)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "hide poll failed", 0)
+	}
+	return nil
+}
+
+// HidePollsByAuthor 隐藏作者的全部投票.
+func HidePollsByAuthor(
+	ctx context.Context,
+	db qrm.DB,
+	authorID int64,
+	now time.Time,
+) error {
+	tbl := table.Polls
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.HiddenAt.SET(pg.TimestampzT(now)),
+			tbl.HiddenBy.SET(pg.Int64(authorID)),
+		).
+		WHERE(
+			tbl.CreatedBy.EQ(pg.Int64(authorID)).
+				AND(tbl.HiddenAt.IS_NULL()),
+		)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "hide polls by author failed", 0)
+	}
+	return nil
+}
+
+// ReassignPollAuthor 将作者的全部投票转给另一个账号.
+func ReassignPollAuthor(
+	ctx context.Context,
+	db qrm.DB,
+	fromUserID int64,
+	toUserID int64,
+) error {
+	tbl := table.Polls
+
+	updateStmt := tbl.UPDATE().
+		SET(tbl.CreatedBy.SET(pg.Int64(toUserID))).
+		WHERE(tbl.CreatedBy.EQ(pg.Int64(fromUserID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "reassign poll author failed", 0)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"genshin-quiz/internal/common"
 	"genshin-quiz/internal/dao"
 	"genshin-quiz/internal/dao/transformer"
+	"genshin-quiz/internal/enum"
 	"genshin-quiz/internal/util"
 	"genshin-quiz/internal/webserver/middleware"
 
@@ -43,7 +44,7 @@ func GetPolls(
 		WHERE(
 			buildPollCondition(params),
 		).
-		ORDER_BY(buildPollOrderBy(params)).
+		ORDER_BY(buildPollOrderBy(params), tbl.ID.DESC()). // 排序值相同时按 ID 稳定排序
 		LIMIT(int64(params.NumPerPage)).
 		OFFSET(int64(offset))
 
@@ -76,7 +77,22 @@ func buildPollCondition(params dao.PollListParams) pg.BoolExpression {
 	tbl := table.Polls
 	transTbl := table.PollTranslations
 
-	condition := pg.Bool(true)
+	authorTbl := table.Users.AS("authors")
+
+	// 被版主隐藏的投票不出现在列表中
+	condition := tbl.HiddenAt.IS_NULL()
+
+	// 被封禁或已注销用户的投票不出现在列表中
+	condition = condition.AND(
+		pg.EXISTS(
+			pg.SELECT(pg.Int(1)).
+				FROM(authorTbl).
+				WHERE(
+					authorTbl.ID.EQ(tbl.CreatedBy).
+						AND(authorTbl.Status.EQ(pg.Int16(int16(enum.UserStatusActive)))),
+				),
+		),
+	)
 
 	if params.IsPublic != nil {
 		if *params.IsPublic {
@@ -149,6 +165,8 @@ func buildPollOrderBy(params dao.PollListParams) pg.OrderByClause {
 		orderExpr = tbl.TotalVotesCount
 	case "likes":
 		orderExpr = tbl.LikesCount
+	case "trending": // 热度，由定时任务更新
+		orderExpr = tbl.TrendingScore
 	default: // created_at
 		orderExpr = tbl.CreatedAt
 	}
@@ -173,13 +191,17 @@ func GetPollByUUID(
 	).FROM(
 		tbl.LEFT_JOIN(userTbl, tbl.CreatedBy.EQ(userTbl.ID)),
 	).WHERE(
-		tbl.PollUUID.EQ(pg.UUID(voteUUID)),
+		tbl.PollUUID.EQ(pg.UUID(voteUUID)).
+			AND(tbl.HiddenAt.IS_NULL()),
 	)
 
 	var result dao.SimplePoll
 
 	err := stmt.QueryContext(ctx, db, &result)
 	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, common.ErrPollNotFound
+		}
 		return nil, errors.WrapPrefix(err, "get poll by uuid failed", 0)
 	}
 
@@ -362,6 +384,11 @@ func BuildPollsWithLike(
 		return nil, err
 	}
 
+	commentsMap, err := GetMultiplePollsCommentsCount(ctx, db, pollIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var userVoted map[int64]bool
 	var userLikeStatus map[int64]int16
 	// 如果用户已登录，检查投票状态和点赞状态
@@ -398,6 +425,8 @@ func BuildPollsWithLike(
 		likeStatus := userLikeStatus[id]
 
 		dto := transformer.ConvertSimplePollToDTO(poll, trans[id], voted, likeStatus)
+		commentsCount := int(commentsMap[id])
+		dto.CommentsCount = &commentsCount
 		dtos = append(dtos, dto)
 	}
 
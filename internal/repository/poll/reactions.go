@@ -0,0 +1,100 @@
+package poll_repo
+
+import (
+	"context"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/util"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// GetPollCommentReactions 批量统计评论的表情回应，viewerID 为 0 时 Reacted 均为 false.
+func GetPollCommentReactions(
+	ctx context.Context,
+	db qrm.DB,
+	commentIDs []int64,
+	viewerID int64,
+) ([]dao.PollCommentReaction, error) {
+	if len(commentIDs) == 0 {
+		return []dao.PollCommentReaction{}, nil
+	}
+
+	tbl := table.PollCommentReactions
+
+	stmt := pg.SELECT(
+		tbl.CommentID,
+		tbl.Reaction,
+		pg.COUNT(pg.STAR).AS("count"),
+		pg.BOOL_OR(tbl.UserID.EQ(pg.Int64(viewerID))).AS("reacted"),
+	).FROM(
+		tbl,
+	).WHERE(
+		tbl.CommentID.IN(util.BuildInt64Expressions(commentIDs)...),
+	).GROUP_BY(
+		tbl.CommentID,
+		tbl.Reaction,
+	).ORDER_BY(
+		tbl.CommentID,
+		tbl.Reaction,
+	)
+
+	var reactions []dao.PollCommentReaction
+	err := stmt.QueryContext(ctx, db, &reactions)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get poll comment reactions failed", 0)
+	}
+	return reactions, nil
+}
+
+// InsertPollCommentReaction 添加表情回应，已回应过时忽略.
+func InsertPollCommentReaction(
+	ctx context.Context,
+	db qrm.DB,
+	reaction model.PollCommentReactions,
+) error {
+	tbl := table.PollCommentReactions
+
+	insertStmt := tbl.INSERT(
+		tbl.CommentID,
+		tbl.UserID,
+		tbl.Reaction,
+		tbl.CreatedAt,
+	).MODEL(reaction).
+		ON_CONFLICT(tbl.CommentID, tbl.UserID, tbl.Reaction).
+		DO_NOTHING()
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "insert poll comment reaction failed", 0)
+	}
+	return nil
+}
+
+// DeletePollCommentReaction 取消表情回应.
+func DeletePollCommentReaction(
+	ctx context.Context,
+	db qrm.DB,
+	commentID int64,
+	userID int64,
+	reaction string,
+) error {
+	tbl := table.PollCommentReactions
+
+	deleteStmt := tbl.DELETE().
+		WHERE(
+			tbl.CommentID.EQ(pg.Int64(commentID)).
+				AND(tbl.UserID.EQ(pg.Int64(userID))).
+				AND(tbl.Reaction.EQ(pg.String(reaction))),
+		)
+
+	_, err := deleteStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "delete poll comment reaction failed", 0)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package poll_repo
+
+import (
+	"context"
+
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/util"
+
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// 热度权重：投票数 < 参与人数 < 点赞
+const (
+	trendingVoteWeight        = 1.0
+	trendingParticipantWeight = 2.0
+	trendingLikeWeight        = 3.0
+)
+
+// RecalculatePollTrendingScores 按时间衰减重新计算投票热度，由定时任务调用.
+func RecalculatePollTrendingScores(
+	ctx context.Context,
+	db qrm.DB,
+) error {
+	votes := table.UserVotes
+	likes := table.PollLikes
+	tbl := table.Polls
+
+	return util.RecalculateTrendingScores(ctx, db, util.TrendingSpec{
+		Name: "poll",
+		Target: util.TrendingTarget{
+			Table: tbl,
+			ID:    tbl.ID,
+			Score: tbl.TrendingScore,
+		},
+		Activity: util.TrendingActivity{
+			Table:     votes,
+			ID:        votes.PollID,
+			UserID:    votes.UserID,
+			CreatedAt: votes.CreatedAt,
+			Amount:    votes.VoteCount,
+		},
+		Likes: util.TrendingLikes{
+			Table:     likes,
+			ID:        likes.PollID,
+			Value:     likes.Value,
+			UpdatedAt: likes.UpdatedAt,
+		},
+		ActivityWeight:    trendingVoteWeight,
+		ParticipantWeight: trendingParticipantWeight,
+		LikeWeight:        trendingLikeWeight,
+	})
+}
@@ -2,71 +2,330 @@ package question_repo
 
 import (
 	"context"
+	"time"
 
 	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
 
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
 )
 
-// GetQuestionComments 获取问题的评论列表（分页）。
+// buildCommentRootCondition 顶层楼层条件，已删除且没有未删除回复的楼层不展示.
+func buildCommentRootCondition(questionID int64) pg.BoolExpression {
+	tbl := table.QuestionComments
+	replyTbl := table.QuestionComments.AS("replies")
+
+	return tbl.QuestionID.EQ(pg.Int64(questionID)).
+		AND(tbl.RootID.IS_NULL()).
+		AND(
+			tbl.DeletedAt.IS_NULL().OR(
+				pg.EXISTS(
+					pg.SELECT(pg.Int(1)).
+						FROM(replyTbl).
+						WHERE(
+							replyTbl.RootID.EQ(tbl.ID).
+								AND(replyTbl.DeletedAt.IS_NULL()),
+						),
+				),
+			),
+		)
+}
+
+// GetQuestionComments 分页获取题目的顶层评论，最新的在前.
 func GetQuestionComments(
 	ctx context.Context,
 	db qrm.DB,
 	questionID int64,
 	limit int,
 	offset int,
-) (*[]model.QuestionComments, error) {
-	// TODO: 实现评论列表获取
-	panic("not implemented")
+) ([]dao.QuestionComment, error) {
+	tbl := table.QuestionComments
+	userTbl := table.Users
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.INNER_JOIN(userTbl, userTbl.ID.EQ(tbl.UserID)),
+	).WHERE(
+		buildCommentRootCondition(questionID),
+	).ORDER_BY(
+		tbl.CreatedAt.DESC(),
+		tbl.ID.DESC(),
+	).LIMIT(int64(limit)).OFFSET(int64(offset))
+
+	var comments []dao.QuestionComment
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get question comments failed", 0)
+	}
+	return comments, nil
 }
 
-// GetQuestionCommentCount 获取问题的评论总数。
+// GetQuestionCommentCount 获取题目的楼层总数，条件与 GetQuestionComments 一致.
 func GetQuestionCommentCount(
 	ctx context.Context,
 	db qrm.DB,
 	questionID int64,
 ) (int64, error) {
-	// TODO: 实现评论计数
-	panic("not implemented")
+	tbl := table.QuestionComments
+
+	stmt := pg.SELECT(pg.COUNT(pg.STAR)).
+		FROM(tbl).
+		WHERE(buildCommentRootCondition(questionID))
+
+	var result struct {
+		Count int64 `alias:"count"`
+	}
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "get question comment count failed", 0)
+	}
+	return result.Count, nil
+}
+
+// GetQuestionCommentReplies 获取楼层下的全部回复，按时间先后.
+func GetQuestionCommentReplies(
+	ctx context.Context,
+	db qrm.DB,
+	rootIDs []int64,
+) ([]dao.QuestionComment, error) {
+	if len(rootIDs) == 0 {
+		return []dao.QuestionComment{}, nil
+	}
+
+	tbl := table.QuestionComments
+	userTbl := table.Users
+
+	idList := make([]pg.Expression, 0, len(rootIDs))
+	for _, id := range rootIDs {
+		idList = append(idList, pg.Int64(id))
+	}
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.INNER_JOIN(userTbl, userTbl.ID.EQ(tbl.UserID)),
+	).WHERE(
+		tbl.RootID.IN(idList...),
+	).ORDER_BY(
+		tbl.CreatedAt.ASC(),
+		tbl.ID.ASC(),
+	)
+
+	var comments []dao.QuestionComment
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get question comment replies failed", 0)
+	}
+	return comments, nil
+}
+
+// GetQuestionCommentByUUID 获取题目下的指定评论，包含已删除的评论.
+func GetQuestionCommentByUUID(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+	commentUUID uuid.UUID,
+) (*dao.QuestionComment, error) {
+	tbl := table.QuestionComments
+	userTbl := table.Users
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.INNER_JOIN(userTbl, userTbl.ID.EQ(tbl.UserID)),
+	).WHERE(
+		tbl.CommentUUID.EQ(pg.UUID(commentUUID)).
+			AND(tbl.QuestionID.EQ(pg.Int64(questionID))),
+	)
+
+	var comments []dao.QuestionComment
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get question comment failed", 0)
+	}
+	if len(comments) == 0 {
+		return nil, common.ErrCommentNotFound
+	}
+	return &comments[0], nil
+}
+
+// GetQuestionCommentByID 根据内部 ID 获取评论，用于查找回复的上级评论.
+func GetQuestionCommentByID(
+	ctx context.Context,
+	db qrm.DB,
+	commentID int64,
+) (*model.QuestionComments, error) {
+	tbl := table.QuestionComments
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.ID.EQ(pg.Int64(commentID)))
+
+	var comments []model.QuestionComments
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get question comment failed", 0)
+	}
+	if len(comments) == 0 {
+		return nil, common.ErrCommentNotFound
+	}
+	return &comments[0], nil
 }
 
-// GetMultipleQuestionsCommentsCount 批量获取多个问题的评论数（避免N+1查询）。
+// GetQuestionCommentByCommentUUID 不限定题目，根据 UUID 获取评论，用于举报.
+func GetQuestionCommentByCommentUUID(
+	ctx context.Context,
+	db qrm.DB,
+	commentUUID uuid.UUID,
+) (*model.QuestionComments, error) {
+	tbl := table.QuestionComments
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.CommentUUID.EQ(pg.UUID(commentUUID)))
+
+	var comments []model.QuestionComments
+	err := stmt.QueryContext(ctx, db, &comments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get question comment failed", 0)
+	}
+	if len(comments) == 0 {
+		return nil, common.ErrCommentNotFound
+	}
+	return &comments[0], nil
+}
+
+// GetMultipleQuestionsCommentsCount 批量获取多个问题的评论数（避免N+1查询），不含已删除的评论.
 func GetMultipleQuestionsCommentsCount(
 	ctx context.Context,
 	db qrm.DB,
 	questionIDs []int64,
 ) (map[int64]int64, error) {
-	// TODO: 实现批量评论计数
-	panic("not implemented")
+	countMap := make(map[int64]int64)
+	if len(questionIDs) == 0 {
+		return countMap, nil
+	}
+
+	tbl := table.QuestionComments
+
+	idList := make([]pg.Expression, 0, len(questionIDs))
+	for _, id := range questionIDs {
+		idList = append(idList, pg.Int64(id))
+	}
+
+	stmt := pg.SELECT(
+		tbl.QuestionID,
+		pg.COUNT(pg.STAR).AS("count"),
+	).FROM(
+		tbl,
+	).WHERE(
+		tbl.QuestionID.IN(idList...).
+			AND(tbl.DeletedAt.IS_NULL()),
+	).GROUP_BY(
+		tbl.QuestionID,
+	)
+
+	var results []struct {
+		QuestionID int64 `alias:"question_comments.question_id"`
+		Count      int64 `alias:"count"`
+	}
+	err := stmt.QueryContext(ctx, db, &results)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get multi question comment count failed", 0)
+	}
+
+	for _, result := range results {
+		countMap[result.QuestionID] = result.Count
+	}
+	return countMap, nil
 }
 
-// InsertQuestionComment 添加问题评论。
+// InsertQuestionComment 添加问题评论.
 func InsertQuestionComment(
 	ctx context.Context,
 	db qrm.DB,
 	comment model.QuestionComments,
 ) (*model.QuestionComments, error) {
-	// TODO: 实现评论插入
-	panic("not implemented")
+	tbl := table.QuestionComments
+
+	insertStmt := tbl.INSERT(
+		tbl.QuestionID,
+		tbl.UserID,
+		tbl.Comment,
+		tbl.CommentUUID,
+		tbl.ParentID,
+		tbl.RootID,
+		tbl.IsSpoiler,
+		tbl.CreatedAt,
+		tbl.UpdatedAt,
+	).MODEL(comment).RETURNING(tbl.AllColumns)
+
+	var inserted model.QuestionComments
+	err := insertStmt.QueryContext(ctx, db, &inserted)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "insert question comment failed", 0)
+	}
+	return &inserted, nil
 }
 
-// UpdateQuestionComment 编辑问题评论。
+// UpdateQuestionComment 编辑问题评论.
 func UpdateQuestionComment(
 	ctx context.Context,
 	db qrm.DB,
 	commentID int64,
 	commentText string,
-) error {
-	// TODO: 实现评论更新
-	panic("not implemented")
+	isSpoiler bool,
+	now time.Time,
+) (*model.QuestionComments, error) {
+	tbl := table.QuestionComments
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Comment.SET(pg.String(commentText)),
+			tbl.IsSpoiler.SET(pg.Bool(isSpoiler)),
+			tbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(commentID)),
+	).RETURNING(tbl.AllColumns)
+
+	var updated model.QuestionComments
+	err := updateStmt.QueryContext(ctx, db, &updated)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "update question comment failed", 0)
+	}
+	return &updated, nil
 }
 
-// DeleteQuestionComment 删除问题评论。
+// DeleteQuestionComment 删除问题评论，保留记录以维持楼层结构.
 func DeleteQuestionComment(
 	ctx context.Context,
 	db qrm.DB,
 	commentID int64,
+	deletedBy int64,
+	now time.Time,
 ) error {
-	// TODO: 实现评论删除
-	panic("not implemented")
+	tbl := table.QuestionComments
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.DeletedAt.SET(pg.TimestampzT(now)),
+			tbl.DeletedBy.SET(pg.Int64(deletedBy)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(commentID)),
+	)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "delete question comment failed", 0)
+	}
+	return nil
 }
@@ -2,10 +2,13 @@ package question_repo
 
 import (
 	"context"
+	"time"
 
 	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/util"
 
+	"github.com/go-errors/errors"
 	pg "github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
 )
@@ -98,6 +101,113 @@ func InsertOptionTranslations(
 	return err
 }
 
+// UpdateQuestionContent 更新题目主体并递增修订版本号，返回更新后的题目.
+func UpdateQuestionContent(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+	updateModel model.Questions,
+) (*model.Questions, error) {
+	tbl := table.Questions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.QuestionType.SET(pg.NewEnumValue(string(updateModel.QuestionType))),
+			tbl.Category.SET(pg.NewEnumValue(string(updateModel.Category))),
+			tbl.Difficulty.SET(pg.NewEnumValue(string(updateModel.Difficulty))),
+			tbl.Public.SET(pg.Bool(updateModel.Public)),
+			tbl.CurrentRevision.SET(tbl.CurrentRevision.ADD(pg.Int32(1))),
+			tbl.UpdatedAt.SET(pg.TimestampzT(*updateModel.UpdatedAt)),
+		).
+		WHERE(tbl.ID.EQ(pg.Int64(questionID))).
+		RETURNING(tbl.AllColumns)
+
+	var question model.Questions
+	err := updateStmt.QueryContext(ctx, db, &question)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "update question failed", 0)
+	}
+
+	return &question, nil
+}
+
+// DeleteQuestionTranslations 删除题目的全部翻译，编辑时整体替换.
+func DeleteQuestionTranslations(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+) error {
+	tbl := table.QuestionTranslations
+
+	deleteStmt := tbl.DELETE().
+		WHERE(tbl.QuestionID.EQ(pg.Int64(questionID)))
+
+	_, err := deleteStmt.ExecContext(ctx, db)
+	return err
+}
+
+// UpdateQuestionOption 更新已有选项，保留选项 ID 以便历史提交仍能关联.
+func UpdateQuestionOption(
+	ctx context.Context,
+	db qrm.DB,
+	option model.QuestionOptions,
+) error {
+	tbl := table.QuestionOptions
+
+	updateStmt := tbl.UPDATE(
+		tbl.OptionType,
+		tbl.ImgURL,
+		tbl.IsAnswer,
+	).MODEL(option).
+		WHERE(tbl.ID.EQ(pg.Int64(option.ID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	return err
+}
+
+// RemoveQuestionOptions 标记选项已移除，不物理删除.
+func RemoveQuestionOptions(
+	ctx context.Context,
+	db qrm.DB,
+	optionIDs []int64,
+	now time.Time,
+) error {
+	if len(optionIDs) == 0 {
+		return nil
+	}
+
+	tbl := table.QuestionOptions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.RemovedAt.SET(pg.TimestampzT(now)),
+		).WHERE(
+		tbl.ID.IN(util.BuildInt64Expressions(optionIDs)...),
+	)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	return err
+}
+
+// DeleteOptionTranslations 删除选项翻译，编辑时整体替换.
+func DeleteOptionTranslations(
+	ctx context.Context,
+	db qrm.DB,
+	optionIDs []int64,
+) error {
+	if len(optionIDs) == 0 {
+		return nil
+	}
+
+	tbl := table.QuestionOptionTranslations
+
+	deleteStmt := tbl.DELETE().
+		WHERE(tbl.OptionID.IN(util.BuildInt64Expressions(optionIDs)...))
+
+	_, err := deleteStmt.ExecContext(ctx, db)
+	return err
+}
+
 func UpdateOptionSelected(
 	ctx context.Context,
 	db qrm.DB,
@@ -131,6 +241,7 @@ func InsertSubmission(
 		tbl.SubmissionUUID,
 		tbl.QuestionID,
 		tbl.UserID,
+		tbl.RevisionID,
 		tbl.IsPractice,
 		// tbl.SelectedOptionIDs,
 		tbl.IsCorrect,
@@ -231,3 +342,211 @@ func UpdateQuestionLikeCount(
 	_, err := updateStmt.ExecContext(ctx, db)
 	return err
 }
+
+// SoftDeleteQuestions 标记题目已删除，测验引用和提交记录保留.
+// 已删除的题目跳过，返回本次删除的题目 ID.
+func SoftDeleteQuestions(
+	ctx context.Context,
+	db qrm.DB,
+	questionIDs []int64,
+	deletedBy int64,
+	now time.Time,
+) ([]int64, error) {
+	if len(questionIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	tbl := table.Questions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.DeletedAt.SET(pg.TimestampzT(now)),
+			tbl.DeletedBy.SET(pg.Int64(deletedBy)),
+		).
+		WHERE(
+			tbl.ID.IN(util.BuildInt64Expressions(questionIDs)...).
+				AND(tbl.DeletedAt.IS_NULL()),
+		).
+		RETURNING(tbl.ID)
+
+	var rows []model.Questions
+	err := updateStmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "soft delete questions failed", 0)
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids, nil
+}
+
+// ReassignQuestionAuthor 将作者的全部题目转给另一个账号.
+func ReassignQuestionAuthor(
+	ctx context.Context,
+	db qrm.DB,
+	fromUserID int64,
+	toUserID int64,
+) error {
+	tbl := table.Questions
+
+	updateStmt := tbl.UPDATE().
+		SET(tbl.CreatedBy.SET(pg.Int64(toUserID))).
+		WHERE(tbl.CreatedBy.EQ(pg.Int64(fromUserID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "reassign question author failed", 0)
+	}
+	return nil
+}
+
+// RestoreQuestion 恢复已删除的题目.
+func RestoreQuestion(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+) error {
+	tbl := table.Questions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.DeletedAt.SET(pg.TimestampzExp(pg.NULL)),
+			tbl.DeletedBy.SET(pg.IntExp(pg.NULL)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(questionID)),
+	)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "restore question failed", 0)
+	}
+	return nil
+}
+
+// PurgeQuestion 彻底删除题目，选项/翻译/提交/测验引用随外键级联删除.
+func PurgeQuestion(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+) error {
+	tbl := table.Questions
+
+	deleteStmt := tbl.DELETE().
+		WHERE(tbl.ID.EQ(pg.Int64(questionID)))
+
+	_, err := deleteStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "purge question failed", 0)
+	}
+	return nil
+}
+
+// SubmitQuestionForReview 作者提交审核，清除上一次的驳回原因.
+func SubmitQuestionForReview(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+	now time.Time,
+) (*model.Questions, error) {
+	tbl := table.Questions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.NewEnumValue(model.QuestionStatus_PendingReview.String())),
+			tbl.SubmittedAt.SET(pg.TimestampzT(now)),
+			tbl.ReviewReason.SET(pg.StringExp(pg.NULL)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(questionID)),
+	).RETURNING(tbl.AllColumns)
+
+	var question model.Questions
+	err := updateStmt.QueryContext(ctx, db, &question)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "submit question for review failed", 0)
+	}
+	return &question, nil
+}
+
+// ReturnQuestionToReview 已审核的题目内容被修改后重新进入待审核，撤下发布并清除上一次的审核结果.
+func ReturnQuestionToReview(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+	now time.Time,
+) (*model.Questions, error) {
+	tbl := table.Questions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.NewEnumValue(model.QuestionStatus_PendingReview.String())),
+			tbl.IsPublished.SET(pg.Bool(false)),
+			tbl.PublishedAt.SET(pg.TimestampzExp(pg.NULL)),
+			tbl.SubmittedAt.SET(pg.TimestampzT(now)),
+			tbl.ReviewedBy.SET(pg.IntExp(pg.NULL)),
+			tbl.ReviewedAt.SET(pg.TimestampzExp(pg.NULL)),
+			tbl.ReviewReason.SET(pg.StringExp(pg.NULL)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(questionID)),
+	).RETURNING(tbl.AllColumns)
+
+	var question model.Questions
+	err := updateStmt.QueryContext(ctx, db, &question)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "return question to review failed", 0)
+	}
+	return &question, nil
+}
+
+// UpdateQuestionReview 记录审核结果，发布状态和发布时间一并更新.
+func UpdateQuestionReview(
+	ctx context.Context,
+	db qrm.DB,
+	review model.Questions,
+) (*model.Questions, error) {
+	tbl := table.Questions
+
+	updateStmt := tbl.UPDATE(
+		tbl.Status,
+		tbl.IsPublished,
+		tbl.PublishedAt,
+		tbl.ReviewedBy,
+		tbl.ReviewedAt,
+		tbl.ReviewReason,
+	).MODEL(review).WHERE(
+		tbl.ID.EQ(pg.Int64(review.ID)),
+	).RETURNING(tbl.AllColumns)
+
+	var question model.Questions
+	err := updateStmt.QueryContext(ctx, db, &question)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "update question review failed", 0)
+	}
+	return &question, nil
+}
+
+// PublishDueQuestions 发布已审核通过且到达发布时间的题目，返回发布数量.
+func PublishDueQuestions(
+	ctx context.Context,
+	db qrm.DB,
+	now time.Time,
+) (int64, error) {
+	tbl := table.Questions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.NewEnumValue(model.QuestionStatus_Published.String())),
+			tbl.IsPublished.SET(pg.Bool(true)),
+		).WHERE(
+		tbl.Status.EQ(pg.NewEnumValue(model.QuestionStatus_Approved.String())).
+			AND(tbl.PublishedAt.LT_EQ(pg.TimestampzT(now))).
+			AND(tbl.DeletedAt.IS_NULL()),
+	)
+
+	res, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "publish due questions failed", 0)
+	}
+	return res.RowsAffected()
+}
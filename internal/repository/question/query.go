@@ -9,6 +9,7 @@ import (
 	"genshin-quiz/generated/oapi"
 	dao "genshin-quiz/internal/dao"
 	"genshin-quiz/internal/dao/transformer"
+	"genshin-quiz/internal/enum"
 	"genshin-quiz/internal/webserver/middleware"
 
 	"genshin-quiz/internal/common"
@@ -75,7 +76,22 @@ func buildQuestionCondition(params dao.QuestionListParams) pg.BoolExpression {
 	tbl := table.Questions
 	transTbl := table.QuestionTranslations
 
-	condition := pg.Bool(true)
+	authorTbl := table.Users.AS("authors")
+
+	// 已删除的题目不出现在列表和题库中
+	condition := tbl.DeletedAt.IS_NULL()
+
+	// 被封禁或已注销用户的题目不出现在列表中
+	condition = condition.AND(
+		pg.EXISTS(
+			pg.SELECT(pg.Int(1)).
+				FROM(authorTbl).
+				WHERE(
+					authorTbl.ID.EQ(tbl.CreatedBy).
+						AND(authorTbl.Status.EQ(pg.Int16(int16(enum.UserStatusActive)))),
+				),
+		),
+	)
 
 	if params.IsPublic != nil {
 		if *params.IsPublic {
@@ -93,6 +109,10 @@ func buildQuestionCondition(params dao.QuestionListParams) pg.BoolExpression {
 		}
 	}
 
+	if params.Status != nil {
+		condition = condition.AND(tbl.Status.EQ(pg.NewEnumValue(params.Status.String())))
+	}
+
 	// 添加创建者过滤
 	if params.Author != nil {
 		userID := *params.Author
@@ -115,6 +135,24 @@ func buildQuestionCondition(params dao.QuestionListParams) pg.BoolExpression {
 		condition = condition.AND(tbl.Difficulty.IN(diffExp...))
 	}
 
+	// 添加语言过滤（必须有对应语言的翻译）
+	if params.Language != nil && len(*params.Language) > 0 {
+		langExp := make([]pg.Expression, 0, len(*params.Language))
+		for _, lang := range *params.Language {
+			langExp = append(langExp, pg.String(lang))
+		}
+		condition = condition.AND(
+			pg.EXISTS(
+				pg.SELECT(pg.Int(1)).
+					FROM(transTbl).
+					WHERE(
+						transTbl.QuestionID.EQ(tbl.ID).
+							AND(transTbl.Language.IN(langExp...)),
+					),
+			),
+		)
+	}
+
 	// 添加关键字搜索（在翻译表的question_text中搜索）
 	if params.Query != nil && *params.Query != "" {
 		searchTerm := "%" + strings.ToLower(*params.Query) + "%"
@@ -136,6 +174,40 @@ func buildQuestionCondition(params dao.QuestionListParams) pg.BoolExpression {
 	return condition
 }
 
+// GetRandomQuestionIDs 按列表过滤条件随机抽取题目 ID.
+func GetRandomQuestionIDs(
+	ctx context.Context,
+	db qrm.DB,
+	params dao.QuestionListParams,
+	limit int,
+) ([]int64, error) {
+	tbl := table.Questions
+
+	stmt := pg.SELECT(
+		tbl.ID,
+	).FROM(
+		tbl,
+	).WHERE(
+		buildQuestionCondition(params),
+	).ORDER_BY(
+		pg.RawFloat("random()").ASC(),
+	).LIMIT(int64(limit))
+
+	var results []struct {
+		ID int64 `alias:"questions.id"`
+	}
+	err := stmt.QueryContext(ctx, db, &results)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get random question ids failed", 0)
+	}
+
+	ids := make([]int64, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	return ids, nil
+}
+
 func buildQuestionOrder(params dao.QuestionListParams) pg.OrderByClause {
 	tbl := table.Questions
 	var orderExpr pg.Expression
@@ -156,6 +228,8 @@ func buildQuestionOrder(params dao.QuestionListParams) pg.OrderByClause {
 		orderExpr = tbl.SubmitCount
 	case "CorrectRate":
 		orderExpr = tbl.CorrectCount
+	case "SubmittedAt": // 提交审核时间
+		orderExpr = tbl.SubmittedAt
 	default:
 		orderExpr = tbl.PublishedAt
 	}
@@ -170,17 +244,40 @@ func GetQuestionByUUID(
 	ctx context.Context,
 	db qrm.DB,
 	uuid uuid.UUID,
+) (*dao.SimpleQuestion, error) {
+	return getQuestionByUUID(ctx, db, uuid, false)
+}
+
+// GetQuestionByUUIDWithDeleted 包含已删除的题目，用于恢复和彻底删除.
+func GetQuestionByUUIDWithDeleted(
+	ctx context.Context,
+	db qrm.DB,
+	uuid uuid.UUID,
+) (*dao.SimpleQuestion, error) {
+	return getQuestionByUUID(ctx, db, uuid, true)
+}
+
+func getQuestionByUUID(
+	ctx context.Context,
+	db qrm.DB,
+	uuid uuid.UUID,
+	includeDeleted bool,
 ) (*dao.SimpleQuestion, error) {
 	tbl := table.Questions
 	userTbl := table.Users
 
+	condition := tbl.QuestionUUID.EQ(pg.UUID(uuid))
+	if !includeDeleted {
+		condition = condition.AND(tbl.DeletedAt.IS_NULL())
+	}
+
 	stmt := pg.SELECT(
 		tbl.AllColumns,
 		userTbl.UserUUID,
 	).FROM(
 		tbl.LEFT_JOIN(userTbl, userTbl.ID.EQ(tbl.CreatedBy)),
 	).WHERE(
-		tbl.QuestionUUID.EQ(pg.UUID(uuid)),
+		condition,
 	)
 
 	var result []dao.SimpleQuestion
@@ -231,7 +328,8 @@ func GetQuestionIDByUUID(
 	stmt := pg.SELECT(tbl.AllColumns).
 		FROM(tbl).
 		WHERE(
-			tbl.QuestionUUID.EQ(pg.UUID(uuid)),
+			tbl.QuestionUUID.EQ(pg.UUID(uuid)).
+				AND(tbl.DeletedAt.IS_NULL()),
 		)
 
 	var dbID []model.Questions
@@ -247,6 +345,92 @@ func GetQuestionIDByUUID(
 	return &dbID[0].ID, nil
 }
 
+// GetQuestionIDsByAuthor 获取作者未删除的题目 ID.
+func GetQuestionIDsByAuthor(
+	ctx context.Context,
+	db qrm.DB,
+	authorID int64,
+) ([]int64, error) {
+	tbl := table.Questions
+
+	stmt := pg.SELECT(tbl.ID).
+		FROM(tbl).
+		WHERE(
+			tbl.CreatedBy.EQ(pg.Int64(authorID)).
+				AND(tbl.DeletedAt.IS_NULL()),
+		)
+
+	var rows []model.Questions
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get question ids by author failed", 0)
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids, nil
+}
+
+// GetQuestionsByUUIDs 批量根据 UUID 获取题目，不包含已删除的题目.
+func GetQuestionsByUUIDs(
+	ctx context.Context,
+	db qrm.DB,
+	uuids []uuid.UUID,
+) ([]model.Questions, error) {
+	if len(uuids) == 0 {
+		return []model.Questions{}, nil
+	}
+
+	tbl := table.Questions
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(
+			tbl.QuestionUUID.IN(util.BuildUUIDExpressions(uuids)...).
+				AND(tbl.DeletedAt.IS_NULL()),
+		)
+
+	var questions []model.Questions
+	err := stmt.QueryContext(ctx, db, &questions)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get questions by uuids failed", 0)
+	}
+
+	return questions, nil
+}
+
+// GetQuestionsByIDs 批量根据内部 ID 获取题目（带创建者），包含已删除的题目以便回顾历史作答.
+func GetQuestionsByIDs(
+	ctx context.Context,
+	db qrm.DB,
+	questionIDs []int64,
+) ([]dao.SimpleQuestion, error) {
+	if len(questionIDs) == 0 {
+		return []dao.SimpleQuestion{}, nil
+	}
+
+	tbl := table.Questions
+	userTbl := table.Users
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.LEFT_JOIN(userTbl, tbl.CreatedBy.EQ(userTbl.ID)),
+	).WHERE(
+		tbl.ID.IN(util.BuildInt64Expressions(questionIDs)...),
+	)
+
+	var questions []dao.SimpleQuestion
+	err := stmt.QueryContext(ctx, db, &questions)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get questions by ids failed", 0)
+	}
+
+	return questions, nil
+}
+
 func GetQuestionOptions(
 	ctx context.Context,
 	db qrm.DB,
@@ -257,7 +441,10 @@ func GetQuestionOptions(
 	stmt := pg.SELECT(tbl.AllColumns).
 		FROM(tbl).
 		WHERE(
-			tbl.QuestionID.EQ(pg.Int64(questionID)))
+			tbl.QuestionID.EQ(pg.Int64(questionID)).
+				AND(tbl.RemovedAt.IS_NULL()),
+		).
+		ORDER_BY(tbl.ID)
 
 	var options []model.QuestionOptions
 	err := stmt.QueryContext(ctx, db, &options)
@@ -268,6 +455,61 @@ func GetQuestionOptions(
 	return options, nil
 }
 
+// GetOptionsByQuestionIDs 批量获取多个题目的选项.
+func GetOptionsByQuestionIDs(
+	ctx context.Context,
+	db qrm.DB,
+	questionIDs []int64,
+) ([]model.QuestionOptions, error) {
+	if len(questionIDs) == 0 {
+		return []model.QuestionOptions{}, nil
+	}
+
+	tbl := table.QuestionOptions
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(
+			tbl.QuestionID.IN(util.BuildInt64Expressions(questionIDs)...).
+				AND(tbl.RemovedAt.IS_NULL()),
+		).
+		ORDER_BY(tbl.ID)
+
+	var options []model.QuestionOptions
+	err := stmt.QueryContext(ctx, db, &options)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get options by question ids failed", 0)
+	}
+
+	return options, nil
+}
+
+// GetOptionsByIDs 按 ID 获取选项，包含已移除的选项，用于还原历史作答.
+func GetOptionsByIDs(
+	ctx context.Context,
+	db qrm.DB,
+	optionIDs []int64,
+) ([]model.QuestionOptions, error) {
+	if len(optionIDs) == 0 {
+		return []model.QuestionOptions{}, nil
+	}
+
+	tbl := table.QuestionOptions
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.ID.IN(util.BuildInt64Expressions(optionIDs)...)).
+		ORDER_BY(tbl.ID)
+
+	var options []model.QuestionOptions
+	err := stmt.QueryContext(ctx, db, &options)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get options by ids failed", 0)
+	}
+
+	return options, nil
+}
+
 func GetQuestionCorrectOptionUUIDs(
 	ctx context.Context,
 	db qrm.DB,
@@ -279,7 +521,9 @@ func GetQuestionCorrectOptionUUIDs(
 	stmt := pg.SELECT(tbl.AllColumns).
 		FROM(tbl.LEFT_JOIN(questionTbl, tbl.QuestionID.EQ(questionTbl.ID))).
 		WHERE(
-			tbl.IsAnswer.EQ(pg.Bool(true)).AND(questionTbl.ID.EQ(pg.Int64(questionID))),
+			tbl.IsAnswer.EQ(pg.Bool(true)).
+				AND(tbl.RemovedAt.IS_NULL()).
+				AND(questionTbl.ID.EQ(pg.Int64(questionID))),
 		)
 
 	var options []model.QuestionOptions
@@ -307,7 +551,9 @@ func GetQuestionCorrectOptions(
 	stmt := pg.SELECT(tbl.AllColumns).
 		FROM(tbl.LEFT_JOIN(questionTbl, tbl.QuestionID.EQ(questionTbl.ID))).
 		WHERE(
-			tbl.IsAnswer.EQ(pg.Bool(true)).AND(questionTbl.ID.EQ(pg.Int64(questionID))),
+			tbl.IsAnswer.EQ(pg.Bool(true)).
+				AND(tbl.RemovedAt.IS_NULL()).
+				AND(questionTbl.ID.EQ(pg.Int64(questionID))),
 		)
 
 	var options []model.QuestionOptions
@@ -354,15 +600,18 @@ func GetQuestionSubmissions(
 	submissionsTbl := table.QuestionSubmissions
 	questionsTbl := table.Questions
 	userTbl := table.Users
+	revisionTbl := table.QuestionRevisions
 
 	stmt := pg.SELECT(
 		submissionsTbl.AllColumns,
 		userTbl.UserUUID.AS("user_id"),
 		userTbl.Nickname.AS("user_name"),
+		revisionTbl.Revision.AS("revision"),
 	).FROM(
 		submissionsTbl.
 			INNER_JOIN(questionsTbl, submissionsTbl.QuestionID.EQ(questionsTbl.ID)).
-			INNER_JOIN(userTbl, submissionsTbl.UserID.EQ(userTbl.ID)),
+			INNER_JOIN(userTbl, submissionsTbl.UserID.EQ(userTbl.ID)).
+			LEFT_JOIN(revisionTbl, submissionsTbl.RevisionID.EQ(revisionTbl.ID)),
 	).WHERE(
 		questionsTbl.QuestionUUID.EQ(pg.UUID(questionUUID)),
 	).ORDER_BY(
@@ -373,6 +622,7 @@ func GetQuestionSubmissions(
 		model.QuestionSubmissions
 		UserID   string `db:"user_id"`
 		UserName string `db:"user_name"`
+		Revision *int32 `alias:"revision"`
 	}
 	err := stmt.QueryContext(ctx, db, &results)
 	if err != nil {
@@ -387,6 +637,7 @@ func GetQuestionSubmissions(
 			CreatedAt: submission.CreatedAt,
 			TimeTaken: submission.TimeTaken,
 			UserName:  submission.UserName,
+			Revision:  submission.Revision,
 		}
 		daos = append(daos, dto)
 	}
@@ -471,7 +722,10 @@ func GetOptionIDsByUUIDs(
 
 	stmt := pg.SELECT(tbl.AllColumns).
 		FROM(tbl).
-		WHERE(tbl.OptionUUID.IN(uuidExpressions...)).
+		WHERE(
+			tbl.OptionUUID.IN(uuidExpressions...).
+				AND(tbl.RemovedAt.IS_NULL()),
+		).
 		ORDER_BY(tbl.ID)
 
 	var optionIDs []model.QuestionOptions
@@ -503,6 +757,11 @@ func BuildQuestionsWithTransaction(
 		return nil, err
 	}
 
+	commentsMap, err := GetMultipleQuestionsCommentsCount(ctx, db, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var solvedMap map[int64]bool
 	var likedMap map[int64]int16
 	// 检查用户是否已解答这些题目（如果用户已登录）
@@ -533,10 +792,10 @@ func BuildQuestionsWithTransaction(
 	dtos := make([]oapi.Question, 0, len(result.Questions))
 	for _, q := range result.Questions {
 		id := q.Question.ID
-		dtos = append(
-			dtos,
-			transformer.ConvertSimpleToQuestion(q, trans[id], solvedMap[id], likedMap[id]),
-		)
+		dto := transformer.ConvertSimpleToQuestion(q, trans[id], solvedMap[id], likedMap[id])
+		commentsCount := int(commentsMap[id])
+		dto.CommentsCount = &commentsCount
+		dtos = append(dtos, dto)
 	}
 
 	return dtos, nil
@@ -0,0 +1,232 @@
+package question_repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/util"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// BuildQuestionSnapshot 读取题目当前的文本和选项，生成修订快照.
+// 需要在写入题目的同一事务内调用.
+func BuildQuestionSnapshot(
+	ctx context.Context,
+	db qrm.DB,
+	question model.Questions,
+) (*oapi.CreateQuestionRequest, error) {
+	trans, err := GetQuestionTransByID(ctx, db, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	options, err := GetQuestionOptions(ctx, db, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	optionIDs := make([]int64, 0, len(options))
+	for _, opt := range options {
+		optionIDs = append(optionIDs, opt.ID)
+	}
+	optionTransMap := make(map[int64]oapi.LocalizedText)
+	if len(optionIDs) > 0 {
+		optionTrans, err := GetQuestionOptionTranslations(ctx, db, optionIDs)
+		if err != nil {
+			return nil, err
+		}
+		optionTransMap = util.BuildOptionTranslationMap(optionTrans)
+	}
+
+	questionText := make(oapi.LocalizedText)
+	explanation := make(oapi.LocalizedText)
+	for _, t := range trans {
+		questionText[t.Language] = t.QuestionText
+		if t.Explanation != nil {
+			explanation[t.Language] = *t.Explanation
+		}
+	}
+
+	snapshotOptions := make([]oapi.CreateQuestionOptionRequest, 0, len(options))
+	for _, opt := range options {
+		optionID := opt.OptionUUID
+		text := optionTransMap[opt.ID]
+		if text == nil {
+			text = oapi.LocalizedText{}
+		}
+		snapshotOptions = append(snapshotOptions, oapi.CreateQuestionOptionRequest{
+			Id:         &optionID,
+			OptionType: oapi.OptionType(opt.OptionType),
+			Text:       &text,
+			MediaUrl:   opt.ImgURL,
+			IsAnswer:   opt.IsAnswer,
+		})
+	}
+
+	return &oapi.CreateQuestionRequest{
+		QuestionType: oapi.QuestionType(question.QuestionType),
+		Category:     oapi.Category(question.Category),
+		Difficulty:   oapi.Difficulty(question.Difficulty),
+		QuestionText: questionText,
+		Explanation:  &explanation,
+		Public:       question.Public,
+		Options:      snapshotOptions,
+	}, nil
+}
+
+// InsertQuestionRevision 写入修订快照，快照写入后不再修改.
+func InsertQuestionRevision(
+	ctx context.Context,
+	db qrm.DB,
+	question model.Questions,
+	snapshot oapi.CreateQuestionRequest,
+	createdBy int64,
+	now time.Time,
+) (*model.QuestionRevisions, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "marshal question snapshot failed", 0)
+	}
+
+	tbl := table.QuestionRevisions
+	insertStmt := tbl.INSERT(
+		tbl.QuestionID,
+		tbl.Revision,
+		tbl.Snapshot,
+		tbl.CreatedBy,
+		tbl.CreatedAt,
+	).MODEL(model.QuestionRevisions{
+		QuestionID: question.ID,
+		Revision:   question.CurrentRevision,
+		Snapshot:   string(data),
+		CreatedBy:  createdBy,
+		CreatedAt:  now,
+	}).RETURNING(tbl.AllColumns)
+
+	var revision model.QuestionRevisions
+	err = insertStmt.QueryContext(ctx, db, &revision)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "insert question revision failed", 0)
+	}
+
+	return &revision, nil
+}
+
+// GetQuestionRevisions 获取题目的全部修订版本，最新的在前.
+func GetQuestionRevisions(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+) ([]dao.QuestionRevision, error) {
+	return getQuestionRevisions(
+		ctx,
+		db,
+		table.QuestionRevisions.QuestionID.EQ(pg.Int64(questionID)),
+	)
+}
+
+// GetQuestionRevision 获取题目的指定修订版本.
+func GetQuestionRevision(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+	revision int32,
+) (*dao.QuestionRevision, error) {
+	tbl := table.QuestionRevisions
+	revisions, err := getQuestionRevisions(
+		ctx,
+		db,
+		tbl.QuestionID.EQ(pg.Int64(questionID)).
+			AND(tbl.Revision.EQ(pg.Int32(revision))),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, common.ErrRevisionNotFound
+	}
+
+	return &revisions[0], nil
+}
+
+func getQuestionRevisions(
+	ctx context.Context,
+	db qrm.DB,
+	condition pg.BoolExpression,
+) ([]dao.QuestionRevision, error) {
+	tbl := table.QuestionRevisions
+	userTbl := table.Users
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.INNER_JOIN(userTbl, tbl.CreatedBy.EQ(userTbl.ID)),
+	).WHERE(
+		condition,
+	).ORDER_BY(
+		tbl.Revision.DESC(),
+	)
+
+	var rows []struct {
+		Revision model.QuestionRevisions
+		Author   model.Users
+	}
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get question revisions failed", 0)
+	}
+
+	revisions := make([]dao.QuestionRevision, 0, len(rows))
+	for _, row := range rows {
+		var snapshot oapi.CreateQuestionRequest
+		if err := json.Unmarshal([]byte(row.Revision.Snapshot), &snapshot); err != nil {
+			return nil, errors.WrapPrefix(err, "unmarshal question snapshot failed", 0)
+		}
+		revisions = append(revisions, dao.QuestionRevision{
+			Revision: row.Revision,
+			Author:   row.Author,
+			Snapshot: snapshot,
+		})
+	}
+
+	return revisions, nil
+}
+
+// GetCurrentRevisionID 获取题目当前修订版本的 ID，提交答案时记录.
+func GetCurrentRevisionID(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+) (*int64, error) {
+	tbl := table.QuestionRevisions
+	questionTbl := table.Questions
+
+	stmt := pg.SELECT(tbl.ID).
+		FROM(
+			tbl.INNER_JOIN(
+				questionTbl,
+				questionTbl.ID.EQ(tbl.QuestionID).
+					AND(questionTbl.CurrentRevision.EQ(tbl.Revision)),
+			),
+		).
+		WHERE(questionTbl.ID.EQ(pg.Int64(questionID)))
+
+	var revisions []model.QuestionRevisions
+	err := stmt.QueryContext(ctx, db, &revisions)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get current revision failed", 0)
+	}
+	if len(revisions) == 0 {
+		return nil, common.ErrRevisionNotFound
+	}
+
+	return &revisions[0].ID, nil
+}
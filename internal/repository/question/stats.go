@@ -3,8 +3,10 @@ package question_repo
 import (
 	"context"
 
+	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/db/genshinquiz/public/table"
 
+	"github.com/go-errors/errors"
 	pg "github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
 )
@@ -119,3 +121,29 @@ func RecalculateAllQuestionSubmissionStats(
 
 	return nil
 }
+
+// GetQuestionSubmitterIDs 获取提交过该题目的用户 ID.
+func GetQuestionSubmitterIDs(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+) ([]int64, error) {
+	tbl := table.QuestionSubmissions
+
+	stmt := pg.SELECT(tbl.UserID).
+		DISTINCT().
+		FROM(tbl).
+		WHERE(tbl.QuestionID.EQ(pg.Int64(questionID)))
+
+	var rows []model.QuestionSubmissions
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get question submitter ids failed", 0)
+	}
+
+	userIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		userIDs = append(userIDs, row.UserID)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,147 @@
+package report_repo
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// InsertReport 创建新的举报，同一目标只能有一条未处理的举报.
+func InsertReport(
+	ctx context.Context,
+	db qrm.DB,
+	report model.Reports,
+) (*model.Reports, error) {
+	tbl := table.Reports
+
+	insertStmt := tbl.INSERT(
+		tbl.ReportUUID,
+		tbl.TargetType,
+		tbl.TargetID,
+		tbl.TargetUUID,
+		tbl.TargetUserID,
+		tbl.Status,
+		tbl.ReportCount,
+		tbl.CreatedAt,
+		tbl.UpdatedAt,
+	).MODEL(report).RETURNING(tbl.AllColumns)
+
+	var inserted model.Reports
+	err := insertStmt.QueryContext(ctx, db, &inserted)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "insert report failed", 0)
+	}
+	return &inserted, nil
+}
+
+// InsertReportEntry 记录一次举报，同一用户重复举报时忽略，返回是否新增.
+func InsertReportEntry(
+	ctx context.Context,
+	db qrm.DB,
+	entry model.ReportEntries,
+) (bool, error) {
+	tbl := table.ReportEntries
+
+	insertStmt := tbl.INSERT(
+		tbl.ReportID,
+		tbl.ReporterID,
+		tbl.Reason,
+		tbl.Details,
+		tbl.CreatedAt,
+	).MODEL(entry).
+		ON_CONFLICT(tbl.ReportID, tbl.ReporterID).DO_NOTHING()
+
+	res, err := insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return false, errors.WrapPrefix(err, "insert report entry failed", 0)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WrapPrefix(err, "insert report entry failed", 0)
+	}
+	return affected > 0, nil
+}
+
+// IncrementReportCount 合并重复举报，举报次数加一.
+func IncrementReportCount(
+	ctx context.Context,
+	db qrm.DB,
+	reportID int64,
+	now time.Time,
+) error {
+	tbl := table.Reports
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.ReportCount.SET(tbl.ReportCount.ADD(pg.Int32(1))),
+			tbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(reportID)),
+	)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "increment report count failed", 0)
+	}
+	return nil
+}
+
+// ResolveReport 结束举报，记录处理人和处理时间.
+func ResolveReport(
+	ctx context.Context,
+	db qrm.DB,
+	reportID int64,
+	status model.ReportStatus,
+	resolvedBy int64,
+	now time.Time,
+) (*model.Reports, error) {
+	tbl := table.Reports
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.NewEnumValue(status.String())),
+			tbl.ResolvedBy.SET(pg.Int64(resolvedBy)),
+			tbl.ResolvedAt.SET(pg.TimestampzT(now)),
+			tbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).WHERE(
+		tbl.ID.EQ(pg.Int64(reportID)),
+	).RETURNING(tbl.AllColumns)
+
+	var updated model.Reports
+	err := updateStmt.QueryContext(ctx, db, &updated)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "resolve report failed", 0)
+	}
+	return &updated, nil
+}
+
+// InsertModerationLog 写入审核日志.
+func InsertModerationLog(
+	ctx context.Context,
+	db qrm.DB,
+	log model.ModerationLogs,
+) error {
+	tbl := table.ModerationLogs
+
+	insertStmt := tbl.INSERT(
+		tbl.ModeratorID,
+		tbl.Action,
+		tbl.ReportID,
+		tbl.TargetType,
+		tbl.TargetID,
+		tbl.Note,
+		tbl.CreatedAt,
+	).MODEL(log)
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "insert moderation log failed", 0)
+	}
+	return nil
+}
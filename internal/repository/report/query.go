@@ -0,0 +1,180 @@
+package report_repo
+
+import (
+	"context"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+)
+
+// GetOpenReport 获取目标未处理的举报，不存在时返回 nil，用于合并重复举报.
+func GetOpenReport(
+	ctx context.Context,
+	db qrm.DB,
+	targetType model.ReportTargetType,
+	targetID int64,
+) (*model.Reports, error) {
+	tbl := table.Reports
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(
+			tbl.TargetType.EQ(pg.NewEnumValue(targetType.String())).
+				AND(tbl.TargetID.EQ(pg.Int64(targetID))).
+				AND(tbl.Status.EQ(pg.NewEnumValue(model.ReportStatus_Open.String()))),
+		).
+		FOR(pg.UPDATE())
+
+	var reports []model.Reports
+	err := stmt.QueryContext(ctx, db, &reports)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get open report failed", 0)
+	}
+	if len(reports) == 0 {
+		return nil, nil
+	}
+	return &reports[0], nil
+}
+
+// GetReportByUUID 获取举报详情.
+func GetReportByUUID(
+	ctx context.Context,
+	db qrm.DB,
+	reportUUID uuid.UUID,
+) (*dao.Report, error) {
+	tbl := table.Reports
+	userTbl := table.Users
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.LEFT_JOIN(userTbl, userTbl.ID.EQ(tbl.TargetUserID)),
+	).WHERE(
+		tbl.ReportUUID.EQ(pg.UUID(reportUUID)),
+	)
+
+	var reports []dao.Report
+	err := stmt.QueryContext(ctx, db, &reports)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get report failed", 0)
+	}
+	if len(reports) == 0 {
+		return nil, common.ErrReportNotFound
+	}
+	return &reports[0], nil
+}
+
+// GetReports 获取举报队列（分页），举报次数多的在前，同次数按时间先后.
+func GetReports(
+	ctx context.Context,
+	db qrm.DB,
+	status model.ReportStatus,
+	limit int,
+	offset int,
+) ([]dao.Report, error) {
+	tbl := table.Reports
+	userTbl := table.Users
+
+	stmt := pg.SELECT(
+		tbl.AllColumns,
+		userTbl.AllColumns,
+	).FROM(
+		tbl.LEFT_JOIN(userTbl, userTbl.ID.EQ(tbl.TargetUserID)),
+	).WHERE(
+		tbl.Status.EQ(pg.NewEnumValue(status.String())),
+	).ORDER_BY(
+		tbl.ReportCount.DESC(),
+		tbl.CreatedAt.ASC(),
+		tbl.ID.ASC(),
+	).LIMIT(int64(limit)).OFFSET(int64(offset))
+
+	var reports []dao.Report
+	err := stmt.QueryContext(ctx, db, &reports)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get reports failed", 0)
+	}
+	return reports, nil
+}
+
+// GetReportCount 获取指定状态的举报总数.
+func GetReportCount(
+	ctx context.Context,
+	db qrm.DB,
+	status model.ReportStatus,
+) (int64, error) {
+	tbl := table.Reports
+
+	stmt := pg.SELECT(pg.COUNT(pg.STAR)).
+		FROM(tbl).
+		WHERE(tbl.Status.EQ(pg.NewEnumValue(status.String())))
+
+	var result struct {
+		Count int64 `alias:"count"`
+	}
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "get report count failed", 0)
+	}
+	return result.Count, nil
+}
+
+// GetReportReasonCounts 批量统计举报中各理由的次数（避免N+1查询）.
+func GetReportReasonCounts(
+	ctx context.Context,
+	db qrm.DB,
+	reportIDs []int64,
+) (map[int64][]dao.ReportReasonCount, error) {
+	countMap := make(map[int64][]dao.ReportReasonCount)
+	if len(reportIDs) == 0 {
+		return countMap, nil
+	}
+
+	tbl := table.ReportEntries
+
+	idList := make([]pg.Expression, 0, len(reportIDs))
+	for _, id := range reportIDs {
+		idList = append(idList, pg.Int64(id))
+	}
+
+	stmt := pg.SELECT(
+		tbl.ReportID,
+		tbl.Reason,
+		pg.COUNT(pg.STAR).AS("count"),
+	).FROM(
+		tbl,
+	).WHERE(
+		tbl.ReportID.IN(idList...),
+	).GROUP_BY(
+		tbl.ReportID,
+		tbl.Reason,
+	).ORDER_BY(
+		tbl.ReportID.ASC(),
+		pg.COUNT(pg.STAR).DESC(),
+	)
+
+	var results []struct {
+		ReportID int64              `alias:"report_entries.report_id"`
+		Reason   model.ReportReason `alias:"report_entries.reason"`
+		Count    int64              `alias:"count"`
+	}
+	err := stmt.QueryContext(ctx, db, &results)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get report reason counts failed", 0)
+	}
+
+	for _, result := range results {
+		countMap[result.ReportID] = append(countMap[result.ReportID], dao.ReportReasonCount{
+			Reason: result.Reason,
+			Count:  result.Count,
+		})
+	}
+	return countMap, nil
+}
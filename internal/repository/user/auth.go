@@ -28,11 +28,40 @@ func loginProviderToInt16(provider enum.LoginProvider) (int16, error) {
 		return 2, nil
 	case "github":
 		return 3, nil
+	case "magic_link":
+		return 4, nil
 	default:
 		return 0, fmt.Errorf("%w: %s", common.ErrInvalidLoginProvider, provider)
 	}
 }
 
+// parseLoginProvider 将凭证类型转换为登录方式，未知类型返回错误.
+func parseLoginProvider(credType int16) (enum.LoginProvider, error) {
+	switch credType {
+	case 0:
+		return "password", nil
+	case 1:
+		return "google", nil
+	case 2:
+		return "apple", nil
+	case 3:
+		return "github", nil
+	case 4:
+		return "magic_link", nil
+	default:
+		return "", errors.Errorf("unknown credential type: %d", credType)
+	}
+}
+
+// loginProviderFromInt16 用于展示，未知类型显示为 unknown.
+func loginProviderFromInt16(credType int16) string {
+	provider, err := parseLoginProvider(credType)
+	if err != nil {
+		return "unknown"
+	}
+	return string(provider)
+}
+
 func InsertUserAuth(
 	ctx context.Context,
 	db qrm.DB,
@@ -81,6 +110,7 @@ func InsertLoginLog(
 	userAgent *string,
 	loginType enum.LoginProvider, // "password", "google"
 	status enum.LoginStatus, // "SUCCESS", "FAILED"
+	secondFactor *enum.SecondFactor, // 未经过两步验证时为 nil
 ) (*model.UserLoginLogs, error) {
 	tbl := table.UserLoginLogs
 
@@ -91,6 +121,12 @@ func InsertLoginLog(
 		return nil, errors.WrapPrefix(err, "cred provider wrong", 0)
 	}
 
+	var secondFactorValue *int16
+	if secondFactor != nil {
+		v := int16(*secondFactor)
+		secondFactorValue = &v
+	}
+
 	now := time.Now()
 	insertStmt := tbl.INSERT(
 		tbl.UserID,
@@ -98,6 +134,7 @@ func InsertLoginLog(
 		tbl.UserAgent,
 		tbl.CredentialType,
 		tbl.Status,
+		tbl.SecondFactor,
 		tbl.LoginAt,
 	).
 		MODEL(model.UserLoginLogs{
@@ -106,6 +143,7 @@ func InsertLoginLog(
 			UserAgent:      userAgent,
 			CredentialType: credType,
 			Status:         int16(status),
+			SecondFactor:   secondFactorValue,
 			LoginAt:        now,
 		}).
 		RETURNING(tbl.AllColumns)
@@ -235,15 +273,13 @@ func InsertUserToken(
 	expiresIn time.Duration,
 ) (string, error) {
 	// 1. 生成 32 字节（256 bit）的密码学安全随机字符串作为原始 Token
-	randomBytes := make([]byte, 32)
-	if _, err := rand.Read(randomBytes); err != nil {
-		return "", errors.WrapPrefix(err, "generate random token failed", 0)
+	rawToken, err := generateRawToken()
+	if err != nil {
+		return "", err
 	}
-	rawToken := hex.EncodeToString(randomBytes)
 
 	// 2. 计算明文 Token 的 SHA-256 哈希用于数据库存储
-	hashBytes := sha256.Sum256([]byte(rawToken))
-	tokenHash := hex.EncodeToString(hashBytes[:])
+	tokenHash := hashToken(rawToken)
 
 	// 3. 构建插入数据
 	now := time.Now()
@@ -269,7 +305,7 @@ func InsertUserToken(
 		})
 
 	// 4. 执行插入
-	_, err := insertStmt.ExecContext(ctx, db)
+	_, err = insertStmt.ExecContext(ctx, db)
 	if err != nil {
 		return "", errors.WrapPrefix(err, "insert user token failed", 0)
 	}
@@ -287,8 +323,7 @@ func VerifyAndUseToken(
 	tbl := table.UserTokens
 
 	// 1. 对传入的明文 rawToken 计算 SHA-256
-	hashBytes := sha256.Sum256([]byte(rawToken))
-	tokenHash := hex.EncodeToString(hashBytes[:])
+	tokenHash := hashToken(rawToken)
 
 	// 2. 查询并更新中未过期且未使用的 Token
 	now := time.Now()
@@ -316,3 +351,17 @@ func VerifyAndUseToken(
 
 	return &tokenRecord, nil
 }
+
+// generateRawToken 生成 32 字节的随机令牌，明文只返回给用户，数据库只保存哈希.
+func generateRawToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", errors.WrapPrefix(err, "generate random token failed", 0)
+	}
+	return hex.EncodeToString(randomBytes), nil
+}
+
+func hashToken(rawToken string) string {
+	hashBytes := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(hashBytes[:])
+}
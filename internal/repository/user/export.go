@@ -0,0 +1,463 @@
+package user_repo
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/enum"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+)
+
+func InsertDataExport(
+	ctx context.Context,
+	db qrm.DB,
+	export model.UserDataExports,
+) (*model.UserDataExports, error) {
+	tbl := table.UserDataExports
+
+	insertStmt := tbl.INSERT(
+		tbl.ExportUUID,
+		tbl.UserID,
+		tbl.Format,
+		tbl.CreatedAt,
+	).
+		MODEL(export).
+		RETURNING(tbl.AllColumns)
+
+	var result model.UserDataExports
+	err := insertStmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "insert data export failed", 0)
+	}
+	return &result, nil
+}
+
+// GetDataExportByUUID 只能查询本人的导出记录.
+func GetDataExportByUUID(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	exportUUID uuid.UUID,
+) (*model.UserDataExports, error) {
+	tbl := table.UserDataExports
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(
+			tbl.ExportUUID.EQ(pg.UUID(exportUUID)).
+				AND(tbl.UserID.EQ(pg.Int64(userID))),
+		)
+
+	var result model.UserDataExports
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, common.ErrExportNotFound
+		}
+		return nil, errors.WrapPrefix(err, "get data export failed", 0)
+	}
+	return &result, nil
+}
+
+func GetDataExportByID(
+	ctx context.Context,
+	db qrm.DB,
+	exportID int64,
+) (*model.UserDataExports, error) {
+	tbl := table.UserDataExports
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.ID.EQ(pg.Int64(exportID)))
+
+	var result model.UserDataExports
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, common.ErrExportNotFound
+		}
+		return nil, errors.WrapPrefix(err, "get data export by id failed", 0)
+	}
+	return &result, nil
+}
+
+// HasPendingDataExport 用户是否有尚未生成完成的导出.
+func HasPendingDataExport(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) (bool, error) {
+	tbl := table.UserDataExports
+
+	stmt := pg.SELECT(tbl.ID).
+		FROM(tbl).
+		WHERE(
+			tbl.UserID.EQ(pg.Int64(userID)).
+				AND(tbl.Status.EQ(pg.NewEnumValue(model.DataExportStatus_Pending.String()))),
+		).
+		LIMIT(1)
+
+	var rows []model.UserDataExports
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return false, errors.WrapPrefix(err, "check pending data export failed", 0)
+	}
+	return len(rows) > 0, nil
+}
+
+// GetPendingDataExportIDs 按申请时间先后获取等待生成的导出.
+func GetPendingDataExportIDs(
+	ctx context.Context,
+	db qrm.DB,
+	limit int64,
+) ([]int64, error) {
+	tbl := table.UserDataExports
+
+	stmt := pg.SELECT(tbl.ID).
+		FROM(tbl).
+		WHERE(tbl.Status.EQ(pg.NewEnumValue(model.DataExportStatus_Pending.String()))).
+		ORDER_BY(tbl.CreatedAt.ASC()).
+		LIMIT(limit)
+
+	var rows []model.UserDataExports
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get pending data exports failed", 0)
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids, nil
+}
+
+// CompleteDataExport 保存导出文件，仅更新仍在等待中的记录.
+func CompleteDataExport(
+	ctx context.Context,
+	db qrm.DB,
+	exportID int64,
+	data []byte,
+	now time.Time,
+	expiresAt time.Time,
+) error {
+	tbl := table.UserDataExports
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.NewEnumValue(model.DataExportStatus_Completed.String())),
+			tbl.Data.SET(pg.Bytea(data)),
+			tbl.CompletedAt.SET(pg.TimestampzT(now)),
+			tbl.ExpiresAt.SET(pg.TimestampzT(expiresAt)),
+		).
+		WHERE(
+			tbl.ID.EQ(pg.Int64(exportID)).
+				AND(tbl.Status.EQ(pg.NewEnumValue(model.DataExportStatus_Pending.String()))),
+		)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "complete data export failed", 0)
+	}
+	return nil
+}
+
+func FailDataExport(
+	ctx context.Context,
+	db qrm.DB,
+	exportID int64,
+	reason string,
+	now time.Time,
+) error {
+	tbl := table.UserDataExports
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.NewEnumValue(model.DataExportStatus_Failed.String())),
+			tbl.Error.SET(pg.String(reason)),
+			tbl.CompletedAt.SET(pg.TimestampzT(now)),
+		).
+		WHERE(
+			tbl.ID.EQ(pg.Int64(exportID)).
+				AND(tbl.Status.EQ(pg.NewEnumValue(model.DataExportStatus_Pending.String()))),
+		)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "fail data export failed", 0)
+	}
+	return nil
+}
+
+// DeleteExpiredDataExports 删除已过期的导出文件，返回删除的数量.
+func DeleteExpiredDataExports(
+	ctx context.Context,
+	db qrm.DB,
+	now time.Time,
+) (int64, error) {
+	tbl := table.UserDataExports
+
+	deleteStmt := tbl.DELETE().
+		WHERE(tbl.ExpiresAt.LT_EQ(pg.TimestampzT(now)))
+
+	res, err := deleteStmt.ExecContext(ctx, db)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "delete expired data exports failed", 0)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "delete expired data exports failed", 0)
+	}
+	return affected, nil
+}
+
+func GetExportSubmissions(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) ([]dao.ExportSubmission, error) {
+	subTbl := table.QuestionSubmissions
+	qTbl := table.Questions
+
+	stmt := pg.SELECT(
+		qTbl.QuestionUUID,
+		subTbl.IsCorrect,
+		subTbl.IsPractice,
+		subTbl.TimeTaken,
+		subTbl.CreatedAt,
+	).
+		FROM(subTbl.INNER_JOIN(qTbl, qTbl.ID.EQ(subTbl.QuestionID))).
+		WHERE(subTbl.UserID.EQ(pg.Int64(userID))).
+		ORDER_BY(subTbl.CreatedAt.ASC())
+
+	rows := make([]dao.ExportSubmission, 0)
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get export submissions failed", 0)
+	}
+	return rows, nil
+}
+
+func GetExportVotes(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) ([]dao.ExportVote, error) {
+	voteTbl := table.UserVotes
+	pollTbl := table.Polls
+	optTbl := table.PollOptions
+
+	stmt := pg.SELECT(
+		pollTbl.PollUUID,
+		optTbl.OptionUUID,
+		voteTbl.VoteCount,
+		voteTbl.CreatedAt,
+	).
+		FROM(
+			voteTbl.
+				INNER_JOIN(pollTbl, pollTbl.ID.EQ(voteTbl.PollID)).
+				INNER_JOIN(optTbl, optTbl.ID.EQ(voteTbl.OptionID)),
+		).
+		WHERE(voteTbl.UserID.EQ(pg.Int64(userID))).
+		ORDER_BY(voteTbl.CreatedAt.ASC())
+
+	rows := make([]dao.ExportVote, 0)
+	err := stmt.QueryContext(ctx, db, &rows)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get export votes failed", 0)
+	}
+	return rows, nil
+}
+
+// GetExportLikes 汇总题目、投票和测验的点赞记录.
+func GetExportLikes(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) ([]dao.ExportLike, error) {
+	likes := make([]dao.ExportLike, 0)
+
+	qLikeTbl := table.QuestionLikes
+	qTbl := table.Questions
+	var questionLikes []struct {
+		UUID      uuid.UUID `alias:"questions.question_uuid"`
+		Value     int16     `alias:"question_likes.value"`
+		CreatedAt time.Time `alias:"question_likes.created_at"`
+	}
+	err := pg.SELECT(qTbl.QuestionUUID, qLikeTbl.Value, qLikeTbl.CreatedAt).
+		FROM(qLikeTbl.INNER_JOIN(qTbl, qTbl.ID.EQ(qLikeTbl.QuestionID))).
+		WHERE(qLikeTbl.UserID.EQ(pg.Int64(userID))).
+		QueryContext(ctx, db, &questionLikes)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get export question likes failed", 0)
+	}
+	for _, l := range questionLikes {
+		likes = append(likes, dao.ExportLike{
+			TargetType: "question",
+			TargetUUID: l.UUID,
+			Value:      l.Value,
+			CreatedAt:  l.CreatedAt,
+		})
+	}
+
+	pLikeTbl := table.PollLikes
+	pTbl := table.Polls
+	var pollLikes []struct {
+		UUID      uuid.UUID `alias:"polls.poll_uuid"`
+		Value     int16     `alias:"poll_likes.value"`
+		CreatedAt time.Time `alias:"poll_likes.created_at"`
+	}
+	err = pg.SELECT(pTbl.PollUUID, pLikeTbl.Value, pLikeTbl.CreatedAt).
+		FROM(pLikeTbl.INNER_JOIN(pTbl, pTbl.ID.EQ(pLikeTbl.PollID))).
+		WHERE(pLikeTbl.UserID.EQ(pg.Int64(userID))).
+		QueryContext(ctx, db, &pollLikes)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get export poll likes failed", 0)
+	}
+	for _, l := range pollLikes {
+		likes = append(likes, dao.ExportLike{
+			TargetType: "poll",
+			TargetUUID: l.UUID,
+			Value:      l.Value,
+			CreatedAt:  l.CreatedAt,
+		})
+	}
+
+	eLikeTbl := table.QuizLikes
+	eTbl := table.Quizzes
+	var examLikes []struct {
+		UUID      uuid.UUID `alias:"quizzes.quiz_uuid"`
+		Value     int16     `alias:"quiz_likes.value"`
+		CreatedAt time.Time `alias:"quiz_likes.created_at"`
+	}
+	err = pg.SELECT(eTbl.QuizUUID, eLikeTbl.Value, eLikeTbl.CreatedAt).
+		FROM(eLikeTbl.INNER_JOIN(eTbl, eTbl.ID.EQ(eLikeTbl.QuizID))).
+		WHERE(eLikeTbl.UserID.EQ(pg.Int64(userID))).
+		QueryContext(ctx, db, &examLikes)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get export exam likes failed", 0)
+	}
+	for _, l := range examLikes {
+		likes = append(likes, dao.ExportLike{
+			TargetType: "exam",
+			TargetUUID: l.UUID,
+			Value:      l.Value,
+			CreatedAt:  l.CreatedAt,
+		})
+	}
+
+	return likes, nil
+}
+
+// GetExportComments 汇总题目评论和投票评论.
+func GetExportComments(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) ([]dao.ExportComment, error) {
+	comments := make([]dao.ExportComment, 0)
+
+	qcTbl := table.QuestionComments
+	qTbl := table.Questions
+	var questionComments []struct {
+		TargetUUID uuid.UUID `alias:"questions.question_uuid"`
+		model.QuestionComments
+	}
+	err := pg.SELECT(qTbl.QuestionUUID, qcTbl.AllColumns).
+		FROM(qcTbl.INNER_JOIN(qTbl, qTbl.ID.EQ(qcTbl.QuestionID))).
+		WHERE(qcTbl.UserID.EQ(pg.Int64(userID))).
+		ORDER_BY(qcTbl.CreatedAt.ASC()).
+		QueryContext(ctx, db, &questionComments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get export question comments failed", 0)
+	}
+	for _, c := range questionComments {
+		comments = append(comments, dao.ExportComment{
+			TargetType:  "question",
+			TargetUUID:  c.TargetUUID,
+			CommentUUID: c.CommentUUID,
+			Content:     c.Comment,
+			CreatedAt:   c.CreatedAt,
+			DeletedAt:   c.DeletedAt,
+		})
+	}
+
+	pcTbl := table.PollComments
+	pTbl := table.Polls
+	var pollComments []struct {
+		TargetUUID uuid.UUID `alias:"polls.poll_uuid"`
+		model.PollComments
+	}
+	err = pg.SELECT(pTbl.PollUUID, pcTbl.AllColumns).
+		FROM(pcTbl.INNER_JOIN(pTbl, pTbl.ID.EQ(pcTbl.PollID))).
+		WHERE(pcTbl.UserID.EQ(pg.Int64(userID))).
+		ORDER_BY(pcTbl.CreatedAt.ASC()).
+		QueryContext(ctx, db, &pollComments)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get export poll comments failed", 0)
+	}
+	for _, c := range pollComments {
+		comments = append(comments, dao.ExportComment{
+			TargetType:  "poll",
+			TargetUUID:  c.TargetUUID,
+			CommentUUID: c.CommentUUID,
+			Content:     c.Content,
+			CreatedAt:   c.CreatedAt,
+			DeletedAt:   c.DeletedAt,
+		})
+	}
+
+	return comments, nil
+}
+
+func GetExportLoginHistory(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) ([]dao.ExportLoginRecord, error) {
+	tbl := table.UserLoginLogs
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.UserID.EQ(pg.Int64(userID))).
+		ORDER_BY(tbl.LoginAt.ASC())
+
+	var logs []model.UserLoginLogs
+	err := stmt.QueryContext(ctx, db, &logs)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get export login history failed", 0)
+	}
+
+	records := make([]dao.ExportLoginRecord, 0, len(logs))
+	for _, l := range logs {
+		records = append(records, dao.ExportLoginRecord{
+			IPAddress: l.IPAddress,
+			UserAgent: l.UserAgent,
+			Provider:  loginProviderFromInt16(l.CredentialType),
+			Status:    loginStatusName(enum.LoginStatus(l.Status)),
+			LoginAt:   l.LoginAt,
+		})
+	}
+	return records, nil
+}
+
+func loginStatusName(status enum.LoginStatus) string {
+	switch status {
+	case enum.LoginStatusSuccess:
+		return "success"
+	case enum.LoginStatusFailed:
+		return "failed"
+	case enum.LoginStatusBlocked:
+		return "blocked"
+	default:
+		return "unknown"
+	}
+}
@@ -42,10 +42,12 @@ func GetUsersLeaderboard(
 	if params.SortDesc {
 		orderClause = orderCol.DESC()
 	}
+	// 被封禁或已注销的用户不上榜；占位账号为正常状态以便保留的内容继续展示，需要单独排除
+	condition := users.Status.EQ(postgres.Int16(int16(enum.UserStatusActive))).
+		AND(users.UserUUID.NOT_EQ(postgres.UUID(DeletedUserUUID)))
 	// accuracy 排序时，只统计有过答题记录的用户，避免全是 0/0 的用户挤占榜单
-	condition := postgres.Bool(true)
 	if params.SortBy == enum.SortByAccuracy || params.SortBy == "" {
-		condition = stats.TotalSubmissions.GT(postgres.Int(0))
+		condition = condition.AND(stats.TotalSubmissions.GT(postgres.Int(0)))
 	}
 
 	stmt := postgres.SELECT(
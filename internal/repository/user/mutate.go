@@ -11,6 +11,7 @@ import (
 
 	"genshin-quiz/internal/common"
 	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/enum"
 
 	"github.com/go-errors/errors"
 	pg "github.com/go-jet/jet/v2/postgres"
@@ -268,6 +269,10 @@ func UpdateUserPrivacies(
 		columns = append(columns, tbl.CountryVisibility)
 		m.CountryVisibility = *params.CountryVisibility
 	}
+	if params.LeaderboardVisibility != nil {
+		columns = append(columns, tbl.LeaderboardVisibility)
+		m.LeaderboardVisibility = *params.LeaderboardVisibility
+	}
 
 	updateStmt := tbl.UPDATE(columns).
 		MODEL(m).
@@ -286,12 +291,235 @@ func UpdateUserPrivacies(
 	return &result, nil
 }
 
+// UpdateUserStatus 更新账号状态（正常、封禁、注销）.
+// SuspendUser 封禁用户，until 为空表示永久封禁.
+func SuspendUser(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	until *time.Time,
+	reason string,
+) error {
+	tbl := table.Users
+
+	untilExp := pg.TimestampzExp(pg.NULL)
+	if until != nil {
+		untilExp = pg.TimestampzT(*until)
+	}
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.Int16(int16(enum.UserStatusSuspended))),
+			tbl.SuspendedUntil.SET(untilExp),
+			tbl.SuspensionReason.SET(pg.String(reason)),
+			tbl.UpdatedAt.SET(pg.CURRENT_TIMESTAMP()),
+		).
+		WHERE(tbl.ID.EQ(pg.Int64(userID))).
+		RETURNING(tbl.ID)
+
+	var result struct {
+		ID int64 `alias:"id"`
+	}
+	err := updateStmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return common.ErrUserNotFound
+		}
+		return errors.WrapPrefix(err, "suspend user failed", 0)
+	}
+	return nil
+}
+
+// LiftUserSuspension 解除封禁，已注销的账号不受影响.
+func LiftUserSuspension(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) error {
+	tbl := table.Users
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.Int16(int16(enum.UserStatusActive))),
+			tbl.SuspendedUntil.SET(pg.TimestampzExp(pg.NULL)),
+			tbl.SuspensionReason.SET(pg.StringExp(pg.NULL)),
+			tbl.UpdatedAt.SET(pg.CURRENT_TIMESTAMP()),
+		).
+		WHERE(
+			tbl.ID.EQ(pg.Int64(userID)).
+				AND(tbl.Status.EQ(pg.Int16(int16(enum.UserStatusSuspended)))),
+		)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "lift user suspension failed", 0)
+	}
+	return nil
+}
+
+// LiftExpiredSuspensions 解除所有已到期的封禁，返回解除的数量.
+func LiftExpiredSuspensions(
+	ctx context.Context,
+	db qrm.DB,
+	now time.Time,
+) (int64, error) {
+	tbl := table.Users
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.Status.SET(pg.Int16(int16(enum.UserStatusActive))),
+			tbl.SuspendedUntil.SET(pg.TimestampzExp(pg.NULL)),
+			tbl.SuspensionReason.SET(pg.StringExp(pg.NULL)),
+			tbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).
+		WHERE(
+			tbl.Status.EQ(pg.Int16(int16(enum.UserStatusSuspended))).
+				AND(tbl.SuspendedUntil.LT_EQ(pg.TimestampzT(now))),
+		)
+
+	res, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "lift expired suspensions failed", 0)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "lift expired suspensions failed", 0)
+	}
+	return affected, nil
+}
+
+// ScheduleUserDeletion 记录注销申请，scheduledAt 之后由定时任务匿名化.
+func ScheduleUserDeletion(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	requestedAt time.Time,
+	scheduledAt time.Time,
+) error {
+	tbl := table.Users
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.DeletionRequestedAt.SET(pg.TimestampzT(requestedAt)),
+			tbl.DeletionScheduledAt.SET(pg.TimestampzT(scheduledAt)),
+			tbl.UpdatedAt.SET(pg.TimestampzT(requestedAt)),
+		).
+		WHERE(tbl.ID.EQ(pg.Int64(userID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "schedule user deletion failed", 0)
+	}
+	return nil
+}
+
+// CancelUserDeletion 撤销注销申请.
+func CancelUserDeletion(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) error {
+	tbl := table.Users
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.DeletionRequestedAt.SET(pg.TimestampzExp(pg.NULL)),
+			tbl.DeletionScheduledAt.SET(pg.TimestampzExp(pg.NULL)),
+			tbl.UpdatedAt.SET(pg.CURRENT_TIMESTAMP()),
+		).
+		WHERE(tbl.ID.EQ(pg.Int64(userID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "cancel user deletion failed", 0)
+	}
+	return nil
+}
+
+// DeleteUser 匿名化账号：清除个人资料和登录凭证，账号记录保留以维持提交、投票等关联.
+// 邮箱改写为不可登录的占位地址，释放原邮箱供重新注册.
 func DeleteUser(
 	ctx context.Context,
 	db qrm.DB,
-	uuid uuid.UUID,
+	user model.Users,
+	now time.Time,
 ) error {
-	// start := time.Now()
+	usersTbl := table.Users
+	_, err := usersTbl.UPDATE().
+		SET(
+			usersTbl.Email.SET(pg.String("deleted-"+user.UserUUID.String()+"@deleted.invalid")),
+			usersTbl.Nickname.SET(pg.String(DeletedUserNickname)),
+			usersTbl.AvatarURL.SET(pg.StringExp(pg.NULL)),
+			usersTbl.Biography.SET(pg.StringExp(pg.NULL)),
+			usersTbl.CreatedIP.SET(pg.StringExp(pg.NULL)),
+			usersTbl.UserRole.SET(pg.Int16(int16(enum.UserRoleUser))),
+			usersTbl.EmailVerified.SET(pg.Bool(false)),
+			usersTbl.Status.SET(pg.Int16(int16(enum.UserStatusDeleted))),
+			usersTbl.DeletedAt.SET(pg.TimestampzT(now)),
+			usersTbl.SuspendedUntil.SET(pg.TimestampzExp(pg.NULL)),
+			usersTbl.SuspensionReason.SET(pg.StringExp(pg.NULL)),
+			usersTbl.DeletionScheduledAt.SET(pg.TimestampzExp(pg.NULL)),
+			usersTbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).
+		WHERE(usersTbl.ID.EQ(pg.Int64(user.ID))).
+		ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "anonymize user failed", 0)
+	}
+
+	profileTbl := table.UserProfiles
+	_, err = profileTbl.UPDATE().
+		SET(
+			profileTbl.Gender.SET(pg.Int16(0)),
+			profileTbl.Country.SET(pg.StringExp(pg.NULL)),
+			profileTbl.Timezone.SET(pg.StringExp(pg.NULL)),
+			profileTbl.Birthday.SET(pg.DateExp(pg.NULL)),
+			profileTbl.Website.SET(pg.StringExp(pg.NULL)),
+			profileTbl.Twitter.SET(pg.StringExp(pg.NULL)),
+			profileTbl.Discord.SET(pg.StringExp(pg.NULL)),
+			profileTbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).
+		WHERE(profileTbl.UserID.EQ(pg.Int64(user.ID))).
+		ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "anonymize user profile failed", 0)
+	}
+
+	// 隐私设置全部改为不公开
+	privacyTbl := table.UserPrivacies
+	_, err = privacyTbl.UPDATE().
+		SET(
+			privacyTbl.EmailVisibility.SET(pg.Int16(0)),
+			privacyTbl.BirthdayVisibility.SET(pg.Int16(0)),
+			privacyTbl.GenderVisibility.SET(pg.Int16(0)),
+			privacyTbl.CountryVisibility.SET(pg.Int16(0)),
+			privacyTbl.LeaderboardVisibility.SET(pg.Int16(0)),
+			privacyTbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).
+		WHERE(privacyTbl.UserID.EQ(pg.Int64(user.ID))).
+		ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "anonymize user privacies failed", 0)
+	}
+
+	// 登录凭证、两步验证、绑定的游戏账号、令牌、会话、登录记录和导出文件直接删除
+	userID := pg.Int64(user.ID)
+	deletes := []pg.DeleteStatement{
+		table.UserCredentials.DELETE().WHERE(table.UserCredentials.UserID.EQ(userID)),
+		table.UserTwoFactor.DELETE().WHERE(table.UserTwoFactor.UserID.EQ(userID)),
+		table.UserRecoveryCodes.DELETE().WHERE(table.UserRecoveryCodes.UserID.EQ(userID)),
+		table.UserGameAccounts.DELETE().WHERE(table.UserGameAccounts.UserID.EQ(userID)),
+		table.UserTokens.DELETE().WHERE(table.UserTokens.UserID.EQ(userID)),
+		table.UserSessions.DELETE().WHERE(table.UserSessions.UserID.EQ(userID)),
+		table.UserLoginLogs.DELETE().WHERE(table.UserLoginLogs.UserID.EQ(userID)),
+		table.UserDataExports.DELETE().WHERE(table.UserDataExports.UserID.EQ(userID)),
+	}
+	for _, stmt := range deletes {
+		_, err = stmt.ExecContext(ctx, db)
+		if err != nil {
+			return errors.WrapPrefix(err, "delete user data failed", 0)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,223 @@
+package user_repo
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/common"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// InsertOAuthState 保存授权请求，state 只存哈希.
+func InsertOAuthState(
+	ctx context.Context,
+	db qrm.DB,
+	rawState string,
+	state model.OAuthStates,
+) error {
+	tbl := table.OAuthStates
+	state.StateHash = hashToken(rawState)
+
+	insertStmt := tbl.INSERT(
+		tbl.StateHash,
+		tbl.Provider,
+		tbl.CodeVerifier,
+		tbl.Nonce,
+		tbl.LinkUserID,
+		tbl.CreatedAt,
+		tbl.ExpiresAt,
+	).MODEL(state)
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "insert oauth state failed", 0)
+	}
+	return nil
+}
+
+// ConsumeOAuthState 取出并删除授权请求，state 只能使用一次.
+func ConsumeOAuthState(
+	ctx context.Context,
+	db qrm.DB,
+	rawState string,
+	provider string,
+	now time.Time,
+) (*model.OAuthStates, error) {
+	tbl := table.OAuthStates
+
+	deleteStmt := tbl.DELETE().
+		WHERE(
+			tbl.StateHash.EQ(pg.String(hashToken(rawState))).
+				AND(tbl.Provider.EQ(pg.String(provider))).
+				AND(tbl.ExpiresAt.GT(pg.TimestampzT(now))),
+		).
+		RETURNING(tbl.AllColumns)
+
+	var result model.OAuthStates
+	err := deleteStmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, common.ErrInvalidToken
+		}
+		return nil, errors.WrapPrefix(err, "consume oauth state failed", 0)
+	}
+	return &result, nil
+}
+
+// DeleteExpiredOAuthStates 删除过期的授权请求，返回删除的数量.
+func DeleteExpiredOAuthStates(
+	ctx context.Context,
+	db qrm.DB,
+	now time.Time,
+) (int64, error) {
+	tbl := table.OAuthStates
+
+	deleteStmt := tbl.DELETE().
+		WHERE(tbl.ExpiresAt.LT(pg.TimestampzT(now)))
+
+	res, err := deleteStmt.ExecContext(ctx, db)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "delete expired oauth states failed", 0)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "delete expired oauth states failed", 0)
+	}
+	return affected, nil
+}
+
+// GetCredentialByIdentifier 按第三方账号查询凭证，不存在时返回 nil.
+func GetCredentialByIdentifier(
+	ctx context.Context,
+	db qrm.DB,
+	identityType string,
+	identifier string,
+) (*model.UserCredentials, error) {
+	tbl := table.UserCredentials
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(
+			tbl.IdentityType.EQ(pg.String(identityType)).
+				AND(tbl.Identifier.EQ(pg.String(identifier))),
+		)
+
+	var cred model.UserCredentials
+	err := stmt.QueryContext(ctx, db, &cred)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.WrapPrefix(err, "get credential by identifier failed", 0)
+	}
+	return &cred, nil
+}
+
+// InsertOAuthCredential 绑定第三方账号，extraData 为第三方返回的原始资料.
+func InsertOAuthCredential(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	identityType string,
+	identifier string,
+	extraData string,
+) error {
+	tbl := table.UserCredentials
+	now := time.Now()
+
+	insertStmt := tbl.INSERT(
+		tbl.UserID,
+		tbl.IdentityType,
+		tbl.Identifier,
+		tbl.ExtraData,
+		tbl.CreatedAt,
+		tbl.UpdatedAt,
+	).MODEL(model.UserCredentials{
+		UserID:       userID,
+		IdentityType: identityType,
+		Identifier:   identifier,
+		ExtraData:    &extraData,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		if contains(err.Error(), "duplicate key") || contains(err.Error(), "unique constraint") {
+			return common.NewBadRequestError("该第三方账号已绑定其他用户")
+		}
+		return errors.WrapPrefix(err, "insert oauth credential failed", 0)
+	}
+	return nil
+}
+
+// UpdateCredentialExtraData 每次第三方登录时刷新保存的资料.
+func UpdateCredentialExtraData(
+	ctx context.Context,
+	db qrm.DB,
+	credentialID int64,
+	extraData string,
+) error {
+	tbl := table.UserCredentials
+
+	stmt := tbl.UPDATE(tbl.ExtraData, tbl.UpdatedAt).
+		SET(
+			pg.Json(extraData),
+			time.Now(),
+		).
+		WHERE(tbl.ID.EQ(pg.Int64(credentialID)))
+
+	_, err := stmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "update credential extra data failed", 0)
+	}
+	return nil
+}
+
+// GetUserCredentials 获取用户的全部登录方式，按绑定时间排序.
+func GetUserCredentials(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) ([]model.UserCredentials, error) {
+	tbl := table.UserCredentials
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.UserID.EQ(pg.Int64(userID))).
+		ORDER_BY(tbl.CreatedAt.ASC())
+
+	var result []model.UserCredentials
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get user credentials failed", 0)
+	}
+	return result, nil
+}
+
+// DeleteUserCredential 解绑登录方式.
+func DeleteUserCredential(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	identityType string,
+) error {
+	tbl := table.UserCredentials
+
+	deleteStmt := tbl.DELETE().
+		WHERE(
+			tbl.UserID.EQ(pg.Int64(userID)).
+				AND(tbl.IdentityType.EQ(pg.String(identityType))),
+		)
+
+	_, err := deleteStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "delete user credential failed", 0)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package user_repo
 
 import (
 	"context"
+	"time"
 
 	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/db/genshinquiz/public/table"
@@ -14,6 +15,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// DeletedUserUUID 占位账号，注销用户保留的内容归到该账号名下.
+var DeletedUserUUID = uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+// DeletedUserNickname 已注销账号和占位账号显示的昵称.
+const DeletedUserNickname = "已注销用户"
+
 func GetUserByEmail(
 	ctx context.Context,
 	db qrm.DB,
@@ -180,3 +187,29 @@ func CheckUserExists(
 
 	return true, nil
 }
+
+// GetUsersDueForDeletion 获取冷静期已结束、等待匿名化的账号.
+func GetUsersDueForDeletion(
+	ctx context.Context,
+	db qrm.DB,
+	now time.Time,
+	limit int64,
+) ([]model.Users, error) {
+	tbl := table.Users
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(
+			tbl.DeletionScheduledAt.LT_EQ(pg.TimestampzT(now)).
+				AND(tbl.DeletedAt.IS_NULL()),
+		).
+		ORDER_BY(tbl.DeletionScheduledAt.ASC()).
+		LIMIT(limit)
+
+	var users []model.Users
+	err := stmt.QueryContext(ctx, db, &users)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get users due for deletion failed", 0)
+	}
+	return users, nil
+}
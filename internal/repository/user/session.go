@@ -0,0 +1,322 @@
+package user_repo
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/enum"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+)
+
+func InsertSession(
+	ctx context.Context,
+	db qrm.DB,
+	session model.UserSessions,
+) (*model.UserSessions, error) {
+	tbl := table.UserSessions
+
+	insertStmt := tbl.INSERT(
+		tbl.SessionUUID,
+		tbl.UserID,
+		tbl.LoginLogID,
+		tbl.CreatedAt,
+		tbl.LastUsedAt,
+		tbl.ExpiresAt,
+		tbl.TwoFactorVerified,
+	).
+		MODEL(session).
+		RETURNING(tbl.AllColumns)
+
+	var result model.UserSessions
+	err := insertStmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "insert session failed", 0)
+	}
+	return &result, nil
+}
+
+// InsertRefreshToken 为会话签发新的刷新令牌，返回明文令牌.
+func InsertRefreshToken(
+	ctx context.Context,
+	db qrm.DB,
+	sessionID int64,
+	now time.Time,
+) (string, error) {
+	rawToken, err := generateRawToken()
+	if err != nil {
+		return "", err
+	}
+
+	tbl := table.UserRefreshTokens
+	insertStmt := tbl.INSERT(
+		tbl.SessionID,
+		tbl.TokenHash,
+		tbl.CreatedAt,
+	).MODEL(model.UserRefreshTokens{
+		SessionID: sessionID,
+		TokenHash: hashToken(rawToken),
+		CreatedAt: now,
+	})
+
+	_, err = insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return "", errors.WrapPrefix(err, "insert refresh token failed", 0)
+	}
+	return rawToken, nil
+}
+
+// GetRefreshTokenForUpdate 按明文令牌查询刷新令牌和会话，并锁定令牌行防止并发刷新.
+func GetRefreshTokenForUpdate(
+	ctx context.Context,
+	db qrm.DB,
+	rawToken string,
+) (*dao.RefreshTokenSession, error) {
+	tokenTbl := table.UserRefreshTokens
+	sessionTbl := table.UserSessions
+
+	stmt := pg.SELECT(tokenTbl.AllColumns, sessionTbl.AllColumns).
+		FROM(tokenTbl.INNER_JOIN(sessionTbl, sessionTbl.ID.EQ(tokenTbl.SessionID))).
+		WHERE(tokenTbl.TokenHash.EQ(pg.String(hashToken(rawToken)))).
+		FOR(pg.UPDATE())
+
+	var result dao.RefreshTokenSession
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, common.ErrInvalidToken
+		}
+		return nil, errors.WrapPrefix(err, "get refresh token failed", 0)
+	}
+	return &result, nil
+}
+
+// MarkRefreshTokenUsed 标记刷新令牌已轮换.
+func MarkRefreshTokenUsed(
+	ctx context.Context,
+	db qrm.DB,
+	tokenID int64,
+	now time.Time,
+) error {
+	tbl := table.UserRefreshTokens
+
+	updateStmt := tbl.UPDATE().
+		SET(tbl.UsedAt.SET(pg.TimestampzT(now))).
+		WHERE(tbl.ID.EQ(pg.Int64(tokenID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "mark refresh token used failed", 0)
+	}
+	return nil
+}
+
+// TouchSession 刷新时更新最近使用时间并顺延会话有效期.
+func TouchSession(
+	ctx context.Context,
+	db qrm.DB,
+	sessionID int64,
+	now time.Time,
+	expiresAt time.Time,
+) error {
+	tbl := table.UserSessions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.LastUsedAt.SET(pg.TimestampzT(now)),
+			tbl.ExpiresAt.SET(pg.TimestampzT(expiresAt)),
+		).
+		WHERE(tbl.ID.EQ(pg.Int64(sessionID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "touch session failed", 0)
+	}
+	return nil
+}
+
+// GetActiveSession 获取未注销且未过期的会话.
+func GetActiveSession(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	sessionUUID uuid.UUID,
+	now time.Time,
+) (*model.UserSessions, error) {
+	tbl := table.UserSessions
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(
+			tbl.SessionUUID.EQ(pg.UUID(sessionUUID)).
+				AND(tbl.UserID.EQ(pg.Int64(userID))).
+				AND(tbl.RevokedAt.IS_NULL()).
+				AND(tbl.ExpiresAt.GT(pg.TimestampzT(now))),
+		)
+
+	var result model.UserSessions
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, common.ErrSessionNotFound
+		}
+		return nil, errors.WrapPrefix(err, "get active session failed", 0)
+	}
+	return &result, nil
+}
+
+// MarkSessionTwoFactorVerified 会话内完成两步验证后标记.
+func MarkSessionTwoFactorVerified(
+	ctx context.Context,
+	db qrm.DB,
+	sessionID int64,
+) error {
+	tbl := table.UserSessions
+
+	stmt := tbl.UPDATE().
+		SET(tbl.TwoFactorVerified.SET(pg.Bool(true))).
+		WHERE(tbl.ID.EQ(pg.Int64(sessionID)))
+
+	_, err := stmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "mark session two factor verified failed", 0)
+	}
+	return nil
+}
+
+// GetActiveSessions 获取用户的有效会话，按最近使用时间倒序.
+func GetActiveSessions(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	now time.Time,
+) ([]dao.UserSession, error) {
+	sessionTbl := table.UserSessions
+	logTbl := table.UserLoginLogs
+
+	stmt := pg.SELECT(sessionTbl.AllColumns, logTbl.AllColumns).
+		FROM(sessionTbl.LEFT_JOIN(logTbl, logTbl.ID.EQ(sessionTbl.LoginLogID))).
+		WHERE(
+			sessionTbl.UserID.EQ(pg.Int64(userID)).
+				AND(sessionTbl.RevokedAt.IS_NULL()).
+				AND(sessionTbl.ExpiresAt.GT(pg.TimestampzT(now))),
+		).
+		ORDER_BY(sessionTbl.LastUsedAt.DESC())
+
+	sessions := make([]dao.UserSession, 0)
+	err := stmt.QueryContext(ctx, db, &sessions)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get active sessions failed", 0)
+	}
+	return sessions, nil
+}
+
+// GetSessionLoginProvider 获取会话登录时使用的方式.
+func GetSessionLoginProvider(
+	ctx context.Context,
+	db qrm.DB,
+	sessionUUID uuid.UUID,
+) (enum.LoginProvider, error) {
+	sessionTbl := table.UserSessions
+	logTbl := table.UserLoginLogs
+
+	stmt := pg.SELECT(logTbl.CredentialType).
+		FROM(sessionTbl.INNER_JOIN(logTbl, logTbl.ID.EQ(sessionTbl.LoginLogID))).
+		WHERE(sessionTbl.SessionUUID.EQ(pg.UUID(sessionUUID)))
+
+	var result model.UserLoginLogs
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return "", common.ErrSessionNotFound
+		}
+		return "", errors.WrapPrefix(err, "get session login provider failed", 0)
+	}
+	return parseLoginProvider(result.CredentialType)
+}
+
+// RevokeSession 注销会话，该会话的访问令牌和刷新令牌随即失效.
+func RevokeSession(
+	ctx context.Context,
+	db qrm.DB,
+	sessionID int64,
+	reason model.SessionRevokeReason,
+	now time.Time,
+) error {
+	tbl := table.UserSessions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.RevokedAt.SET(pg.TimestampzT(now)),
+			tbl.RevokeReason.SET(pg.NewEnumValue(reason.String())),
+		).
+		WHERE(
+			tbl.ID.EQ(pg.Int64(sessionID)).
+				AND(tbl.RevokedAt.IS_NULL()),
+		)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "revoke session failed", 0)
+	}
+	return nil
+}
+
+// RevokeUserSessions 注销用户的全部会话.
+func RevokeUserSessions(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	reason model.SessionRevokeReason,
+	now time.Time,
+) error {
+	tbl := table.UserSessions
+
+	updateStmt := tbl.UPDATE().
+		SET(
+			tbl.RevokedAt.SET(pg.TimestampzT(now)),
+			tbl.RevokeReason.SET(pg.NewEnumValue(reason.String())),
+		).
+		WHERE(
+			tbl.UserID.EQ(pg.Int64(userID)).
+				AND(tbl.RevokedAt.IS_NULL()),
+		)
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "revoke user sessions failed", 0)
+	}
+	return nil
+}
+
+// DeleteExpiredSessions 删除 before 之前已过期或已注销的会话，返回删除的数量.
+func DeleteExpiredSessions(
+	ctx context.Context,
+	db qrm.DB,
+	before time.Time,
+) (int64, error) {
+	tbl := table.UserSessions
+
+	deleteStmt := tbl.DELETE().
+		WHERE(
+			tbl.ExpiresAt.LT(pg.TimestampzT(before)).
+				OR(tbl.RevokedAt.LT(pg.TimestampzT(before))),
+		)
+
+	res, err := deleteStmt.ExecContext(ctx, db)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "delete expired sessions failed", 0)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "delete expired sessions failed", 0)
+	}
+	return affected, nil
+}
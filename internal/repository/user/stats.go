@@ -62,6 +62,25 @@ func UpdateUserSubmissionStats(
 	return err
 }
 
+// UpdateUserQuestionsCreated 调整用户创建的题目数，创建和恢复时 delta 为 1，删除时为 -1.
+func UpdateUserQuestionsCreated(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	delta int64,
+) error {
+	tbl := table.UserStats
+
+	updateStmt := tbl.UPDATE().SET(
+		tbl.QuestionsCreated.SET(
+			pg.IntExp(pg.GREATEST(tbl.QuestionsCreated.ADD(pg.Int64(delta)), pg.Int64(0))),
+		),
+	).WHERE(tbl.UserID.EQ(pg.Int64(userID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	return err
+}
+
 func RecalculateUserStats(
 	ctx context.Context,
 	db qrm.DB,
@@ -83,11 +102,14 @@ func RecalculateUserStats(
 				AND(submissionTbl.IsPractice.EQ(pg.Bool(false))),
 		)
 
-	// 计算用户创建的题目数
+	// 计算用户创建的题目数（不含已删除）
 	questionStats := pg.SELECT(
 		pg.COUNT(pg.STAR).AS("questions_created"),
 	).FROM(questionTbl).
-		WHERE(questionTbl.CreatedBy.EQ(pg.Int64(userID)))
+		WHERE(
+			questionTbl.CreatedBy.EQ(pg.Int64(userID)).
+				AND(questionTbl.DeletedAt.IS_NULL()),
+		)
 
 	// 执行统计查询
 	var submissionResult struct {
@@ -0,0 +1,252 @@
+package user_repo
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/db/genshinquiz/public/table"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// GetTwoFactor 获取用户的两步验证设置，未设置时返回 nil.
+func GetTwoFactor(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) (*model.UserTwoFactor, error) {
+	tbl := table.UserTwoFactor
+
+	stmt := pg.SELECT(tbl.AllColumns).
+		FROM(tbl).
+		WHERE(tbl.UserID.EQ(pg.Int64(userID)))
+
+	var result model.UserTwoFactor
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.WrapPrefix(err, "get two factor failed", 0)
+	}
+	return &result, nil
+}
+
+// SaveTwoFactorSecret 保存待确认的密钥，重复注册时覆盖尚未确认的密钥.
+func SaveTwoFactorSecret(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	secret string,
+	now time.Time,
+) error {
+	tbl := table.UserTwoFactor
+
+	insertStmt := tbl.INSERT(
+		tbl.UserID,
+		tbl.Secret,
+		tbl.CreatedAt,
+		tbl.UpdatedAt,
+	).
+		MODEL(model.UserTwoFactor{
+			UserID:    userID,
+			Secret:    secret,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}).
+		ON_CONFLICT(tbl.UserID).
+		DO_UPDATE(
+			pg.SET(
+				tbl.Secret.SET(tbl.EXCLUDED.Secret),
+				tbl.EnabledAt.SET(pg.TimestampzExp(pg.NULL)),
+				tbl.LastUsedStep.SET(pg.IntExp(pg.NULL)),
+				tbl.UpdatedAt.SET(tbl.EXCLUDED.UpdatedAt),
+			).WHERE(tbl.EnabledAt.IS_NULL()),
+		)
+
+	_, err := insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "save two factor secret failed", 0)
+	}
+	return nil
+}
+
+// EnableTwoFactor 确认后启用两步验证.
+func EnableTwoFactor(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	step int64,
+	now time.Time,
+) error {
+	tbl := table.UserTwoFactor
+
+	stmt := tbl.UPDATE().
+		SET(
+			tbl.EnabledAt.SET(pg.TimestampzT(now)),
+			tbl.LastUsedStep.SET(pg.Int64(step)),
+			tbl.UpdatedAt.SET(pg.TimestampzT(now)),
+		).
+		WHERE(tbl.UserID.EQ(pg.Int64(userID)))
+
+	_, err := stmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "enable two factor failed", 0)
+	}
+	return nil
+}
+
+// UseTOTPStep 记录通过验证的时间步，同一时间步或更早的验证码不能再次使用.
+// 返回 false 表示验证码已被使用.
+func UseTOTPStep(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	step int64,
+) (bool, error) {
+	tbl := table.UserTwoFactor
+
+	stmt := tbl.UPDATE().
+		SET(
+			tbl.LastUsedStep.SET(pg.Int64(step)),
+			tbl.UpdatedAt.SET(pg.TimestampzT(time.Now())),
+		).
+		WHERE(
+			tbl.UserID.EQ(pg.Int64(userID)).
+				AND(tbl.LastUsedStep.IS_NULL().OR(tbl.LastUsedStep.LT(pg.Int64(step)))),
+		)
+
+	res, err := stmt.ExecContext(ctx, db)
+	if err != nil {
+		return false, errors.WrapPrefix(err, "use totp step failed", 0)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WrapPrefix(err, "use totp step failed", 0)
+	}
+	return affected > 0, nil
+}
+
+// DeleteTwoFactor 关闭两步验证，恢复码一并删除.
+func DeleteTwoFactor(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) error {
+	_, err := table.UserTwoFactor.DELETE().
+		WHERE(table.UserTwoFactor.UserID.EQ(pg.Int64(userID))).
+		ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "delete two factor failed", 0)
+	}
+	_, err = table.UserRecoveryCodes.DELETE().
+		WHERE(table.UserRecoveryCodes.UserID.EQ(pg.Int64(userID))).
+		ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "delete recovery codes failed", 0)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes 生成一组新的恢复码，旧的全部作废，返回明文恢复码.
+func ReplaceRecoveryCodes(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	count int,
+	now time.Time,
+) ([]string, error) {
+	tbl := table.UserRecoveryCodes
+
+	_, err := tbl.DELETE().
+		WHERE(tbl.UserID.EQ(pg.Int64(userID))).
+		ExecContext(ctx, db)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "delete recovery codes failed", 0)
+	}
+
+	codes := make([]string, 0, count)
+	models := make([]model.UserRecoveryCodes, 0, count)
+	for range count {
+		rawToken, err := generateRawToken()
+		if err != nil {
+			return nil, err
+		}
+		// 取 10 位十六进制并分成两段，便于抄写
+		code := rawToken[:5] + "-" + rawToken[5:10]
+		codes = append(codes, code)
+		models = append(models, model.UserRecoveryCodes{
+			UserID:    userID,
+			CodeHash:  hashToken(code),
+			CreatedAt: now,
+		})
+	}
+
+	insertStmt := tbl.INSERT(
+		tbl.UserID,
+		tbl.CodeHash,
+		tbl.CreatedAt,
+	).MODELS(models)
+
+	_, err = insertStmt.ExecContext(ctx, db)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "insert recovery codes failed", 0)
+	}
+	return codes, nil
+}
+
+// UseRecoveryCode 核销恢复码，返回 false 表示恢复码无效或已使用.
+func UseRecoveryCode(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	code string,
+) (bool, error) {
+	tbl := table.UserRecoveryCodes
+
+	stmt := tbl.UPDATE().
+		SET(tbl.UsedAt.SET(pg.TimestampzT(time.Now()))).
+		WHERE(
+			tbl.UserID.EQ(pg.Int64(userID)).
+				AND(tbl.CodeHash.EQ(pg.String(hashToken(code)))).
+				AND(tbl.UsedAt.IS_NULL()),
+		)
+
+	res, err := stmt.ExecContext(ctx, db)
+	if err != nil {
+		return false, errors.WrapPrefix(err, "use recovery code failed", 0)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WrapPrefix(err, "use recovery code failed", 0)
+	}
+	return affected > 0, nil
+}
+
+// CountUnusedRecoveryCodes 统计剩余可用的恢复码.
+func CountUnusedRecoveryCodes(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+) (int64, error) {
+	tbl := table.UserRecoveryCodes
+
+	stmt := pg.SELECT(pg.COUNT(tbl.ID).AS("count")).
+		FROM(tbl).
+		WHERE(
+			tbl.UserID.EQ(pg.Int64(userID)).
+				AND(tbl.UsedAt.IS_NULL()),
+		)
+
+	var result struct {
+		Count int64 `alias:"count"`
+	}
+	err := stmt.QueryContext(ctx, db, &result)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "count recovery codes failed", 0)
+	}
+	return result.Count, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/enum"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	examAccessTokenType = "exam_access"
+	// 访问凭证有效期
+	examAccessTokenTTL = 30 * time.Minute
+	// 窗口期内的密码尝试次数限制，验证成功后清零
+	examAccessMaxFailures = 5
+	examAccessFailWindow  = 15 * time.Minute
+)
+
+type examAccessClaims struct {
+	Type     string    `json:"typ"`
+	QuizUUID uuid.UUID `json:"quiz_uuid"`
+	UserID   int64     `json:"uid"`
+	jwt.RegisteredClaims
+}
+
+// generateExamAccessToken 签发只对指定测验和用户有效的短期凭证.
+func generateExamAccessToken(
+	secret string,
+	quizUUID uuid.UUID,
+	userID int64,
+	now time.Time,
+) (string, time.Time, error) {
+	expiresAt := now.Add(examAccessTokenTTL)
+	claims := examAccessClaims{
+		Type:     examAccessTokenType,
+		QuizUUID: quizUUID,
+		UserID:   userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// verifyExamAccessToken 校验凭证签名、有效期、测验和用户是否匹配.
+func verifyExamAccessToken(
+	secret string,
+	tokenString string,
+	quizUUID uuid.UUID,
+	userID int64,
+) bool {
+	var claims examAccessClaims
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		&claims,
+		func(*jwt.Token) (any, error) { return []byte(secret), nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !token.Valid {
+		return false
+	}
+
+	return claims.Type == examAccessTokenType &&
+		claims.QuizUUID == quizUUID &&
+		claims.UserID == userID
+}
+
+// hasExamAccess 判断当前用户能否查看题目、作答和查看排行榜，三处共用同一规则.
+// 非公开测验只是不出现在默认列表中，知道链接即可访问；密码测验需要创建者、管理人员或持有有效凭证.
+func hasExamAccess(
+	ctx context.Context,
+	app *config.App,
+	exam dao.SimpleExam,
+	accessToken *string,
+) bool {
+	if exam.Quiz.AccessCredentials == nil {
+		return true
+	}
+	if middleware.HasPermission(ctx, enum.PermExamAttemptReview) {
+		return true
+	}
+
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return false
+	}
+	if exam.Quiz.CreatedBy == userClaims.UserID {
+		return true
+	}
+	if accessToken == nil || *accessToken == "" {
+		return false
+	}
+
+	return verifyExamAccessToken(
+		app.Config.JWTSecret,
+		*accessToken,
+		exam.Quiz.QuizUUID,
+		userClaims.UserID,
+	)
+}
+
+// checkExamAccess 无访问权限时返回需要验证密码的错误.
+func checkExamAccess(
+	ctx context.Context,
+	app *config.App,
+	exam dao.SimpleExam,
+	accessToken *string,
+) error {
+	if !hasExamAccess(ctx, app, exam, accessToken) {
+		return common.ErrExamAccessRequired
+	}
+	return nil
+}
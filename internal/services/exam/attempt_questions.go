@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	question_repo "genshin-quiz/internal/repository/question"
+	"genshin-quiz/internal/util"
+
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// buildAttemptQuestions 按作答顺序构建题目，作答期间不返回答案、解析和选项统计.
+// optionOrder 为每道题的选项顺序，未指定的题目保持原顺序.
+func buildAttemptQuestions(
+	ctx context.Context,
+	db qrm.DB,
+	questionIDs []int64,
+	optionOrder map[int64][]int64,
+) ([]oapi.Question, error) {
+	questions, err := question_repo.GetQuestionsByIDs(ctx, db, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	trans, err := question_repo.GetQuestionTransByIDs(ctx, db, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	options, err := question_repo.GetOptionsByQuestionIDs(ctx, db, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	optionIDs := make([]int64, 0, len(options))
+	optionsByQuestion := make(map[int64][]model.QuestionOptions)
+	for _, opt := range options {
+		optionIDs = append(optionIDs, opt.ID)
+		optionsByQuestion[opt.QuestionID] = append(optionsByQuestion[opt.QuestionID], opt)
+	}
+	transMap := make(map[int64]oapi.LocalizedText)
+	if len(optionIDs) > 0 {
+		optionTranslations, err := question_repo.GetQuestionOptionTranslations(ctx, db, optionIDs)
+		if err != nil {
+			return nil, err
+		}
+		transMap = util.BuildOptionTranslationMap(optionTranslations)
+	}
+
+	for questionID, order := range optionOrder {
+		position := make(map[int64]int, len(order))
+		for i, id := range order {
+			position[id] = i
+		}
+		opts := optionsByQuestion[questionID]
+		sort.SliceStable(opts, func(i, j int) bool {
+			return position[opts[i].ID] < position[opts[j].ID]
+		})
+	}
+
+	questionMap := make(map[int64]dao.SimpleQuestion, len(questions))
+	for _, q := range questions {
+		questionMap[q.Question.ID] = q
+	}
+
+	dtos := make([]oapi.Question, 0, len(questionIDs))
+	for _, id := range questionIDs {
+		q, ok := questionMap[id]
+		if !ok {
+			continue
+		}
+		dto := transformer.ConvertDetailToQuestion(dao.DetailedQuestion{
+			Question:           q.Question,
+			User:               q.User,
+			Translation:        trans[id],
+			Options:            optionsByQuestion[id],
+			OptionTranslations: transMap,
+		}, false, 0)
+		dto.Explanation = nil
+		for i := range dto.Options {
+			dto.Options[i].SelectedCount = 0
+		}
+		dtos = append(dtos, dto)
+	}
+
+	return dtos, nil
+}
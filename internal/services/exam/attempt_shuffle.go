@@ -0,0 +1,45 @@
+package services
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+)
+
+// buildAttemptOrder 根据种子生成题目和选项顺序，种子为 nil 时保持原顺序.
+// 相同的种子和输入总是得到相同的结果，回顾时可以按原样重放.
+func buildAttemptOrder(
+	questionIDs []int64,
+	options []model.QuestionOptions,
+	seed *int64,
+) ([]int64, map[int64][]int64) {
+	questionOrder := make([]int64, len(questionIDs))
+	copy(questionOrder, questionIDs)
+
+	optionOrder := make(map[int64][]int64, len(questionIDs))
+	for _, opt := range options {
+		optionOrder[opt.QuestionID] = append(optionOrder[opt.QuestionID], opt.ID)
+	}
+	for _, ids := range optionOrder {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+
+	if seed == nil {
+		return questionOrder, optionOrder
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(*seed), 0))
+	rng.Shuffle(len(questionOrder), func(i, j int) {
+		questionOrder[i], questionOrder[j] = questionOrder[j], questionOrder[i]
+	})
+	// 按打乱后的题目顺序依次打乱选项，保证随机数消耗顺序固定
+	for _, questionID := range questionOrder {
+		ids := optionOrder[questionID]
+		rng.Shuffle(len(ids), func(i, j int) {
+			ids[i], ids[j] = ids[j], ids[i]
+		})
+	}
+
+	return questionOrder, optionOrder
+}
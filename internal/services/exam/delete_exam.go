@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	"genshin-quiz/internal/webserver/middleware"
+)
+
+func DeleteExam(
+	ctx context.Context,
+	app *config.App,
+	req oapi.DeleteExamRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	examInfo, err := exam_repo.GetExamByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return err
+	}
+
+	// 只有创建者可以删除
+	if examInfo.Quiz.CreatedBy != userClaims.UserID {
+		return common.ErrPermissionDenied
+	}
+
+	if err := exam_repo.DeleteExam(ctx, app.DB, examInfo.Quiz.ID); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagExams)
+	return nil
+}
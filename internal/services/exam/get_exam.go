@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	"genshin-quiz/internal/webserver/middleware"
+)
+
+func GetExam(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetExamRequestObject,
+) (*oapi.Exam, error) {
+	res, err := exam_repo.GetExamByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	examDBId := res.Quiz.ID
+
+	// 获取翻译
+	trans, err := exam_repo.GetExamTransByID(ctx, app.DB, examDBId)
+	if err != nil {
+		return nil, err
+	}
+	// 获取题目
+	questions, err := exam_repo.GetExamQuestions(ctx, app.DB, examDBId)
+	if err != nil {
+		return nil, err
+	}
+
+	detailedExam := dao.DetailedExam{
+		Quiz:        res.Quiz,
+		User:        res.User,
+		Translation: trans,
+		Questions:   questions,
+	}
+
+	// 检查用户的点赞状态（如果用户已登录）
+	likeStatus := int16(0)
+	if userClaims, ok := middleware.GetUserFromContextOnly(ctx); ok {
+		userLikeStatus, err := exam_repo.GetExamLikeStatus(
+			ctx,
+			app.DB,
+			userClaims.UserID,
+			examDBId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if userLikeStatus != nil {
+			likeStatus = *userLikeStatus
+		}
+	}
+
+	// 密码测验未验证前不返回题目
+	if !hasExamAccess(ctx, app, *res, req.Params.XExamAccessToken) {
+		detailedExam.Questions = []dao.ExamQuestionRow{}
+	}
+
+	dto := transformer.ConvertDetailToExam(detailedExam, likeStatus)
+
+	return &dto, nil
+}
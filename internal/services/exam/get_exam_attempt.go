@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	"genshin-quiz/internal/enum"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	question_repo "genshin-quiz/internal/repository/question"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-errors/errors"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// GetExamAttempt 作答回顾，只有本人和管理员可见，且必须已提交或已超时.
+func GetExamAttempt(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetExamAttemptRequestObject,
+) (*oapi.ExamAttemptReview, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	examInfo, err := exam_repo.GetExamByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	viewer, err := user_repo.GetUserInfoByID(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	isAdmin := middleware.HasPermission(ctx, enum.PermExamAttemptReview)
+
+	language := viewer.Language
+	if req.Params.Language != nil && *req.Params.Language != "" {
+		language = *req.Params.Language
+	}
+
+	examQuestions, err := exam_repo.GetExamQuestionsWithDeleted(ctx, app.DB, examInfo.Quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+	pointsMap := make(map[int64]int32, len(examQuestions))
+	for _, q := range examQuestions {
+		pointsMap[q.QuizQuestion.QuestionID] = q.QuizQuestion.Points
+	}
+
+	attempt, err := exam_repo.GetExamAttemptByUUID(ctx, app.DB, req.AttemptId)
+	if err != nil {
+		if errors.Is(err, common.ErrAttemptNotFound) {
+			// 未提交：只有超时的作答可以回顾
+			return getExpiredAttemptReview(ctx, app, req, examInfo.Quiz.ID, pointsMap, isAdmin, language)
+		}
+		return nil, err
+	}
+	if attempt.QuizID != examInfo.Quiz.ID {
+		return nil, common.ErrAttemptNotFound
+	}
+	if attempt.UserID != userClaims.UserID && !isAdmin {
+		return nil, common.ErrPermissionDenied
+	}
+
+	answers, err := exam_repo.GetExamAnswersByAttemptID(ctx, app.DB, attempt.ID)
+	if err != nil {
+		return nil, err
+	}
+	questionIDs := make([]int64, 0, len(answers))
+	answerMap := make(map[int64]model.QuizAnswers, len(answers))
+	optionOrder := make(map[int64][]int64, len(answers))
+	for _, answer := range answers {
+		questionIDs = append(questionIDs, answer.QuestionID)
+		answerMap[answer.QuestionID] = answer
+		if answer.OptionOrder != nil {
+			optionOrder[answer.QuestionID] = *answer.OptionOrder
+		}
+		// 题目被移出测验时以实际得分作为满分
+		if _, ok := pointsMap[answer.QuestionID]; !ok {
+			pointsMap[answer.QuestionID] = answer.Points
+		}
+	}
+
+	questions, err := buildAttemptReview(ctx, app.DB, questionIDs, optionOrder, answerMap, pointsMap, language)
+	if err != nil {
+		return nil, err
+	}
+
+	submittedAt := attempt.CreatedAt
+	timeSpent := int(submittedAt.Sub(attempt.StartedAt).Seconds())
+
+	return &oapi.ExamAttemptReview{
+		AttemptId:   attempt.AttemptUUID,
+		ExamId:      examInfo.Quiz.QuizUUID,
+		Status:      oapi.ExamAttemptReviewStatusSubmitted,
+		TotalScore:  int(attempt.TotalScore),
+		MaxScore:    int(attempt.MaxScore),
+		StartedAt:   attempt.StartedAt,
+		SubmittedAt: &submittedAt,
+		TimeSpent:   &timeSpent,
+		Language:    language,
+		Questions:   questions,
+	}, nil
+}
+
+// getExpiredAttemptReview 超时未提交的作答从另存的回顾数据中回顾，所有题目计 0 分.
+func getExpiredAttemptReview(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetExamAttemptRequestObject,
+	examID int64,
+	pointsMap map[int64]int32,
+	isAdmin bool,
+	language string,
+) (*oapi.ExamAttemptReview, error) {
+	if app.Redis == nil {
+		return nil, common.ErrAttemptNotFound
+	}
+
+	session, err := exam_repo.GetAttemptReview(ctx, app.Redis, req.AttemptId)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		// 不限时的作答没有回顾数据，仍在进行中时返回作答中
+		session, err = exam_repo.GetAttemptSession(ctx, app.Redis, req.AttemptId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if session == nil || session.QuizID != examID {
+		return nil, common.ErrAttemptNotFound
+	}
+
+	userClaims, _ := middleware.GetUserFromContextOnly(ctx)
+	if session.UserID != userClaims.UserID && !isAdmin {
+		return nil, common.ErrPermissionDenied
+	}
+	// 不限时的作答或仍在提交宽限期内，答案不可见
+	if session.ExpiresAt == nil || time.Now().Before(session.ExpiresAt.Add(attemptSubmitGrace)) {
+		return nil, common.ErrAttemptInProgress
+	}
+
+	maxScore := int32(0)
+	for _, questionID := range session.QuestionIDs {
+		maxScore += pointsMap[questionID]
+	}
+
+	questions, err := buildAttemptReview(
+		ctx,
+		app.DB,
+		session.QuestionIDs,
+		session.OptionOrder,
+		map[int64]model.QuizAnswers{},
+		pointsMap,
+		language,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.ExamAttemptReview{
+		AttemptId:  session.AttemptUUID,
+		ExamId:     req.Id,
+		Status:     oapi.ExamAttemptReviewStatusExpired,
+		TotalScore: 0,
+		MaxScore:   int(maxScore),
+		StartedAt:  session.StartedAt,
+		Language:   language,
+		Questions:  questions,
+	}, nil
+}
+
+// buildAttemptReview 按作答时的题目和选项顺序构建回顾.
+// 记录了选项顺序的题目按记录的选项 ID 还原，之后被移除的选项照常展示，新增的选项不展示.
+func buildAttemptReview(
+	ctx context.Context,
+	db qrm.DB,
+	questionIDs []int64,
+	optionOrder map[int64][]int64,
+	answers map[int64]model.QuizAnswers,
+	pointsMap map[int64]int32,
+	language string,
+) ([]oapi.ExamAttemptReviewQuestion, error) {
+	questions, err := question_repo.GetQuestionsByIDs(ctx, db, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	trans, err := question_repo.GetQuestionTransByIDs(ctx, db, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	options, err := loadAttemptOptions(ctx, db, questionIDs, optionOrder, answers)
+	if err != nil {
+		return nil, err
+	}
+
+	optionIDs := make([]int64, 0, len(options))
+	optionMap := make(map[int64]model.QuestionOptions, len(options))
+	for _, opt := range options {
+		optionIDs = append(optionIDs, opt.ID)
+		optionMap[opt.ID] = opt
+	}
+	transMap := make(map[int64]oapi.LocalizedText)
+	if len(optionIDs) > 0 {
+		optionTranslations, err := question_repo.GetQuestionOptionTranslations(ctx, db, optionIDs)
+		if err != nil {
+			return nil, err
+		}
+		transMap = util.BuildOptionTranslationMap(optionTranslations)
+	}
+
+	// 没有记录顺序的题目（旧作答）按选项 ID 排列
+	_, defaultOrder := buildAttemptOrder(questionIDs, options, nil)
+
+	questionMap := make(map[int64]model.Questions, len(questions))
+	for _, q := range questions {
+		questionMap[q.Question.ID] = q.Question
+	}
+
+	dtos := make([]oapi.ExamAttemptReviewQuestion, 0, len(questionIDs))
+	for _, questionID := range questionIDs {
+		question, ok := questionMap[questionID]
+		if !ok {
+			continue
+		}
+
+		order, ok := optionOrder[questionID]
+		if !ok {
+			order = defaultOrder[questionID]
+		}
+		orderedOptions := make([]model.QuestionOptions, 0, len(order))
+		for _, id := range order {
+			if opt, ok := optionMap[id]; ok && opt.QuestionID == questionID {
+				orderedOptions = append(orderedOptions, opt)
+			}
+		}
+
+		row := dao.ExamAttemptReviewQuestion{
+			Question:           question,
+			Translation:        trans[questionID],
+			Options:            orderedOptions,
+			OptionTranslations: transMap,
+			MaxPoints:          pointsMap[questionID],
+		}
+		if answer, ok := answers[questionID]; ok {
+			row.Answer = &answer
+		}
+		dtos = append(dtos, transformer.ToExamAttemptReviewQuestion(row, language))
+	}
+
+	return dtos, nil
+}
+
+// loadAttemptOptions 加载作答时展示的选项.
+// 有记录顺序的题目按记录的 ID 加载（包含已移除的选项），其余题目取当前选项.
+func loadAttemptOptions(
+	ctx context.Context,
+	db qrm.DB,
+	questionIDs []int64,
+	optionOrder map[int64][]int64,
+	answers map[int64]model.QuizAnswers,
+) ([]model.QuestionOptions, error) {
+	recordedIDs := make([]int64, 0)
+	legacyQuestionIDs := make([]int64, 0)
+	for _, questionID := range questionIDs {
+		order, ok := optionOrder[questionID]
+		if !ok {
+			legacyQuestionIDs = append(legacyQuestionIDs, questionID)
+			continue
+		}
+		recordedIDs = append(recordedIDs, order...)
+	}
+	// 已选的和判分时正确的选项即使后来被移除也要展示
+	for _, answer := range answers {
+		if answer.SelectedOptionIds != nil {
+			recordedIDs = append(recordedIDs, *answer.SelectedOptionIds...)
+		}
+		if answer.CorrectOptionIds != nil {
+			recordedIDs = append(recordedIDs, *answer.CorrectOptionIds...)
+		}
+	}
+
+	options, err := question_repo.GetOptionsByIDs(ctx, db, recordedIDs)
+	if err != nil {
+		return nil, err
+	}
+	legacyOptions, err := question_repo.GetOptionsByQuestionIDs(ctx, db, legacyQuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(options))
+	for _, opt := range options {
+		seen[opt.ID] = true
+	}
+	for _, opt := range legacyOptions {
+		if !seen[opt.ID] {
+			options = append(options, opt)
+		}
+	}
+
+	return options, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/dao/transformer"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	"genshin-quiz/internal/webserver/middleware"
+)
+
+func GetExamLeaderboard(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetExamLeaderboardRequestObject,
+) (*oapi.ExamLeaderboard, error) {
+	limit := 20
+	if req.Params.Limit != nil {
+		limit = *req.Params.Limit
+	}
+
+	offset := 0
+	if req.Params.Offset != nil {
+		offset = *req.Params.Offset
+	}
+
+	examInfo, err := exam_repo.GetExamByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExamAccess(ctx, app, *examInfo, req.Params.XExamAccessToken); err != nil {
+		return nil, err
+	}
+
+	// 关闭排行榜可见性的用户不上榜，本人的记录总是显示
+	var viewerID *int64
+	if userClaims, ok := middleware.GetUserFromContextOnly(ctx); ok {
+		viewerID = &userClaims.UserID
+	}
+
+	rows, total, err := exam_repo.GetExamLeaderboard(ctx, app.DB, examInfo.Quiz.ID, viewerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]oapi.ExamLeaderboardEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, transformer.ToExamLeaderboardEntry(row))
+	}
+
+	return &oapi.ExamLeaderboard{
+		Entries: entries,
+		Total:   total,
+	}, nil
+}
@@ -5,12 +5,76 @@ import (
 
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/dao"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/webserver/middleware"
 )
 
 func GetExams(
 	ctx context.Context,
 	app *config.App,
 	req oapi.GetExamsRequestObject,
-) error {
-	return nil
+) (*oapi.GetExams200JSONResponse, error) {
+	page := 1
+	if req.Params.Page != nil {
+		page = *req.Params.Page
+	}
+
+	limit := 25
+	if req.Params.Limit != nil {
+		limit = *req.Params.Limit
+	}
+
+	sortBy := "created_at"
+	if req.Params.SortBy != nil {
+		sortBy = *req.Params.SortBy
+	}
+
+	sortDesc := false
+	if req.Params.SortDesc != nil {
+		sortDesc = *req.Params.SortDesc
+	}
+
+	// 默认只返回公开测验
+	isPublic := true
+	param := dao.ExamListParams{
+		Page:       page,
+		NumPerPage: limit,
+		IsPublic:   &isPublic,
+		Category:   req.Params.Category,
+		Difficulty: req.Params.Difficulty,
+		Query:      req.Params.Query,
+		SortBy:     sortBy,
+		SortDesc:   sortDesc,
+	}
+
+	if req.Params.CreatedBy != nil {
+		author, err := user_repo.GetUserInfoByUUID(ctx, app.DB, *req.Params.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		param.Author = &author.ID
+
+		// 查看自己创建的测验时包括非公开测验
+		if userClaims, ok := middleware.GetUserFromContextOnly(ctx); ok &&
+			userClaims.UserID == author.ID {
+			param.IsPublic = nil
+		}
+	}
+
+	result, err := exam_repo.GetExams(ctx, app.DB, param)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos, err := exam_repo.BuildExamsWithLike(ctx, app.DB, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.GetExams200JSONResponse{
+		Exams: dtos,
+		Total: result.Total,
+	}, nil
 }
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	question_repo "genshin-quiz/internal/repository/question"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// examQuestionInput 待写入测验的题目，按顺序排列.
+type examQuestionInput struct {
+	Question model.Questions
+	Points   int32
+}
+
+// createExamInput 手动创建和随机生成共用的参数.
+type createExamInput struct {
+	Title       oapi.LocalizedText
+	Description *oapi.LocalizedText
+	Categories  []string
+	Difficulty  oapi.Difficulty
+	TimeLimit   int
+	Public      bool
+	Password    *string
+	Shuffle     bool
+	Questions   []examQuestionInput
+}
+
+func PostCreateExam(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostCreateExamRequestObject,
+) (*oapi.Exam, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	if len(req.Body.Title) == 0 {
+		return nil, common.NewBadRequestError("测验标题不能为空")
+	}
+	if req.Body.TimeLimit < 0 {
+		return nil, common.NewBadRequestError("时间限制不能为负数")
+	}
+
+	examQuestions, err := sortExamQuestions(req.Body.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验题目：必须存在且已发布，且为公开题目或自己创建的题目
+	questionUUIDs := make([]uuid.UUID, 0, len(examQuestions))
+	for _, q := range examQuestions {
+		questionUUIDs = append(questionUUIDs, *q.QuestionId)
+	}
+	questions, err := question_repo.GetQuestionsByUUIDs(ctx, app.DB, questionUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	questionMap := make(map[uuid.UUID]model.Questions, len(questions))
+	for _, q := range questions {
+		if !q.IsPublished || (!q.Public && q.CreatedBy != userClaims.UserID) {
+			continue
+		}
+		questionMap[q.QuestionUUID] = q
+	}
+	if len(questionMap) != len(questionUUIDs) {
+		return nil, common.ErrInvalidExamQuestions
+	}
+
+	questionInputs := make([]examQuestionInput, 0, len(examQuestions))
+	for _, q := range examQuestions {
+		questionInputs = append(questionInputs, examQuestionInput{
+			Question: questionMap[*q.QuestionId],
+			Points:   int32(*q.Points),
+		})
+	}
+
+	categories := make([]string, 0, len(req.Body.Categories))
+	for _, c := range req.Body.Categories {
+		categories = append(categories, string(c))
+	}
+
+	shuffle := false
+	if req.Body.Shuffle != nil {
+		shuffle = *req.Body.Shuffle
+	}
+
+	return createExam(ctx, app, userClaims.UserID, createExamInput{
+		Title:       req.Body.Title,
+		Description: req.Body.Description,
+		Categories:  categories,
+		Difficulty:  req.Body.Difficulty,
+		TimeLimit:   req.Body.TimeLimit,
+		Public:      req.Body.Public,
+		Password:    req.Body.Password,
+		Shuffle:     shuffle,
+		Questions:   questionInputs,
+	})
+}
+
+// createExam 在一个事务内写入测验、翻译、题目和统计.
+func createExam(
+	ctx context.Context,
+	app *config.App,
+	userID int64,
+	input createExamInput,
+) (*oapi.Exam, error) {
+	author, err := user_repo.GetUserInfoByID(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	// 0 表示不限时
+	var timeLimit *int32
+	if input.TimeLimit > 0 {
+		limit := int32(input.TimeLimit)
+		timeLimit = &limit
+	}
+
+	insertModel := model.Quizzes{
+		QuizUUID:   uuid.New(),
+		Public:     input.Public,
+		Difficulty: model.Difficulty(input.Difficulty),
+		Categories: input.Categories,
+		TimeLimit:  timeLimit,
+		Shuffle:    input.Shuffle,
+		CreatedBy:  userID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	// 设置了密码的测验只保存哈希
+	if input.Password != nil && *input.Password != "" {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(*input.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		credentials := string(hashed)
+		insertModel.AccessCredentials = &credentials
+	}
+	createdExam, err := exam_repo.InsertExam(ctx, tx, insertModel)
+	if err != nil {
+		return nil, err
+	}
+
+	// 测验翻译
+	transModels := make([]model.QuizTranslations, 0, len(input.Title))
+	for lang, title := range input.Title {
+		transModel := model.QuizTranslations{
+			QuizID:    createdExam.ID,
+			Language:  lang,
+			Title:     title,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if input.Description != nil {
+			if description, exists := (*input.Description)[lang]; exists {
+				transModel.Description = &description
+			}
+		}
+		transModels = append(transModels, transModel)
+	}
+	err = exam_repo.InsertExamTranslations(ctx, tx, transModels)
+	if err != nil {
+		return nil, err
+	}
+
+	// 测验题目
+	questionModels := make([]model.QuizQuestions, 0, len(input.Questions))
+	questionRows := make([]dao.ExamQuestionRow, 0, len(input.Questions))
+	for i, q := range input.Questions {
+		questionModel := model.QuizQuestions{
+			QuizID:        createdExam.ID,
+			QuestionID:    q.Question.ID,
+			QuestionOrder: int32(i),
+			Points:        q.Points,
+			CreatedAt:     now,
+		}
+		questionModels = append(questionModels, questionModel)
+		questionRows = append(questionRows, dao.ExamQuestionRow{
+			QuizQuestion: questionModel,
+			QuestionUUID: q.Question.QuestionUUID,
+		})
+	}
+	err = exam_repo.InsertExamQuestions(ctx, tx, questionModels)
+	if err != nil {
+		return nil, err
+	}
+
+	// 初始化统计
+	err = exam_repo.InsertExamStats(ctx, tx, createdExam.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagExams)
+
+	dto := transformer.ConvertDetailToExam(dao.DetailedExam{
+		Quiz:        *createdExam,
+		User:        *author,
+		Translation: transModels,
+		Questions:   questionRows,
+	}, 0)
+
+	return &dto, nil
+}
+
+// sortExamQuestions 校验题目并按 order_index 排序，未指定积分时默认 1 分.
+func sortExamQuestions(questions []oapi.ExamQuestion) ([]oapi.ExamQuestion, error) {
+	if len(questions) == 0 {
+		return nil, common.ErrInvalidExamQuestions
+	}
+
+	seen := make(map[uuid.UUID]bool, len(questions))
+	result := make([]oapi.ExamQuestion, 0, len(questions))
+	for i, q := range questions {
+		if q.QuestionId == nil || seen[*q.QuestionId] {
+			return nil, common.ErrInvalidExamQuestions
+		}
+		seen[*q.QuestionId] = true
+
+		if q.OrderIndex == nil {
+			order := float32(i)
+			q.OrderIndex = &order
+		}
+		if q.Points == nil {
+			points := float32(1)
+			q.Points = &points
+		}
+		if *q.Points <= 0 || *q.Points != float32(math.Trunc(float64(*q.Points))) {
+			return nil, common.NewBadRequestError("题目分数必须为正整数")
+		}
+		result = append(result, q)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return *result[i].OrderIndex < *result[j].OrderIndex
+	})
+
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/ratelimit"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func PostExamAccess(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostExamAccessRequestObject,
+) (*oapi.ExamAccessGrant, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	examInfo, err := exam_repo.GetExamByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if examInfo.Quiz.AccessCredentials == nil {
+		return nil, common.NewBadRequestError("该测验不需要密码")
+	}
+
+	examDBId := examInfo.Quiz.ID
+
+	// 先计数再校验密码，并发的猜测不能同时绕过次数检查；验证成功后清零
+	limitKey := fmt.Sprintf("exam_access:%d:%d", examDBId, userClaims.UserID)
+	result, err := app.Limiter.Allow(ctx, limitKey, ratelimit.Limit{
+		Requests: examAccessMaxFailures,
+		Window:   examAccessFailWindow,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !result.Allowed {
+		return nil, common.ErrTooManyAttempts
+	}
+
+	err = bcrypt.CompareHashAndPassword(
+		[]byte(*examInfo.Quiz.AccessCredentials),
+		[]byte(req.Body.Password),
+	)
+	if err != nil {
+		return nil, common.ErrInvalidExamPassword
+	}
+
+	if err := app.Limiter.Reset(ctx, limitKey); err != nil {
+		app.Logger.Warn("Failed to reset exam access attempts", zap.Error(err))
+	}
+
+	token, expiresAt, err := generateExamAccessToken(
+		app.Config.JWTSecret,
+		examInfo.Quiz.QuizUUID,
+		userClaims.UserID,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.ExamAccessGrant{
+		AccessToken: token,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	question_repo "genshin-quiz/internal/repository/question"
+	"genshin-quiz/internal/webserver/middleware"
+)
+
+const (
+	maxGeneratedExamQuestions = 100
+	// 排除已答对题目时，每个难度最多取多少候选题再过滤
+	generatedExamCandidateLimit = 500
+)
+
+var generatedExamDifficulties = []oapi.Difficulty{oapi.Easy, oapi.Medium, oapi.Hard}
+
+func PostGenerateExam(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostGenerateExamRequestObject,
+) (*oapi.Exam, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	if len(req.Body.Title) == 0 {
+		return nil, common.NewBadRequestError("测验标题不能为空")
+	}
+	if req.Body.TimeLimit < 0 {
+		return nil, common.NewBadRequestError("时间限制不能为负数")
+	}
+	if req.Body.Count <= 0 || req.Body.Count > maxGeneratedExamQuestions {
+		return nil, common.NewBadRequestError("题目数量无效")
+	}
+	if req.Body.Language == "" {
+		return nil, common.NewBadRequestError("语言不能为空")
+	}
+
+	counts, err := allocateDifficultyCounts(req.Body.DifficultyMix, req.Body.Count)
+	if err != nil {
+		return nil, err
+	}
+	excludeSolved := req.Body.ExcludeSolved != nil && *req.Body.ExcludeSolved
+
+	// 只从已发布的公开题目中抽取
+	isPublic := true
+	isPublished := true
+	languages := []string{req.Body.Language}
+
+	pickedIDs := make([]int64, 0, req.Body.Count)
+	for _, bucket := range counts {
+		params := dao.QuestionListParams{
+			IsPublic:    &isPublic,
+			IsPublished: &isPublished,
+			Category:    req.Body.Category,
+			Difficulty:  bucket.Difficulties,
+			Language:    &languages,
+		}
+
+		limit := bucket.Count
+		if excludeSolved {
+			limit = generatedExamCandidateLimit
+		}
+		candidates, err := question_repo.GetRandomQuestionIDs(ctx, app.DB, params, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		if excludeSolved {
+			solvedMap, err := question_repo.CheckMultipleQuestionsSolved(
+				ctx,
+				app.DB,
+				userClaims.UserID,
+				candidates,
+			)
+			if err != nil {
+				return nil, err
+			}
+			unsolved := make([]int64, 0, len(candidates))
+			for _, id := range candidates {
+				if !solvedMap[id] {
+					unsolved = append(unsolved, id)
+				}
+			}
+			candidates = unsolved
+		}
+
+		if len(candidates) < bucket.Count {
+			return nil, common.ErrNotEnoughQuestions
+		}
+		pickedIDs = append(pickedIDs, candidates[:bucket.Count]...)
+	}
+
+	questions, err := question_repo.GetQuestionsByIDs(ctx, app.DB, pickedIDs)
+	if err != nil {
+		return nil, err
+	}
+	questionMap := make(map[int64]model.Questions, len(questions))
+	for _, q := range questions {
+		questionMap[q.Question.ID] = q.Question
+	}
+
+	questionInputs := make([]examQuestionInput, 0, len(pickedIDs))
+	difficultyCount := make(map[oapi.Difficulty]int)
+	categorySet := make(map[string]bool)
+	for _, id := range pickedIDs {
+		q, ok := questionMap[id]
+		if !ok {
+			return nil, common.ErrNotEnoughQuestions
+		}
+		questionInputs = append(questionInputs, examQuestionInput{
+			Question: q,
+			Points:   1,
+		})
+		difficultyCount[oapi.Difficulty(q.Difficulty)]++
+		categorySet[string(q.Category)] = true
+	}
+
+	// 测验难度取数量最多的难度，数量相同时取较难的
+	difficulty := oapi.Medium
+	maxCount := 0
+	for _, d := range generatedExamDifficulties {
+		if difficultyCount[d] > 0 && difficultyCount[d] >= maxCount {
+			difficulty = d
+			maxCount = difficultyCount[d]
+		}
+	}
+
+	categories := make([]string, 0, len(categorySet))
+	for c := range categorySet {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+
+	return createExam(ctx, app, userClaims.UserID, createExamInput{
+		Title:       req.Body.Title,
+		Description: req.Body.Description,
+		Categories:  categories,
+		Difficulty:  difficulty,
+		TimeLimit:   req.Body.TimeLimit,
+		Public:      req.Body.Public,
+		Password:    req.Body.Password,
+		Shuffle:     true,
+		Questions:   questionInputs,
+	})
+}
+
+type difficultyBucket struct {
+	Difficulties *[]oapi.Difficulty // nil 表示不限难度
+	Count        int
+}
+
+// allocateDifficultyCounts 按比例把题目数量分配到各难度（最大余数法）.
+func allocateDifficultyCounts(mix *oapi.DifficultyMix, total int) ([]difficultyBucket, error) {
+	if mix == nil {
+		return []difficultyBucket{{Count: total}}, nil
+	}
+
+	weights := []*int{mix.Easy, mix.Medium, mix.Hard}
+	sum := 0
+	for _, w := range weights {
+		if w == nil {
+			continue
+		}
+		if *w < 0 {
+			return nil, common.NewBadRequestError("难度比例不能为负数")
+		}
+		sum += *w
+	}
+	if sum == 0 {
+		return []difficultyBucket{{Count: total}}, nil
+	}
+
+	counts := make([]int, len(weights))
+	remainders := make([]int, len(weights))
+	assigned := 0
+	for i, w := range weights {
+		if w == nil {
+			continue
+		}
+		counts[i] = total * *w / sum
+		remainders[i] = total * *w % sum
+		assigned += counts[i]
+	}
+
+	// 剩余的题目分给余数最大的难度
+	order := []int{0, 1, 2}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for _, i := range order {
+		if assigned >= total {
+			break
+		}
+		if weights[i] == nil || *weights[i] == 0 {
+			continue
+		}
+		counts[i]++
+		assigned++
+	}
+
+	buckets := make([]difficultyBucket, 0, len(weights))
+	for i, d := range generatedExamDifficulties {
+		if counts[i] == 0 {
+			continue
+		}
+		difficulties := []oapi.Difficulty{d}
+		buckets = append(buckets, difficultyBucket{
+			Difficulties: &difficulties,
+			Count:        counts[i],
+		})
+	}
+	return buckets, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	"genshin-quiz/internal/webserver/middleware"
+)
+
+func PostLikeExam(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostLikeExamRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	value := int16(req.Body.Like)
+
+	examInfo, err := exam_repo.GetExamByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return err
+	}
+
+	examDBId := examInfo.Quiz.ID
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 更新点赞状态
+	err = exam_repo.UpsertExamLike(ctx, tx, userClaims.UserID, examDBId, value)
+	if err != nil {
+		return err
+	}
+	// 更新测验的点赞数
+	err = exam_repo.UpdateExamLikeCount(ctx, tx, examDBId)
+	if err != nil {
+		return err
+	}
+
+	// 提交事务
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagExams)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	question_repo "genshin-quiz/internal/repository/question"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// 提交的网络延迟宽限
+	attemptSubmitGrace = 10 * time.Second
+	// 不限时测验的作答会话最长保留时间
+	untimedAttemptTTL = 24 * time.Hour
+	// 超时后回顾数据继续保留，用于回顾未提交的作答
+	expiredAttemptReviewTTL = 24 * time.Hour
+)
+
+func PostStartExamAttempt(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostStartExamAttemptRequestObject,
+) (*oapi.ExamAttemptStart, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+	if app.Redis == nil {
+		return nil, common.ErrRedisUnavailable
+	}
+
+	examInfo, err := exam_repo.GetExamByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExamAccess(ctx, app, *examInfo, req.Params.XExamAccessToken); err != nil {
+		return nil, err
+	}
+
+	examQuestions, err := exam_repo.GetExamQuestions(ctx, app.DB, examInfo.Quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(examQuestions) == 0 {
+		return nil, common.ErrInvalidExamQuestions
+	}
+
+	questionIDs := make([]int64, 0, len(examQuestions))
+	for _, q := range examQuestions {
+		questionIDs = append(questionIDs, q.QuizQuestion.QuestionID)
+	}
+
+	// 需要打乱时为本次作答生成种子，顺序随会话保存，提交时写入数据库
+	var seed *int64
+	if examInfo.Quiz.Shuffle {
+		s := rand.Int64()
+		seed = &s
+	}
+	options, err := question_repo.GetOptionsByQuestionIDs(ctx, app.DB, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	questionOrder, optionOrder := buildAttemptOrder(questionIDs, options, seed)
+
+	questions, err := buildAttemptQuestions(ctx, app.DB, questionOrder, optionOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	// 开始时间以服务端为准
+	now := time.Now()
+	session := dao.ExamAttemptSession{
+		AttemptUUID: uuid.New(),
+		QuizID:      examInfo.Quiz.ID,
+		UserID:      userClaims.UserID,
+		StartedAt:   now,
+		QuestionIDs: questionOrder,
+		ShuffleSeed: seed,
+		OptionOrder: optionOrder,
+	}
+	// 限时测验的会话在时限和提交宽限后过期，回顾数据另存并保留更久
+	ttl := untimedAttemptTTL
+	if limit := examInfo.Quiz.TimeLimit; limit != nil && *limit > 0 {
+		expiresAt := now.Add(time.Duration(*limit) * time.Second)
+		session.ExpiresAt = &expiresAt
+		ttl = time.Duration(*limit)*time.Second + attemptSubmitGrace
+
+		err = exam_repo.SaveAttemptReview(ctx, app.Redis, session, ttl+expiredAttemptReviewTTL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = exam_repo.SaveAttemptSession(ctx, app.Redis, session, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.ExamAttemptStart{
+		AttemptId: session.AttemptUUID,
+		ExamId:    examInfo.Quiz.QuizUUID,
+		StartedAt: session.StartedAt,
+		ExpiresAt: session.ExpiresAt,
+		Questions: questions,
+	}, nil
+}
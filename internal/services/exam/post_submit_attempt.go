@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	question_repo "genshin-quiz/internal/repository/question"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+func PostSubmitExamAttempt(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostSubmitExamAttemptRequestObject,
+) (*oapi.ExamAttemptResult, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+	if app.Redis == nil {
+		return nil, common.ErrRedisUnavailable
+	}
+
+	submittedAt := time.Now()
+
+	session, err := exam_repo.GetAttemptSession(ctx, app.Redis, req.AttemptId)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		// 会话不存在：已经提交过，或者已超时
+		exists, err := exam_repo.CheckAttemptExists(ctx, app.DB, req.AttemptId)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, common.ErrAttemptSubmitted
+		}
+		return nil, common.ErrAttemptNotFound
+	}
+
+	examInfo, err := exam_repo.GetExamByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userClaims.UserID || session.QuizID != examInfo.Quiz.ID {
+		return nil, common.ErrAttemptNotFound
+	}
+	if session.ExpiresAt != nil && submittedAt.After(session.ExpiresAt.Add(attemptSubmitGrace)) {
+		return nil, common.ErrAttemptExpired
+	}
+
+	// 题目积分
+	examQuestions, err := exam_repo.GetExamQuestionsWithDeleted(ctx, app.DB, examInfo.Quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+	pointsMap := make(map[int64]int32, len(examQuestions))
+	questionIDByUUID := make(map[uuid.UUID]int64, len(examQuestions))
+	questionUUIDByID := make(map[int64]uuid.UUID, len(examQuestions))
+	for _, q := range examQuestions {
+		pointsMap[q.QuizQuestion.QuestionID] = q.QuizQuestion.Points
+		questionIDByUUID[q.QuestionUUID] = q.QuizQuestion.QuestionID
+		questionUUIDByID[q.QuizQuestion.QuestionID] = q.QuestionUUID
+	}
+
+	// 选项与正确答案
+	options, err := question_repo.GetOptionsByQuestionIDs(ctx, app.DB, session.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	optionByUUID := make(map[uuid.UUID]model.QuestionOptions, len(options))
+	correctIDs := make(map[int64]pq.Int64Array)
+	for _, opt := range options {
+		optionByUUID[opt.OptionUUID] = opt
+		if opt.IsAnswer {
+			correctIDs[opt.QuestionID] = append(correctIDs[opt.QuestionID], opt.ID)
+		}
+	}
+
+	// 整理用户答案
+	inSession := make(map[int64]bool, len(session.QuestionIDs))
+	for _, id := range session.QuestionIDs {
+		inSession[id] = true
+	}
+	answers := make(map[int64]oapi.ExamAnswer, len(req.Body.Answers))
+	selectedMap := make(map[int64][]model.QuestionOptions, len(req.Body.Answers))
+	for _, answer := range req.Body.Answers {
+		questionID, ok := questionIDByUUID[answer.QuestionId]
+		if !ok || !inSession[questionID] {
+			return nil, common.NewBadRequestError("题目不属于本次作答")
+		}
+		if _, dup := answers[questionID]; dup {
+			return nil, common.NewBadRequestError("同一题目重复作答")
+		}
+		answers[questionID] = answer
+
+		seen := make(map[uuid.UUID]bool, len(answer.SelectedOptionIds))
+		for _, optionUUID := range answer.SelectedOptionIds {
+			opt, ok := optionByUUID[optionUUID]
+			if !ok || opt.QuestionID != questionID || seen[optionUUID] {
+				return nil, common.NewBadRequestError("选项无效")
+			}
+			seen[optionUUID] = true
+			selectedMap[questionID] = append(selectedMap[questionID], opt)
+		}
+	}
+
+	// 判分：选中的选项与正确选项完全一致才得分
+	elapsed := int32(submittedAt.Sub(session.StartedAt).Seconds())
+	totalScore := int32(0)
+	maxScore := int32(0)
+	answerModels := make([]model.QuizAnswers, 0, len(session.QuestionIDs))
+	answerResults := make([]oapi.ExamAnswerResult, 0, len(session.QuestionIDs))
+	for displayOrder, questionID := range session.QuestionIDs {
+		points, ok := pointsMap[questionID]
+		if !ok {
+			// 作答期间题目被移出测验
+			continue
+		}
+		maxScore += points
+
+		selected := selectedMap[questionID]
+		correct := len(selected) > 0 && len(selected) == len(correctIDs[questionID])
+		selectedIDs := make(pq.Int64Array, 0, len(selected))
+		for _, opt := range selected {
+			correct = correct && opt.IsAnswer
+			selectedIDs = append(selectedIDs, opt.ID)
+		}
+
+		earned := int32(0)
+		if correct {
+			earned = points
+			totalScore += points
+		}
+
+		// 记录判分时的正确选项，之后编辑题目不影响回顾
+		correctOptionIDs := correctIDs[questionID]
+		if correctOptionIDs == nil {
+			correctOptionIDs = pq.Int64Array{}
+		}
+		answerModel := model.QuizAnswers{
+			QuestionID:       questionID,
+			IsCorrect:        correct,
+			Points:           earned,
+			DisplayOrder:     int32(displayOrder),
+			CorrectOptionIds: &correctOptionIDs,
+			CreatedAt:        submittedAt,
+		}
+		if order, ok := session.OptionOrder[questionID]; ok {
+			optionOrder := pq.Int64Array(order)
+			answerModel.OptionOrder = &optionOrder
+		}
+		if answer, answered := answers[questionID]; answered {
+			answerModel.SelectedOptionIds = &selectedIDs
+			if answer.TimeSpent != nil {
+				timeTaken := min(max(int32(*answer.TimeSpent), 0), elapsed)
+				answerModel.TimeTaken = &timeTaken
+			}
+		}
+		answerModels = append(answerModels, answerModel)
+
+		answerResults = append(answerResults, oapi.ExamAnswerResult{
+			QuestionId: questionUUIDByID[questionID],
+			Correct:    correct,
+			Points:     int(earned),
+			MaxPoints:  int(points),
+		})
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// attempt_uuid 唯一，并发重复提交时只有一次能成功，其余返回 ErrAttemptSubmitted
+	attempt, err := exam_repo.InsertExamAttempt(ctx, tx, model.QuizAttempts{
+		AttemptUUID: session.AttemptUUID,
+		QuizID:      session.QuizID,
+		UserID:      session.UserID,
+		StartedAt:   session.StartedAt,
+		TotalScore:  totalScore,
+		MaxScore:    maxScore,
+		ShuffleSeed: session.ShuffleSeed,
+		CreatedAt:   submittedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range answerModels {
+		answerModels[i].AttemptID = attempt.ID
+	}
+	err = exam_repo.InsertExamAnswers(ctx, tx, answerModels)
+	if err != nil {
+		return nil, err
+	}
+
+	// 测验统计与作答记录一起提交
+	err = exam_repo.RecalculateExamStats(ctx, tx, session.QuizID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// 会话删除失败不影响结果，重复提交会被 attempt_uuid 拦截
+	if err := exam_repo.DeleteAttemptSession(ctx, app.Redis, session.AttemptUUID); err != nil {
+		app.Logger.Warn("Failed to delete attempt session", zap.Error(err))
+	}
+
+	return &oapi.ExamAttemptResult{
+		AttemptId:   attempt.AttemptUUID,
+		ExamId:      examInfo.Quiz.QuizUUID,
+		TotalScore:  int(attempt.TotalScore),
+		MaxScore:    int(attempt.MaxScore),
+		StartedAt:   attempt.StartedAt,
+		SubmittedAt: attempt.CreatedAt,
+		Answers:     answerResults,
+	}, nil
+}
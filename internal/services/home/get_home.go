@@ -2,18 +2,41 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/dao"
+	exam_repo "genshin-quiz/internal/repository/exam"
 	poll_repo "genshin-quiz/internal/repository/poll"
 	question_repo "genshin-quiz/internal/repository/question"
 )
 
+const homeCacheTTL = time.Minute
+
+// GetHome 首页数据，按语言和访问者缓存，题目、投票或测验变更时失效.
 func GetHome(
 	ctx context.Context,
 	app *config.App,
 	req oapi.GetHomeRequestObject,
+) (*oapi.GetHome200JSONResponse, error) {
+	return cache.GetOrLoad(
+		ctx,
+		app.Redis,
+		cache.Key(ctx, "home", req.Params),
+		[]string{cache.TagQuestions, cache.TagPolls, cache.TagExams},
+		homeCacheTTL,
+		func(ctx context.Context) (*oapi.GetHome200JSONResponse, error) {
+			return loadHome(ctx, app, req)
+		},
+	)
+}
+
+func loadHome(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetHomeRequestObject,
 ) (*oapi.GetHome200JSONResponse, error) {
 	var language *[]string
 	if req.Params.Language != nil {
@@ -34,9 +57,13 @@ func GetHome(
 	if err != nil {
 		return nil, err
 	}
+	popularExams, err := getPopularExams(ctx, app)
+	if err != nil {
+		return nil, err
+	}
 
 	return &oapi.GetHome200JSONResponse{
-		PopularExams:    []oapi.Exam{},
+		PopularExams:    popularExams,
 		LatestQuestions: latestQuestions,
 		LatestPolls:     latestPolls,
 		PopularPolls:    popularPolls,
@@ -49,18 +76,45 @@ func getLatestQuestions(
 	language *[]string,
 ) ([]oapi.Question, error) {
 	questionSortBy := "PublishDate"
+	isPublished := true
 	result, err := question_repo.GetQuestions(ctx, app.DB, dao.QuestionListParams{
+		Page:        1,
+		NumPerPage:  5,
+		IsPublished: &isPublished,
+		SortBy:      questionSortBy,
+		SortDesc:    true,
+		Language:    language,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dtos, err := question_repo.BuildQuestionsWithTransaction(ctx, app.DB, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtos, nil
+}
+
+// getPopularExams 按热度排序的公开测验，热度由定时任务计算.
+func getPopularExams(
+	ctx context.Context,
+	app *config.App,
+) ([]oapi.Exam, error) {
+	isPublic := true
+	result, err := exam_repo.GetExams(ctx, app.DB, dao.ExamListParams{
 		Page:       1,
 		NumPerPage: 5,
-		SortBy:     questionSortBy,
+		IsPublic:   &isPublic,
+		SortBy:     "trending",
 		SortDesc:   true,
-		Language:   language,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	dtos, err := question_repo.BuildQuestionsWithTransaction(ctx, app.DB, result)
+	dtos, err := exam_repo.BuildExamsWithLike(ctx, app.DB, result)
 	if err != nil {
 		return nil, err
 	}
@@ -68,12 +122,13 @@ func getLatestQuestions(
 	return dtos, nil
 }
 
+// getPopularPolls 按热度排序的投票，热度由定时任务计算.
 func getPopularPolls(
 	ctx context.Context,
 	app *config.App,
 	language *[]string,
 ) ([]oapi.Poll, error) {
-	sortBy := "created_at"
+	sortBy := "trending"
 	result, err := poll_repo.GetPolls(ctx, app.DB, dao.PollListParams{
 		Page:       1,
 		NumPerPage: 5,
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	poll_repo "genshin-quiz/internal/repository/poll"
+	question_repo "genshin-quiz/internal/repository/question"
+	report_repo "genshin-quiz/internal/repository/report"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/google/uuid"
+)
+
+const maxReportDetailsLength = 1000
+
+// reportTarget 被举报的内容，UserID 为内容作者，作者已注销时为 nil.
+type reportTarget struct {
+	ID     int64
+	UUID   uuid.UUID
+	UserID *int64
+}
+
+// PostReport 举报题目、投票、评论或用户.
+// 同一目标未处理的举报合并为一条，同一用户重复举报只记一次.
+func PostReport(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostReportRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+	if !req.Body.TargetType.Valid() || !req.Body.Reason.Valid() {
+		return common.NewBadRequestError("举报参数无效")
+	}
+
+	var details *string
+	if req.Body.Details != nil {
+		trimmed := strings.TrimSpace(*req.Body.Details)
+		if utf8.RuneCountInString(trimmed) > maxReportDetailsLength {
+			return common.NewBadRequestError("举报说明过长")
+		}
+		if trimmed != "" {
+			details = &trimmed
+		}
+	}
+
+	target, err := getReportTarget(ctx, app, req.Body.TargetType, req.Body.TargetId)
+	if err != nil {
+		return err
+	}
+	if target.UserID != nil && *target.UserID == userClaims.UserID {
+		return common.NewBadRequestError("不能举报自己的内容")
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	targetType := model.ReportTargetType(req.Body.TargetType)
+	report, err := report_repo.GetOpenReport(ctx, tx, targetType, target.ID)
+	if err != nil {
+		return err
+	}
+	isNewReport := report == nil
+	if isNewReport {
+		report, err = report_repo.InsertReport(ctx, tx, model.Reports{
+			ReportUUID:   uuid.New(),
+			TargetType:   targetType,
+			TargetID:     target.ID,
+			TargetUUID:   target.UUID,
+			TargetUserID: target.UserID,
+			Status:       model.ReportStatus_Open,
+			ReportCount:  1,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	inserted, err := report_repo.InsertReportEntry(ctx, tx, model.ReportEntries{
+		ReportID:   report.ID,
+		ReporterID: userClaims.UserID,
+		Reason:     model.ReportReason(req.Body.Reason),
+		Details:    details,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		return err
+	}
+	// 新举报创建时已计为一次
+	if inserted && !isNewReport {
+		err = report_repo.IncrementReportCount(ctx, tx, report.ID, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// getReportTarget 查找被举报的内容，已删除或不存在的内容不能举报.
+func getReportTarget(
+	ctx context.Context,
+	app *config.App,
+	targetType oapi.ReportTargetType,
+	targetUUID uuid.UUID,
+) (*reportTarget, error) {
+	switch targetType {
+	case oapi.ReportTargetTypeQuestion:
+		question, err := question_repo.GetQuestionByUUID(ctx, app.DB, targetUUID)
+		if err != nil {
+			return nil, err
+		}
+		return &reportTarget{
+			ID:     question.Question.ID,
+			UUID:   targetUUID,
+			UserID: &question.Question.CreatedBy,
+		}, nil
+	case oapi.ReportTargetTypePoll:
+		poll, err := poll_repo.GetPollByUUID(ctx, app.DB, targetUUID)
+		if err != nil {
+			return nil, err
+		}
+		return &reportTarget{
+			ID:     poll.Poll.ID,
+			UUID:   targetUUID,
+			UserID: &poll.Poll.CreatedBy,
+		}, nil
+	case oapi.ReportTargetTypeQuestionComment:
+		comment, err := question_repo.GetQuestionCommentByCommentUUID(ctx, app.DB, targetUUID)
+		if err != nil {
+			return nil, err
+		}
+		if comment.DeletedAt != nil {
+			return nil, common.ErrCommentNotFound
+		}
+		return &reportTarget{
+			ID:     comment.ID,
+			UUID:   targetUUID,
+			UserID: &comment.UserID,
+		}, nil
+	case oapi.ReportTargetTypePollComment:
+		comment, err := poll_repo.GetPollCommentByCommentUUID(ctx, app.DB, targetUUID)
+		if err != nil {
+			return nil, err
+		}
+		if comment.DeletedAt != nil {
+			return nil, common.ErrCommentNotFound
+		}
+		return &reportTarget{
+			ID:     comment.ID,
+			UUID:   targetUUID,
+			UserID: comment.UserID,
+		}, nil
+	case oapi.ReportTargetTypeUser:
+		user, err := user_repo.GetUserInfoByUUID(ctx, app.DB, targetUUID)
+		if err != nil {
+			return nil, err
+		}
+		return &reportTarget{
+			ID:     user.ID,
+			UUID:   targetUUID,
+			UserID: &user.ID,
+		}, nil
+	default:
+		return nil, common.NewBadRequestError("举报类型无效")
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	"genshin-quiz/internal/enum"
+	poll_repo "genshin-quiz/internal/repository/poll"
+	question_repo "genshin-quiz/internal/repository/question"
+	report_repo "genshin-quiz/internal/repository/report"
+	question_services "genshin-quiz/internal/services/question"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// GetReportQueue 查看举报队列，默认只看未处理的举报.
+func GetReportQueue(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetReportQueueRequestObject,
+) (*oapi.GetReportQueue200JSONResponse, error) {
+	page := 1
+	if req.Params.Page != nil && *req.Params.Page > 0 {
+		page = *req.Params.Page
+	}
+	limit := 25
+	if req.Params.Limit != nil && *req.Params.Limit > 0 {
+		limit = *req.Params.Limit
+	}
+	status := model.ReportStatus_Open
+	if req.Params.Status != nil {
+		if !req.Params.Status.Valid() {
+			return nil, common.NewBadRequestError("举报状态无效")
+		}
+		status = model.ReportStatus(*req.Params.Status)
+	}
+
+	reports, err := report_repo.GetReports(ctx, app.DB, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	total, err := report_repo.GetReportCount(ctx, app.DB, status)
+	if err != nil {
+		return nil, err
+	}
+
+	reportIDs := make([]int64, 0, len(reports))
+	for _, r := range reports {
+		reportIDs = append(reportIDs, r.Report.ID)
+	}
+	reasonCounts, err := report_repo.GetReportReasonCounts(ctx, app.DB, reportIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]oapi.Report, 0, len(reports))
+	for _, r := range reports {
+		dtos = append(dtos, transformer.ToReport(r, reasonCounts[r.Report.ID]))
+	}
+
+	return &oapi.GetReportQueue200JSONResponse{
+		Reports: dtos,
+		Total:   int(total),
+	}, nil
+}
+
+// ResolveReport 处理举报：忽略、隐藏内容或封禁作者，每次处理都写入审核日志.
+func ResolveReport(
+	ctx context.Context,
+	app *config.App,
+	req oapi.ResolveReportRequestObject,
+) (*oapi.Report, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+	if !req.Body.Action.Valid() {
+		return nil, common.NewBadRequestError("处理方式无效")
+	}
+
+	report, err := report_repo.GetReportByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if report.Report.Status != model.ReportStatus_Open {
+		return nil, common.NewBadRequestError("举报已处理")
+	}
+	err = middleware.RequirePermission(ctx, moderationPermission(req.Body.Action, report.Report.TargetType))
+	if err != nil {
+		return nil, err
+	}
+
+	var note *string
+	if req.Body.Note != nil {
+		trimmed := strings.TrimSpace(*req.Body.Note)
+		if trimmed != "" {
+			note = &trimmed
+		}
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	status := model.ReportStatus_Resolved
+	switch req.Body.Action {
+	case oapi.Dismiss:
+		status = model.ReportStatus_Dismissed
+	case oapi.Hide:
+		err = hideReportedContent(ctx, tx, report.Report, userClaims.UserID, now)
+	case oapi.Suspend:
+		err = suspendReportedUser(ctx, tx, report.Report, req.Body.SuspendedUntil, note, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := report_repo.ResolveReport(ctx, tx, report.Report.ID, status, userClaims.UserID, now)
+	if err != nil {
+		return nil, err
+	}
+	err = report_repo.InsertModerationLog(ctx, tx, model.ModerationLogs{
+		ModeratorID: &userClaims.UserID,
+		Action:      model.ModerationAction(req.Body.Action),
+		ReportID:    &report.Report.ID,
+		TargetType:  report.Report.TargetType,
+		TargetID:    report.Report.TargetID,
+		Note:        note,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if tags := resolvedReportCacheTags(req.Body.Action, report.Report.TargetType); len(tags) > 0 {
+		cache.Invalidate(ctx, app.Redis, tags...)
+	}
+
+	reasonCounts, err := report_repo.GetReportReasonCounts(ctx, app.DB, []int64{resolved.ID})
+	if err != nil {
+		return nil, err
+	}
+	dto := transformer.ToReport(
+		dao.Report{Report: *resolved, TargetUser: report.TargetUser},
+		reasonCounts[resolved.ID],
+	)
+	return &dto, nil
+}
+
+// hideReportedContent 隐藏被举报的内容，题目和评论软删除，投票标记为隐藏.
+func hideReportedContent(
+	ctx context.Context,
+	db qrm.DB,
+	report model.Reports,
+	moderatorID int64,
+	now time.Time,
+) error {
+	switch report.TargetType {
+	case model.ReportTargetType_Question:
+		question, err := question_repo.GetQuestionByUUIDWithDeleted(ctx, db, report.TargetUUID)
+		if err != nil {
+			return err
+		}
+		return question_services.SoftDeleteQuestions(
+			ctx,
+			db,
+			question.Question.CreatedBy,
+			[]int64{question.Question.ID},
+			moderatorID,
+			now,
+		)
+	case model.ReportTargetType_Poll:
+		return poll_repo.HidePoll(ctx, db, report.TargetID, moderatorID, now)
+	case model.ReportTargetType_QuestionComment:
+		comment, err := question_repo.GetQuestionCommentByID(ctx, db, report.TargetID)
+		if err != nil {
+			return err
+		}
+		if comment.DeletedAt != nil {
+			return nil
+		}
+		return question_repo.DeleteQuestionComment(ctx, db, comment.ID, moderatorID, now)
+	case model.ReportTargetType_PollComment:
+		comment, err := poll_repo.GetPollCommentByCommentUUID(ctx, db, report.TargetUUID)
+		if err != nil {
+			return err
+		}
+		if comment.DeletedAt != nil {
+			return nil
+		}
+		return poll_repo.DeletePollComment(ctx, db, comment.ID, moderatorID, now)
+	default:
+		return common.NewBadRequestError("用户举报不能隐藏，请选择封禁")
+	}
+}
+
+// suspendReportedUser 封禁被举报内容的作者，备注作为封禁原因.
+func suspendReportedUser(
+	ctx context.Context,
+	db qrm.DB,
+	report model.Reports,
+	until *time.Time,
+	note *string,
+	now time.Time,
+) error {
+	if report.TargetUserID == nil {
+		return common.ErrUserNotFound
+	}
+	if note == nil {
+		return common.NewBadRequestError("封禁时必须填写原因")
+	}
+	return suspendUser(ctx, db, *report.TargetUserID, until, *note, now)
+}
+
+// resolvedReportCacheTags 处理举报后需要失效的缓存，评论数随评论隐藏变化.
+func resolvedReportCacheTags(action oapi.ModerationAction, targetType model.ReportTargetType) []string {
+	switch action {
+	case oapi.Hide:
+		switch targetType {
+		case model.ReportTargetType_Question:
+			return []string{cache.TagQuestions, cache.TagExams}
+		case model.ReportTargetType_QuestionComment:
+			return []string{cache.TagQuestions}
+		case model.ReportTargetType_Poll, model.ReportTargetType_PollComment:
+			return []string{cache.TagPolls}
+		}
+	case oapi.Suspend:
+		return cache.UserContentTags
+	}
+	return nil
+}
+
+// moderationPermission 处理方式需要的权限，忽略举报只需要审核举报的权限.
+func moderationPermission(action oapi.ModerationAction, targetType model.ReportTargetType) enum.Permission {
+	if action == oapi.Suspend {
+		return enum.PermUserSuspend
+	}
+	if action != oapi.Hide {
+		return enum.PermReportReview
+	}
+	switch targetType {
+	case model.ReportTargetType_Question:
+		return enum.PermQuestionDelete
+	case model.ReportTargetType_Poll:
+		return enum.PermPollDelete
+	default:
+		return enum.PermCommentModerate
+	}
+}
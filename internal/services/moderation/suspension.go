@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+	report_repo "genshin-quiz/internal/repository/report"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// SuspendUser 封禁用户，可以指定截止时间，封禁期间无法登录和访问.
+func SuspendUser(
+	ctx context.Context,
+	app *config.App,
+	req oapi.SuspendUserRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	reason := strings.TrimSpace(req.Body.Reason)
+	if reason == "" {
+		return common.NewBadRequestError("封禁时必须填写原因")
+	}
+	user, err := user_repo.GetUserInfoByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return err
+	}
+	if user.ID == userClaims.UserID {
+		return common.NewBadRequestError("不能封禁自己")
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	err = suspendUser(ctx, tx, user.ID, req.Body.Until, reason, now)
+	if err != nil {
+		return err
+	}
+	err = report_repo.InsertModerationLog(ctx, tx, model.ModerationLogs{
+		ModeratorID: &userClaims.UserID,
+		Action:      model.ModerationAction_Suspend,
+		TargetType:  model.ReportTargetType_User,
+		TargetID:    user.ID,
+		Note:        &reason,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.UserContentTags...)
+	return nil
+}
+
+// UnsuspendUser 提前解除封禁.
+func UnsuspendUser(
+	ctx context.Context,
+	app *config.App,
+	req oapi.UnsuspendUserRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	user, err := user_repo.GetUserInfoByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return err
+	}
+	if enum.UserStatus(user.Status) != enum.UserStatusSuspended {
+		return common.NewBadRequestError("用户未被封禁")
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = user_repo.LiftUserSuspension(ctx, tx, user.ID)
+	if err != nil {
+		return err
+	}
+	err = report_repo.InsertModerationLog(ctx, tx, model.ModerationLogs{
+		ModeratorID: &userClaims.UserID,
+		Action:      model.ModerationAction_Unsuspend,
+		TargetType:  model.ReportTargetType_User,
+		TargetID:    user.ID,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.UserContentTags...)
+	return nil
+}
+
+// suspendUser 不能封禁管理员和版主，截止时间必须晚于当前时间.
+func suspendUser(
+	ctx context.Context,
+	db qrm.DB,
+	userID int64,
+	until *time.Time,
+	reason string,
+	now time.Time,
+) error {
+	if until != nil && !until.After(now) {
+		return common.NewBadRequestError("封禁截止时间必须晚于当前时间")
+	}
+	user, err := user_repo.GetUserInfoByID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	if util.IsModerator(enum.UserRole(user.UserRole)) {
+		return common.NewBadRequestError("不能封禁管理员或版主")
+	}
+	if enum.UserStatus(user.Status) == enum.UserStatusDeleted {
+		return common.ErrUserNotFound
+	}
+	return user_repo.SuspendUser(ctx, db, user.ID, until, reason)
+}
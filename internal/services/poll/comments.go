@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	"genshin-quiz/internal/enum"
+	poll_repo "genshin-quiz/internal/repository/poll"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/google/uuid"
+)
+
+// GetPollComments 获取投票评论.
+// tree 模式按楼层分页并附带全部回复，flat 模式按时间顺序平铺分页，回复通过 parent_id 关联.
+func GetPollComments(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetPollCommentsRequestObject,
+) (*oapi.CommentList, error) {
+	poll, err := poll_repo.GetPollByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	page := 1
+	if req.Params.Page != nil && *req.Params.Page > 0 {
+		page = *req.Params.Page
+	}
+	limit := 20
+	if req.Params.Limit != nil && *req.Params.Limit > 0 {
+		limit = *req.Params.Limit
+	}
+	rootsOnly := req.Params.Mode == nil || *req.Params.Mode == oapi.Tree
+
+	pollID := poll.Poll.ID
+	rows, err := poll_repo.GetPollComments(ctx, app.DB, pollID, rootsOnly, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	total, err := poll_repo.GetPollCommentCount(ctx, app.DB, pollID, rootsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	if rootsOnly {
+		rootIDs := make([]int64, 0, len(rows))
+		for _, r := range rows {
+			rootIDs = append(rootIDs, r.Comment.ID)
+		}
+		replies, err := poll_repo.GetPollCommentReplies(ctx, app.DB, rootIDs)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, replies...)
+	}
+
+	parentUUIDs, err := getPollCommentParentUUIDs(ctx, app, rows)
+	if err != nil {
+		return nil, err
+	}
+	reactions, err := getPollCommentReactions(ctx, app, rows)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]oapi.Comment, 0, len(rows))
+	for _, r := range rows {
+		var parentUUID *uuid.UUID
+		if r.Comment.ParentID != nil {
+			if parent, exists := parentUUIDs[*r.Comment.ParentID]; exists {
+				parentUUID = &parent
+			}
+		}
+		dtos = append(dtos, transformer.ToPollComment(r, parentUUID, reactions[r.Comment.ID]))
+	}
+
+	comments := dtos
+	if rootsOnly {
+		comments = transformer.BuildCommentTree(dtos)
+	}
+	return &oapi.CommentList{
+		Comments: comments,
+		Total:    int(total),
+	}, nil
+}
+
+// PostPollComment 发表投票评论，指定 parent_id 时作为回复挂在同一楼层下.
+func PostPollComment(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostPollCommentRequestObject,
+) (*oapi.Comment, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	poll, err := poll_repo.GetPollByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	content, err := util.ValidateCommentContent(req.Body.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	comment := model.PollComments{
+		CommentUUID: uuid.New(),
+		PollID:      poll.Poll.ID,
+		UserID:      &userClaims.UserID,
+		Content:     content,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	var parentUUID *uuid.UUID
+	if req.Body.ParentId != nil {
+		parent, err := poll_repo.GetPollCommentByUUID(ctx, app.DB, poll.Poll.ID, *req.Body.ParentId)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Comment.DeletedAt != nil {
+			return nil, common.NewBadRequestError("不能回复已删除的评论")
+		}
+		comment.ParentID = &parent.Comment.ID
+		comment.RootID = parent.Comment.RootID
+		if comment.RootID == nil {
+			comment.RootID = &parent.Comment.ID
+		}
+		parentUUID = &parent.Comment.CommentUUID
+	}
+
+	inserted, err := poll_repo.InsertPollComment(ctx, app.DB, comment)
+	if err != nil {
+		return nil, err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagPolls)
+	author, err := user_repo.GetUserInfoByID(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := transformer.ToPollComment(dao.PollComment{Comment: *inserted, User: author}, parentUUID, nil)
+	return &dto, nil
+}
+
+// UpdatePollComment 仅评论作者可以编辑，已删除的评论不能编辑.
+func UpdatePollComment(
+	ctx context.Context,
+	app *config.App,
+	req oapi.UpdatePollCommentRequestObject,
+) (*oapi.Comment, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	poll, err := poll_repo.GetPollByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := poll_repo.GetPollCommentByUUID(ctx, app.DB, poll.Poll.ID, req.CommentId)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Comment.DeletedAt != nil {
+		return nil, common.ErrCommentNotFound
+	}
+	if comment.Comment.UserID == nil || *comment.Comment.UserID != userClaims.UserID {
+		return nil, common.ErrPermissionDenied
+	}
+
+	content, err := util.ValidateCommentContent(req.Body.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := poll_repo.UpdatePollComment(ctx, app.DB, comment.Comment.ID, content, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	row := dao.PollComment{Comment: *updated, User: comment.User}
+	parentUUIDs, err := getPollCommentParentUUIDs(ctx, app, []dao.PollComment{row})
+	if err != nil {
+		return nil, err
+	}
+	var parentUUID *uuid.UUID
+	if updated.ParentID != nil {
+		if parent, exists := parentUUIDs[*updated.ParentID]; exists {
+			parentUUID = &parent
+		}
+	}
+
+	reactions, err := getPollCommentReactions(ctx, app, []dao.PollComment{row})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := transformer.ToPollComment(row, parentUUID, reactions[updated.ID])
+	return &dto, nil
+}
+
+// DeletePollComment 评论作者或版主可以删除，回复保留并显示上级为 [deleted].
+func DeletePollComment(
+	ctx context.Context,
+	app *config.App,
+	req oapi.DeletePollCommentRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	poll, err := poll_repo.GetPollByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return err
+	}
+	comment, err := poll_repo.GetPollCommentByUUID(ctx, app.DB, poll.Poll.ID, req.CommentId)
+	if err != nil {
+		return err
+	}
+	if comment.Comment.DeletedAt != nil {
+		return common.ErrCommentNotFound
+	}
+	// 作者已注销的评论只有管理人员可以删除
+	ownerID := int64(0)
+	if comment.Comment.UserID != nil {
+		ownerID = *comment.Comment.UserID
+	}
+	err = middleware.RequireOwnerOrPermission(ctx, ownerID, enum.PermCommentModerate)
+	if err != nil {
+		return err
+	}
+
+	err = poll_repo.DeletePollComment(ctx, app.DB, comment.Comment.ID, userClaims.UserID, time.Now())
+	if err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagPolls)
+	return nil
+}
+
+// AddPollCommentReaction 对评论添加表情回应，已删除的评论不能回应.
+func AddPollCommentReaction(
+	ctx context.Context,
+	app *config.App,
+	req oapi.AddPollCommentReactionRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+	if !req.Reaction.Valid() {
+		return common.NewBadRequestError("不支持的表情")
+	}
+
+	comment, err := getReactablePollComment(ctx, app, req.Id, req.CommentId)
+	if err != nil {
+		return err
+	}
+
+	return poll_repo.InsertPollCommentReaction(ctx, app.DB, model.PollCommentReactions{
+		CommentID: comment.ID,
+		UserID:    userClaims.UserID,
+		Reaction:  string(req.Reaction),
+		CreatedAt: time.Now(),
+	})
+}
+
+// RemovePollCommentReaction 取消表情回应，未回应过时忽略.
+func RemovePollCommentReaction(
+	ctx context.Context,
+	app *config.App,
+	req oapi.RemovePollCommentReactionRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	comment, err := getReactablePollComment(ctx, app, req.Id, req.CommentId)
+	if err != nil {
+		return err
+	}
+
+	return poll_repo.DeletePollCommentReaction(ctx, app.DB, comment.ID, userClaims.UserID, string(req.Reaction))
+}
+
+// getReactablePollComment 获取可以回应的评论，已删除的评论视为不存在.
+func getReactablePollComment(
+	ctx context.Context,
+	app *config.App,
+	pollUUID uuid.UUID,
+	commentUUID uuid.UUID,
+) (*model.PollComments, error) {
+	poll, err := poll_repo.GetPollByUUID(ctx, app.DB, pollUUID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := poll_repo.GetPollCommentByUUID(ctx, app.DB, poll.Poll.ID, commentUUID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Comment.DeletedAt != nil {
+		return nil, common.ErrCommentNotFound
+	}
+	return &comment.Comment, nil
+}
+
+// getPollCommentReactions 按评论分组查询表情回应，未登录时不标记已回应.
+func getPollCommentReactions(
+	ctx context.Context,
+	app *config.App,
+	rows []dao.PollComment,
+) (map[int64][]dao.PollCommentReaction, error) {
+	commentIDs := make([]int64, 0, len(rows))
+	for _, r := range rows {
+		if r.Comment.DeletedAt == nil {
+			commentIDs = append(commentIDs, r.Comment.ID)
+		}
+	}
+	viewerID := int64(0)
+	if userClaims, ok := middleware.GetUserFromContextOnly(ctx); ok {
+		viewerID = userClaims.UserID
+	}
+
+	reactions, err := poll_repo.GetPollCommentReactions(ctx, app.DB, commentIDs, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	reactionMap := make(map[int64][]dao.PollCommentReaction, len(commentIDs))
+	for _, r := range reactions {
+		reactionMap[r.CommentID] = append(reactionMap[r.CommentID], r)
+	}
+	return reactionMap, nil
+}
+
+// getPollCommentParentUUIDs 查询上级评论的 UUID，平铺模式下上级评论可能不在当前页.
+func getPollCommentParentUUIDs(
+	ctx context.Context,
+	app *config.App,
+	rows []dao.PollComment,
+) (map[int64]uuid.UUID, error) {
+	uuidMap := make(map[int64]uuid.UUID, len(rows))
+	for _, r := range rows {
+		uuidMap[r.Comment.ID] = r.Comment.CommentUUID
+	}
+
+	missing := make([]int64, 0)
+	for _, r := range rows {
+		if r.Comment.ParentID == nil {
+			continue
+		}
+		if _, exists := uuidMap[*r.Comment.ParentID]; !exists {
+			missing = append(missing, *r.Comment.ParentID)
+		}
+	}
+	parents, err := poll_repo.GetPollCommentsByIDs(ctx, app.DB, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range parents {
+		uuidMap[p.ID] = p.CommentUUID
+	}
+	return uuidMap, nil
+}
@@ -112,6 +112,12 @@ func GetPoll(
 
 	// 转换为 DTO
 	dto := transformer.ConvertDetailedVoteToDTO(detailedPoll, polldOptions, likeStatus)
+	commentsMap, err := poll_repo.GetMultiplePollsCommentsCount(ctx, app.DB, []int64{pollDBId})
+	if err != nil {
+		return nil, err
+	}
+	commentsCount := int(commentsMap[pollDBId])
+	dto.CommentsCount = &commentsCount
 
 	response := oapi.GetPoll200JSONResponse(dto)
 	return &response, nil
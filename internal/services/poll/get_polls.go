@@ -2,17 +2,39 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/dao"
 	poll_repo "genshin-quiz/internal/repository/poll"
 )
 
+const pollListCacheTTL = 30 * time.Second
+
+// GetPolls 投票列表，按筛选条件和访问者缓存，投票变更时失效.
 func GetPolls(
 	ctx context.Context,
 	app *config.App,
 	req oapi.GetPollsRequestObject,
+) (*oapi.GetPolls200JSONResponse, error) {
+	return cache.GetOrLoad(
+		ctx,
+		app.Redis,
+		cache.Key(ctx, "polls", req.Params),
+		[]string{cache.TagPolls},
+		pollListCacheTTL,
+		func(ctx context.Context) (*oapi.GetPolls200JSONResponse, error) {
+			return loadPolls(ctx, app, req)
+		},
+	)
+}
+
+func loadPolls(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetPollsRequestObject,
 ) (*oapi.GetPolls200JSONResponse, error) {
 	// 设置默认值
 	page := 1
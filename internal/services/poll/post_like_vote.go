@@ -8,6 +8,7 @@ import (
 	"genshin-quiz/config"
 	"genshin-quiz/generated/db/genshinquiz/public/table"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/common"
 	poll_repo "genshin-quiz/internal/repository/poll"
 	"genshin-quiz/internal/webserver/middleware"
@@ -64,6 +65,7 @@ func PostLikePoll(
 	if err := tx.Commit(); err != nil {
 		return err
 	}
+	cache.Invalidate(ctx, app.Redis, cache.TagPolls)
 
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"genshin-quiz/config"
 	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/common"
 	poll_repo "genshin-quiz/internal/repository/poll"
 	"genshin-quiz/internal/webserver/middleware"
@@ -63,6 +64,7 @@ func PostCreatePoll(
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
+	cache.Invalidate(ctx, app.Redis, cache.TagPolls)
 
 	// Convert to API response format
 	response := oapi.PostCreatePoll201JSONResponse{
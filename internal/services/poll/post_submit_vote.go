@@ -8,7 +8,9 @@ import (
 	"genshin-quiz/config"
 	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/metrics"
 	poll_repo "genshin-quiz/internal/repository/poll"
 	"genshin-quiz/internal/webserver/middleware"
 
@@ -154,6 +156,8 @@ func saveUserVotes(
 	if err := tx.Commit(); err != nil {
 		return err
 	}
+	cache.Invalidate(ctx, app.Redis, cache.TagPolls)
+	metrics.RecordVote()
 
 	return nil
 }
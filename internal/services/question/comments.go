@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	"genshin-quiz/internal/enum"
+	question_repo "genshin-quiz/internal/repository/question"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/google/uuid"
+)
+
+// GetQuestionComments 按楼层分页，每页楼层附带全部回复.
+// 标记为包含答案的评论，对未解答该题的用户隐藏内容.
+func GetQuestionComments(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetQuestionCommentsRequestObject,
+) (*oapi.CommentList, error) {
+	question, err := getVisibleQuestion(ctx, app, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	page := 1
+	if req.Params.Page != nil && *req.Params.Page > 0 {
+		page = *req.Params.Page
+	}
+	limit := 20
+	if req.Params.Limit != nil && *req.Params.Limit > 0 {
+		limit = *req.Params.Limit
+	}
+
+	questionID := question.Question.ID
+	roots, err := question_repo.GetQuestionComments(ctx, app.DB, questionID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	total, err := question_repo.GetQuestionCommentCount(ctx, app.DB, questionID)
+	if err != nil {
+		return nil, err
+	}
+	rootIDs := make([]int64, 0, len(roots))
+	for _, r := range roots {
+		rootIDs = append(rootIDs, r.Comment.ID)
+	}
+	replies, err := question_repo.GetQuestionCommentReplies(ctx, app.DB, rootIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	canSeeSpoilers, err := canSeeQuestionSpoilers(ctx, app, question.Question)
+	if err != nil {
+		return nil, err
+	}
+	viewerID := int64(0)
+	if userClaims, ok := middleware.GetUserFromContextOnly(ctx); ok {
+		viewerID = userClaims.UserID
+	}
+
+	rows := make([]dao.QuestionComment, 0, len(roots)+len(replies))
+	rows = append(rows, roots...)
+	rows = append(rows, replies...)
+	uuidMap := make(map[int64]uuid.UUID, len(rows))
+	for _, r := range rows {
+		uuidMap[r.Comment.ID] = r.Comment.CommentUUID
+	}
+	dtos := make([]oapi.Comment, 0, len(rows))
+	for _, r := range rows {
+		var parentUUID *uuid.UUID
+		if r.Comment.ParentID != nil {
+			if parent, exists := uuidMap[*r.Comment.ParentID]; exists {
+				parentUUID = &parent
+			}
+		}
+		// 评论者本人总能看到自己的评论
+		hidden := r.Comment.IsSpoiler && !canSeeSpoilers && r.Comment.UserID != viewerID
+		dtos = append(dtos, transformer.ToQuestionComment(r, parentUUID, hidden))
+	}
+
+	return &oapi.CommentList{
+		Comments: transformer.BuildCommentTree(dtos),
+		Total:    int(total),
+	}, nil
+}
+
+// PostQuestionComment 发表评论，指定 parent_id 时作为回复挂在同一楼层下.
+func PostQuestionComment(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostQuestionCommentRequestObject,
+) (*oapi.Comment, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	question, err := getVisibleQuestion(ctx, app, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	content, err := util.ValidateCommentContent(req.Body.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	comment := model.QuestionComments{
+		QuestionID:  question.Question.ID,
+		UserID:      userClaims.UserID,
+		Comment:     content,
+		CommentUUID: uuid.New(),
+		IsSpoiler:   req.Body.Spoiler != nil && *req.Body.Spoiler,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	var parentUUID *uuid.UUID
+	if req.Body.ParentId != nil {
+		parent, err := question_repo.GetQuestionCommentByUUID(
+			ctx,
+			app.DB,
+			question.Question.ID,
+			*req.Body.ParentId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Comment.DeletedAt != nil {
+			return nil, common.NewBadRequestError("不能回复已删除的评论")
+		}
+		comment.ParentID = &parent.Comment.ID
+		comment.RootID = parent.Comment.RootID
+		if comment.RootID == nil {
+			comment.RootID = &parent.Comment.ID
+		}
+		parentUUID = &parent.Comment.CommentUUID
+	}
+
+	inserted, err := question_repo.InsertQuestionComment(ctx, app.DB, comment)
+	if err != nil {
+		return nil, err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions)
+	author, err := user_repo.GetUserInfoByID(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := transformer.ToQuestionComment(
+		dao.QuestionComment{Comment: *inserted, User: *author},
+		parentUUID,
+		false,
+	)
+	return &dto, nil
+}
+
+// UpdateQuestionComment 仅评论作者可以编辑，已删除的评论不能编辑.
+func UpdateQuestionComment(
+	ctx context.Context,
+	app *config.App,
+	req oapi.UpdateQuestionCommentRequestObject,
+) (*oapi.Comment, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	question, err := getVisibleQuestion(ctx, app, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := question_repo.GetQuestionCommentByUUID(
+		ctx,
+		app.DB,
+		question.Question.ID,
+		req.CommentId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Comment.DeletedAt != nil {
+		return nil, common.ErrCommentNotFound
+	}
+	if comment.Comment.UserID != userClaims.UserID {
+		return nil, common.ErrPermissionDenied
+	}
+
+	content, err := util.ValidateCommentContent(req.Body.Content)
+	if err != nil {
+		return nil, err
+	}
+	isSpoiler := comment.Comment.IsSpoiler
+	if req.Body.Spoiler != nil {
+		isSpoiler = *req.Body.Spoiler
+	}
+
+	updated, err := question_repo.UpdateQuestionComment(
+		ctx,
+		app.DB,
+		comment.Comment.ID,
+		content,
+		isSpoiler,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentUUID *uuid.UUID
+	if updated.ParentID != nil {
+		parent, err := question_repo.GetQuestionCommentByID(ctx, app.DB, *updated.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		parentUUID = &parent.CommentUUID
+	}
+
+	dto := transformer.ToQuestionComment(
+		dao.QuestionComment{Comment: *updated, User: comment.User},
+		parentUUID,
+		false,
+	)
+	return &dto, nil
+}
+
+// DeleteQuestionComment 评论作者或版主可以删除，有回复的楼层保留为已删除.
+func DeleteQuestionComment(
+	ctx context.Context,
+	app *config.App,
+	req oapi.DeleteQuestionCommentRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	question, err := getVisibleQuestion(ctx, app, req.Id)
+	if err != nil {
+		return err
+	}
+	comment, err := question_repo.GetQuestionCommentByUUID(
+		ctx,
+		app.DB,
+		question.Question.ID,
+		req.CommentId,
+	)
+	if err != nil {
+		return err
+	}
+	if comment.Comment.DeletedAt != nil {
+		return common.ErrCommentNotFound
+	}
+	err = middleware.RequireOwnerOrPermission(ctx, comment.Comment.UserID, enum.PermCommentModerate)
+	if err != nil {
+		return err
+	}
+
+	err = question_repo.DeleteQuestionComment(
+		ctx,
+		app.DB,
+		comment.Comment.ID,
+		userClaims.UserID,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions)
+	return nil
+}
+
+// getVisibleQuestion 获取当前用户可见的题目，不可见时视为不存在.
+func getVisibleQuestion(
+	ctx context.Context,
+	app *config.App,
+	questionUUID uuid.UUID,
+) (*dao.SimpleQuestion, error) {
+	question, err := question_repo.GetQuestionByUUID(ctx, app.DB, questionUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewQuestion(ctx, question.Question) {
+		return nil, common.ErrQuestionNotFound
+	}
+	return question, nil
+}
+
+// canSeeQuestionSpoilers 已解答的用户、题目作者和版主可以看到包含答案的评论.
+func canSeeQuestionSpoilers(
+	ctx context.Context,
+	app *config.App,
+	question model.Questions,
+) (bool, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return false, nil
+	}
+	if question.CreatedBy == userClaims.UserID {
+		return true, nil
+	}
+	solved, err := question_repo.CheckQuestionSolved(ctx, app.DB, userClaims.UserID, question.ID)
+	if err != nil {
+		return false, err
+	}
+	if solved {
+		return true, nil
+	}
+	return middleware.HasPermission(ctx, enum.PermCommentModerate), nil
+}
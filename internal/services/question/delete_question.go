@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+	exam_repo "genshin-quiz/internal/repository/exam"
+	question_repo "genshin-quiz/internal/repository/question"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// DeleteQuestion 软删除题目，作者或管理员可操作.
+// 题目从列表、首页和题库中消失，已有测验引用和提交记录保留.
+func DeleteQuestion(
+	ctx context.Context,
+	app *config.App,
+	req oapi.DeleteQuestionRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	question, err := question_repo.GetQuestionByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return err
+	}
+	err = middleware.RequireOwnerOrPermission(ctx, question.Question.CreatedBy, enum.PermQuestionDelete)
+	if err != nil {
+		return err
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = SoftDeleteQuestions(
+		ctx,
+		tx,
+		question.Question.CreatedBy,
+		[]int64{question.Question.ID},
+		userClaims.UserID,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions, cache.TagExams)
+	return nil
+}
+
+// RestoreQuestion 恢复已删除的题目.
+// 管理员可以恢复任意题目，作者只能恢复自己删除的题目.
+func RestoreQuestion(
+	ctx context.Context,
+	app *config.App,
+	req oapi.RestoreQuestionRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	question, err := question_repo.GetQuestionByUUIDWithDeleted(ctx, app.DB, req.Id)
+	if err != nil {
+		return err
+	}
+	if question.Question.DeletedAt == nil {
+		return common.NewBadRequestError("题目未被删除")
+	}
+
+	deletedBySelf := question.Question.DeletedBy != nil &&
+		*question.Question.DeletedBy == userClaims.UserID
+	if question.Question.CreatedBy != userClaims.UserID || !deletedBySelf {
+		err = middleware.RequirePermission(ctx, enum.PermQuestionDelete)
+		if err != nil {
+			return err
+		}
+	}
+
+	examIDs, err := exam_repo.GetExamIDsByQuestion(ctx, app.DB, question.Question.ID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = question_repo.RestoreQuestion(ctx, tx, question.Question.ID)
+	if err != nil {
+		return err
+	}
+	err = user_repo.UpdateUserQuestionsCreated(ctx, tx, question.Question.CreatedBy, 1)
+	if err != nil {
+		return err
+	}
+	err = syncQuestionExams(ctx, tx, examIDs, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions, cache.TagExams)
+	return nil
+}
+
+// PurgeQuestion 彻底删除题目，仅管理员可操作.
+// 测验中的题目和作答记录随之删除，作答总分、用户答题统计和受影响测验的统计在同一事务内重新计算.
+func PurgeQuestion(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PurgeQuestionRequestObject,
+) error {
+	question, err := question_repo.GetQuestionByUUIDWithDeleted(ctx, app.DB, req.Id)
+	if err != nil {
+		return err
+	}
+
+	examIDs, err := exam_repo.GetExamIDsByQuestion(ctx, app.DB, question.Question.ID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 作答和提交记录随题目级联删除，先扣除作答中该题的分数并记下需要重新统计的用户
+	attemptExamIDs, err := exam_repo.DeductQuestionFromAttempts(ctx, tx, question.Question.ID)
+	if err != nil {
+		return err
+	}
+	submitterIDs, err := question_repo.GetQuestionSubmitterIDs(ctx, tx, question.Question.ID)
+	if err != nil {
+		return err
+	}
+
+	err = question_repo.PurgeQuestion(ctx, tx, question.Question.ID)
+	if err != nil {
+		return err
+	}
+	// 已软删除的题目不再计入，无需重复扣减
+	if question.Question.DeletedAt == nil {
+		err = user_repo.UpdateUserQuestionsCreated(ctx, tx, question.Question.CreatedBy, -1)
+		if err != nil {
+			return err
+		}
+	}
+	for _, userID := range submitterIDs {
+		err = user_repo.RecalculateUserStats(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+	}
+	for _, examID := range attemptExamIDs {
+		if !slices.Contains(examIDs, examID) {
+			examIDs = append(examIDs, examID)
+		}
+	}
+	err = syncQuestionExams(ctx, tx, examIDs, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions, cache.TagExams, cache.TagLeaderboard)
+	return nil
+}
+
+// SoftDeleteQuestions 软删除同一作者的题目，同步作者题目数和所在测验，需在事务内调用.
+// 删除、举报隐藏和注销账号共用，已删除的题目跳过，不重复扣减.
+func SoftDeleteQuestions(
+	ctx context.Context,
+	db qrm.DB,
+	authorID int64,
+	questionIDs []int64,
+	deletedBy int64,
+	now time.Time,
+) error {
+	deletedIDs, err := question_repo.SoftDeleteQuestions(ctx, db, questionIDs, deletedBy, now)
+	if err != nil {
+		return err
+	}
+	if len(deletedIDs) == 0 {
+		return nil
+	}
+
+	err = user_repo.UpdateUserQuestionsCreated(ctx, db, authorID, -int64(len(deletedIDs)))
+	if err != nil {
+		return err
+	}
+	examIDs, err := exam_repo.GetExamIDsByQuestions(ctx, db, deletedIDs)
+	if err != nil {
+		return err
+	}
+	return syncQuestionExams(ctx, db, examIDs, now)
+}
+
+// syncQuestionExams 题目删除、恢复或彻底删除后，更新所在测验的修改时间并重新统计.
+func syncQuestionExams(
+	ctx context.Context,
+	db qrm.DB,
+	examIDs []int64,
+	now time.Time,
+) error {
+	err := exam_repo.TouchExams(ctx, db, examIDs, now)
+	if err != nil {
+		return err
+	}
+	for _, examID := range examIDs {
+		err = exam_repo.RecalculateExamStats(ctx, db, examID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
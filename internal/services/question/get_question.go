@@ -5,6 +5,7 @@ import (
 
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
 	dao "genshin-quiz/internal/dao"
 	"genshin-quiz/internal/dao/transformer"
 	question_repo "genshin-quiz/internal/repository/question"
@@ -22,6 +23,9 @@ func GetQuestion(
 	if err != nil {
 		return nil, err
 	}
+	if !canViewQuestion(ctx, res.Question) {
+		return nil, common.ErrQuestionNotFound
+	}
 	questionDBId := res.Question.ID
 	// 获取翻译
 	trans, err := question_repo.GetQuestionTransByID(ctx, app.DB, questionDBId)
@@ -93,6 +97,16 @@ func GetQuestion(
 	}
 
 	dto := transformer.ConvertDetailToQuestion(detailedQuestion, solved, likeStatus)
+	commentsMap, err := question_repo.GetMultipleQuestionsCommentsCount(
+		ctx,
+		app.DB,
+		[]int64{questionDBId},
+	)
+	if err != nil {
+		return nil, err
+	}
+	commentsCount := int(commentsMap[questionDBId])
+	dto.CommentsCount = &commentsCount
 
 	return &dto, nil
 }
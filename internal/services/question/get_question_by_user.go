@@ -8,6 +8,7 @@ import (
 	dao "genshin-quiz/internal/dao"
 	question_repo "genshin-quiz/internal/repository/question"
 	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/webserver/middleware"
 )
 
 func GetQuestionsByUser(
@@ -36,6 +37,12 @@ func GetQuestionsByUser(
 		NumPerPage: limit,
 		Author:     &userInfo.ID,
 	}
+	// 作者本人可以看到草稿和审核中的题目，其他人只能看到已发布的
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok || userClaims.UserID != userInfo.ID {
+		isPublished := true
+		param.IsPublished = &isPublished
+	}
 	result, err := question_repo.GetQuestions(ctx, app.DB, param)
 	if err != nil {
 		return nil, err
@@ -41,8 +41,14 @@ func GetQuestionMySubmissions(
 		if submission.TimeTaken != nil {
 			timeSpent = int(*submission.TimeTaken)
 		}
+		var revision *int
+		if submission.Revision != nil {
+			r := int(*submission.Revision)
+			revision = &r
+		}
 		dto := oapi.QuestionSubmission{
 			IsCorrect:         submission.IsCorrect,
+			Revision:          revision,
 			SelectedOptionIds: (*submissionMap)[submission.ID],
 			SubmittedAt:       submission.CreatedAt,
 			TimeSpent:         timeSpent,
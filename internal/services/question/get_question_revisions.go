@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	question_repo "genshin-quiz/internal/repository/question"
+
+	"github.com/google/uuid"
+)
+
+// GetQuestionRevisions 修订历史包含正确答案，仅作者和管理员可见.
+func GetQuestionRevisions(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetQuestionRevisionsRequestObject,
+) ([]oapi.QuestionRevision, error) {
+	question, err := getAuthoredQuestion(ctx, app, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := question_repo.GetQuestionRevisions(ctx, app.DB, question.Question.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]oapi.QuestionRevision, 0, len(revisions))
+	for _, r := range revisions {
+		dtos = append(dtos, oapi.QuestionRevision{
+			Revision:  int(r.Revision.Revision),
+			CreatedBy: r.Author.UserUUID,
+			CreatedAt: r.Revision.CreatedAt,
+			Current:   r.Revision.Revision == question.Question.CurrentRevision,
+			Question:  r.Snapshot,
+		})
+	}
+
+	return dtos, nil
+}
+
+func GetQuestionRevisionDiff(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetQuestionRevisionDiffRequestObject,
+) (*oapi.QuestionRevisionDiff, error) {
+	if req.Params.From <= 0 || req.Params.To <= 0 {
+		return nil, common.NewBadRequestError("修订版本号无效")
+	}
+
+	question, err := getAuthoredQuestion(ctx, app, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := question_repo.GetQuestionRevision(
+		ctx,
+		app.DB,
+		question.Question.ID,
+		int32(req.Params.From),
+	)
+	if err != nil {
+		return nil, err
+	}
+	to, err := question_repo.GetQuestionRevision(
+		ctx,
+		app.DB,
+		question.Question.ID,
+		int32(req.Params.To),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.QuestionRevisionDiff{
+		From:    req.Params.From,
+		To:      req.Params.To,
+		Changes: diffQuestionSnapshots(from.Snapshot, to.Snapshot),
+	}, nil
+}
+
+// diffQuestionSnapshots 逐字段对比两个快照，文本按语言逐条输出.
+func diffQuestionSnapshots(from, to oapi.CreateQuestionRequest) []oapi.QuestionRevisionChange {
+	changes := make([]oapi.QuestionRevisionChange, 0)
+
+	addChange := func(field string, before, after *string) {
+		changes = append(changes, oapi.QuestionRevisionChange{
+			Field: field,
+			From:  before,
+			To:    after,
+		})
+	}
+	if from.QuestionType != to.QuestionType {
+		addChange("question_type", strPtr(string(from.QuestionType)), strPtr(string(to.QuestionType)))
+	}
+	if from.Category != to.Category {
+		addChange("category", strPtr(string(from.Category)), strPtr(string(to.Category)))
+	}
+	if from.Difficulty != to.Difficulty {
+		addChange("difficulty", strPtr(string(from.Difficulty)), strPtr(string(to.Difficulty)))
+	}
+	if from.Public != to.Public {
+		addChange("public", strPtr(strconv.FormatBool(from.Public)), strPtr(strconv.FormatBool(to.Public)))
+	}
+
+	changes = append(
+		changes,
+		diffLocalizedText("question_text", nil, &from.QuestionText, &to.QuestionText)...,
+	)
+	changes = append(
+		changes,
+		diffLocalizedText("explanation", nil, from.Explanation, to.Explanation)...,
+	)
+
+	changes = append(changes, diffSnapshotOptions(from.Options, to.Options)...)
+
+	return changes
+}
+
+// diffSnapshotOptions 选项按选项 ID 对应，先输出新增和修改，再输出移除.
+func diffSnapshotOptions(from, to []oapi.CreateQuestionOptionRequest) []oapi.QuestionRevisionChange {
+	changes := make([]oapi.QuestionRevisionChange, 0)
+
+	fromOptions := make(map[uuid.UUID]oapi.CreateQuestionOptionRequest, len(from))
+	for _, opt := range from {
+		if opt.Id != nil {
+			fromOptions[*opt.Id] = opt
+		}
+	}
+	toOptions := make(map[uuid.UUID]bool, len(to))
+	for _, opt := range to {
+		if opt.Id == nil {
+			continue
+		}
+		toOptions[*opt.Id] = true
+
+		before, exists := fromOptions[*opt.Id]
+		if !exists {
+			changes = append(changes, oapi.QuestionRevisionChange{
+				Field:    "option_added",
+				OptionId: opt.Id,
+				To:       strPtr(strconv.FormatBool(opt.IsAnswer)),
+			})
+			changes = append(changes, diffLocalizedText("option_text", opt.Id, nil, opt.Text)...)
+			continue
+		}
+
+		optionChange := func(field string, b, a string) {
+			if b != a {
+				changes = append(changes, oapi.QuestionRevisionChange{
+					Field:    field,
+					OptionId: opt.Id,
+					From:     strPtr(b),
+					To:       strPtr(a),
+				})
+			}
+		}
+		optionChange("option_type", string(before.OptionType), string(opt.OptionType))
+		optionChange("option_media_url", derefOrEmpty(before.MediaUrl), derefOrEmpty(opt.MediaUrl))
+		optionChange(
+			"option_is_answer",
+			strconv.FormatBool(before.IsAnswer),
+			strconv.FormatBool(opt.IsAnswer),
+		)
+		changes = append(changes, diffLocalizedText("option_text", opt.Id, before.Text, opt.Text)...)
+	}
+	for _, opt := range from {
+		if opt.Id != nil && !toOptions[*opt.Id] {
+			changes = append(changes, oapi.QuestionRevisionChange{
+				Field:    "option_removed",
+				OptionId: opt.Id,
+				From:     strPtr(strconv.FormatBool(opt.IsAnswer)),
+			})
+		}
+	}
+
+	return changes
+}
+
+// diffLocalizedText 按语言对比多语言文本，语言按代码排序输出.
+func diffLocalizedText(
+	field string,
+	optionID *uuid.UUID,
+	from, to *oapi.LocalizedText,
+) []oapi.QuestionRevisionChange {
+	before := oapi.LocalizedText{}
+	if from != nil {
+		before = *from
+	}
+	after := oapi.LocalizedText{}
+	if to != nil {
+		after = *to
+	}
+
+	languages := make([]string, 0, len(before)+len(after))
+	for lang := range before {
+		languages = append(languages, lang)
+	}
+	for lang := range after {
+		if _, exists := before[lang]; !exists {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+
+	changes := make([]oapi.QuestionRevisionChange, 0)
+	for _, lang := range languages {
+		b, hasBefore := before[lang]
+		a, hasAfter := after[lang]
+		if hasBefore && hasAfter && a == b {
+			continue
+		}
+		change := oapi.QuestionRevisionChange{
+			Field:    field,
+			Language: strPtr(lang),
+			OptionId: optionID,
+		}
+		if hasBefore {
+			change.From = strPtr(b)
+		}
+		if hasAfter {
+			change.To = strPtr(a)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func derefOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
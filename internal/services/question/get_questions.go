@@ -2,17 +2,39 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	dao "genshin-quiz/internal/dao"
 	question_repo "genshin-quiz/internal/repository/question"
 )
 
+const questionListCacheTTL = 30 * time.Second
+
+// GetQuestions 已发布题目列表，按筛选条件和访问者缓存，题目变更时失效.
 func GetQuestions(
 	ctx context.Context,
 	app *config.App,
 	req oapi.GetQuestionsRequestObject,
+) (*oapi.GetQuestions200JSONResponse, error) {
+	return cache.GetOrLoad(
+		ctx,
+		app.Redis,
+		cache.Key(ctx, "questions", req.Params),
+		[]string{cache.TagQuestions},
+		questionListCacheTTL,
+		func(ctx context.Context) (*oapi.GetQuestions200JSONResponse, error) {
+			return loadQuestions(ctx, app, req)
+		},
+	)
+}
+
+func loadQuestions(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetQuestionsRequestObject,
 ) (*oapi.GetQuestions200JSONResponse, error) {
 	page := 1 // 修正：页码从1开始，不是0
 	if req.Params.Page != nil {
@@ -32,16 +54,18 @@ func GetQuestions(
 		sortDesc = *req.Params.SortDesc
 	}
 
-	// 调用 repository 层获取数据
+	// 列表只展示已发布的题目
+	isPublished := true
 	param := dao.QuestionListParams{
-		Page:       page,
-		NumPerPage: limit,
-		Category:   req.Params.Category,
-		Difficulty: req.Params.Difficulty,
-		Query:      req.Params.Query,
-		Language:   req.Params.Language,
-		SortBy:     sortBy,
-		SortDesc:   sortDesc,
+		Page:        page,
+		NumPerPage:  limit,
+		IsPublished: &isPublished,
+		Category:    req.Params.Category,
+		Difficulty:  req.Params.Difficulty,
+		Query:       req.Params.Query,
+		Language:    req.Params.Language,
+		SortBy:      sortBy,
+		SortDesc:    sortDesc,
 	}
 	result, err := question_repo.GetQuestions(ctx, app.DB, param)
 	if err != nil {
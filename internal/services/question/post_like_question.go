@@ -5,6 +5,7 @@ import (
 
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/common"
 	question_repo "genshin-quiz/internal/repository/question"
 	"genshin-quiz/internal/webserver/middleware"
@@ -51,6 +52,7 @@ func PostLikeQuestion(
 	if err := tx.Commit(); err != nil {
 		return err
 	}
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions)
 
 	return nil
 }
@@ -8,8 +8,10 @@ import (
 	"genshin-quiz/config"
 	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/common"
 	question_repo "genshin-quiz/internal/repository/question"
+	user_repo "genshin-quiz/internal/repository/user"
 	"genshin-quiz/internal/webserver/middleware"
 
 	"github.com/google/uuid"
@@ -41,35 +43,25 @@ func PostCreateQuestion(
 		QuestionType: model.QuestionType(req.Body.QuestionType),
 		Category:     model.Category(req.Body.Category),
 		Difficulty:   model.Difficulty(req.Body.Difficulty),
-		IsPublished:  true,
-		PublishedAt:  &now,
-		CreatedBy:    userClaims.UserID, // 使用从 JWT 获取的用户 ID
-		CreatedAt:    now,
+		// 新题目为草稿，审核通过后发布
+		Status:      model.QuestionStatus_Draft,
+		IsPublished: false,
+		CreatedBy:   userClaims.UserID, // 使用从 JWT 获取的用户 ID
+		CreatedAt:   now,
+		// 创建即为第 1 版
+		CurrentRevision: 1,
 	}
 	createdQuestion, err := question_repo.InsertQuestion(ctx, tx, insertModel)
 	if err != nil {
 		return nil, err
 	}
 	// 提问翻译
-	transModels := make([]model.QuestionTranslations, 0, len(req.Body.QuestionText))
-	for lang, text := range req.Body.QuestionText {
-		transModel := model.QuestionTranslations{
-			QuestionID:   createdQuestion.ID,
-			Language:     lang,
-			QuestionText: text,
-			CreatedAt:    now,
-			UpdatedAt:    now,
-		}
-
-		// 如果有对应语言的解释，添加到翻译记录中
-		if req.Body.Explanation != nil {
-			if explanation, exists := (*req.Body.Explanation)[lang]; exists {
-				transModel.Explanation = &explanation
-			}
-		}
-
-		transModels = append(transModels, transModel)
-	}
+	transModels := buildQuestionTranslations(
+		createdQuestion.ID,
+		req.Body.QuestionText,
+		req.Body.Explanation,
+		now,
+	)
 
 	// 批量插入翻译数据
 	err = question_repo.InsertQuestionTranslations(ctx, tx, transModels)
@@ -99,24 +91,14 @@ func PostCreateQuestion(
 	}
 
 	// 翻译生成数据
-	optionTransModels := make(
-		[]model.QuestionOptionTranslations,
-		0,
-		len(req.Body.Options)*len(req.Body.QuestionText),
-	)
+	optionTransModels := make([]model.QuestionOptionTranslations, 0)
 	for i, option := range *insertedOptions {
 		source := req.Body.Options[i]
 		// 为每个选项创建翻译记录
-		for lang, text := range *source.Text {
-			optionTransModel := model.QuestionOptionTranslations{
-				OptionID:   option.ID,
-				Language:   lang,
-				OptionText: text,
-				CreatedAt:  now,
-				UpdatedAt:  now,
-			}
-			optionTransModels = append(optionTransModels, optionTransModel)
-		}
+		optionTransModels = append(
+			optionTransModels,
+			buildOptionTranslations(option.ID, source.Text, now)...,
+		)
 	}
 	// 插入选项翻译
 	err = question_repo.InsertOptionTranslations(ctx, tx, optionTransModels)
@@ -124,21 +106,85 @@ func PostCreateQuestion(
 		return nil, err
 	}
 
+	// 实时更新用户统计信息
+	err = user_repo.UpdateUserQuestionsCreated(ctx, tx, userClaims.UserID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// 记录第 1 版快照
+	snapshot, err := question_repo.BuildQuestionSnapshot(ctx, tx, *createdQuestion)
+	if err != nil {
+		return nil, err
+	}
+	_, err = question_repo.InsertQuestionRevision(
+		ctx,
+		tx,
+		*createdQuestion,
+		*snapshot,
+		userClaims.UserID,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// 提交事务
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions)
+
+	return oapi.PostCreateQuestion201JSONResponse(*snapshot), nil
+}
 
-	// Convert to API response format
-	response := oapi.PostCreateQuestion201JSONResponse{
-		Category:     oapi.Category(createdQuestion.Category),
-		Difficulty:   oapi.Difficulty(createdQuestion.Difficulty),
-		QuestionType: oapi.QuestionType(createdQuestion.QuestionType),
-		QuestionText: req.Body.QuestionText,                // 直接使用请求中的翻译数据
-		Explanation:  req.Body.Explanation,                 // 直接使用请求中的解释数据
-		Options:      []oapi.CreateQuestionOptionRequest{}, // Add options
-		Public:       createdQuestion.Public,
+// buildQuestionTranslations 按语言生成题目翻译，解释为可选.
+func buildQuestionTranslations(
+	questionID int64,
+	questionText oapi.LocalizedText,
+	explanation *oapi.LocalizedText,
+	now time.Time,
+) []model.QuestionTranslations {
+	transModels := make([]model.QuestionTranslations, 0, len(questionText))
+	for lang, text := range questionText {
+		transModel := model.QuestionTranslations{
+			QuestionID:   questionID,
+			Language:     lang,
+			QuestionText: text,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+
+		// 如果有对应语言的解释，添加到翻译记录中
+		if explanation != nil {
+			if text, exists := (*explanation)[lang]; exists {
+				transModel.Explanation = &text
+			}
+		}
+
+		transModels = append(transModels, transModel)
 	}
+	return transModels
+}
 
-	return response, nil
+// buildOptionTranslations 按语言生成选项翻译.
+func buildOptionTranslations(
+	optionID int64,
+	texts *oapi.LocalizedText,
+	now time.Time,
+) []model.QuestionOptionTranslations {
+	if texts == nil {
+		return nil
+	}
+	transModels := make([]model.QuestionOptionTranslations, 0, len(*texts))
+	for lang, text := range *texts {
+		transModels = append(transModels, model.QuestionOptionTranslations{
+			OptionID:   optionID,
+			Language:   lang,
+			OptionText: text,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		})
+	}
+	return transModels
 }
@@ -7,7 +7,9 @@ import (
 	"genshin-quiz/config"
 	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/metrics"
 	question_repo "genshin-quiz/internal/repository/question"
 	user_repo "genshin-quiz/internal/repository/user"
 	"genshin-quiz/internal/webserver/middleware"
@@ -25,8 +27,17 @@ func PostSubmitAnswer(
 		return nil, common.ErrUserNotInContext
 	}
 
-	// 调用仓库层获取问题详情
-	questionID, err := question_repo.GetQuestionIDByUUID(ctx, app.DB, req.Id)
+	// 调用仓库层获取问题详情，未发布的题目不能作答
+	question, err := question_repo.GetQuestionByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !question.Question.IsPublished {
+		return nil, common.ErrQuestionNotFound
+	}
+	questionID := &question.Question.ID
+	// 记录作答时的修订版本，之后修改答案不影响历史判定
+	revisionID, err := question_repo.GetCurrentRevisionID(ctx, app.DB, *questionID)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +77,7 @@ func PostSubmitAnswer(
 	insertData := model.QuestionSubmissions{
 		SubmissionUUID: uuid.New(),
 		QuestionID:     *questionID,
+		RevisionID:     revisionID,
 		UserID:         userClaims.UserID,
 		TimeTaken:      &timeTaken,
 		IsPractice:     alreadySolved,
@@ -105,6 +117,9 @@ func PostSubmitAnswer(
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
+	metrics.RecordAnswer(correct)
+	// 只失效提交者自己的缓存，题目统计和排行榜等汇总数据由 TTL 刷新
+	cache.Invalidate(ctx, app.Redis, cache.UserTag(userClaims.UserID))
 
 	return &oapi.PostSubmitAnswer200JSONResponse{Correct: correct}, nil
 }
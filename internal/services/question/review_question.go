@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/enum"
+	question_repo "genshin-quiz/internal/repository/question"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"go.uber.org/zap"
+)
+
+// PostQuestionReviewRequest 作者将草稿或被驳回的题目提交审核.
+func PostQuestionReviewRequest(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostQuestionReviewRequestRequestObject,
+) (*oapi.QuestionReviewState, error) {
+	question, err := getAuthoredQuestion(ctx, app, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	status := question.Question.Status
+	if status != model.QuestionStatus_Draft && status != model.QuestionStatus_Rejected {
+		return nil, common.NewBadRequestError("只有草稿或被驳回的题目可以提交审核")
+	}
+
+	updated, err := question_repo.SubmitQuestionForReview(
+		ctx,
+		app.DB,
+		question.Question.ID,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return toQuestionReviewState(*updated), nil
+}
+
+// PostQuestionReview 版主审核题目.
+// 通过时未指定发布时间或时间已过则立即发布，否则等待定时任务发布；驳回必须填写原因.
+func PostQuestionReview(
+	ctx context.Context,
+	app *config.App,
+	req oapi.PostQuestionReviewRequestObject,
+) (*oapi.QuestionReviewState, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	question, err := question_repo.GetQuestionByUUID(ctx, app.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if question.Question.Status != model.QuestionStatus_PendingReview {
+		return nil, common.NewBadRequestError("题目不在待审核状态")
+	}
+
+	now := time.Now()
+	review := question.Question
+	review.ReviewedBy = &userClaims.UserID
+	review.ReviewedAt = &now
+	review.ReviewReason = nil
+
+	switch req.Body.Decision {
+	case oapi.Approve:
+		if req.Body.PublishAt != nil && req.Body.PublishAt.After(now) {
+			review.Status = model.QuestionStatus_Approved
+			review.IsPublished = false
+			review.PublishedAt = req.Body.PublishAt
+		} else {
+			review.Status = model.QuestionStatus_Published
+			review.IsPublished = true
+			review.PublishedAt = &now
+		}
+	case oapi.Reject:
+		if req.Body.Reason == nil || strings.TrimSpace(*req.Body.Reason) == "" {
+			return nil, common.NewBadRequestError("驳回时必须填写原因")
+		}
+		reason := strings.TrimSpace(*req.Body.Reason)
+		review.Status = model.QuestionStatus_Rejected
+		review.IsPublished = false
+		review.PublishedAt = nil
+		review.ReviewReason = &reason
+	default:
+		return nil, common.NewBadRequestError("审核结果无效")
+	}
+
+	updated, err := question_repo.UpdateQuestionReview(ctx, app.DB, review)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions)
+	notifyReviewDecision(ctx, app, *updated)
+
+	return toQuestionReviewState(*updated), nil
+}
+
+// GetQuestionReviewQueue 待审核题目，先提交的排在前面.
+func GetQuestionReviewQueue(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetQuestionReviewQueueRequestObject,
+) (*oapi.GetQuestionReviewQueue200JSONResponse, error) {
+	page := 1
+	if req.Params.Page != nil {
+		page = *req.Params.Page
+	}
+	limit := 25
+	if req.Params.Limit != nil {
+		limit = *req.Params.Limit
+	}
+
+	status := model.QuestionStatus_PendingReview
+	result, err := question_repo.GetQuestions(ctx, app.DB, dao.QuestionListParams{
+		Page:       page,
+		NumPerPage: limit,
+		Status:     &status,
+		SortBy:     "SubmittedAt",
+		SortDesc:   false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dtos, err := question_repo.BuildQuestionsWithTransaction(ctx, app.DB, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.GetQuestionReviewQueue200JSONResponse{
+		Questions: dtos,
+		Total:     result.Total,
+	}, nil
+}
+
+// notifyReviewDecision 邮件通知作者审核结果，发送失败不影响审核.
+func notifyReviewDecision(ctx context.Context, app *config.App, question model.Questions) {
+	author, err := user_repo.GetUserInfoByID(ctx, app.DB, question.CreatedBy)
+	if err != nil {
+		app.Logger.Warn("Failed to load question author for review notification", zap.Error(err))
+		return
+	}
+
+	link := html.EscapeString(
+		util.GenerateQuestionLink(app.Config.Domain, question.QuestionUUID.String()),
+	)
+	var subject, body string
+	switch question.Status {
+	case model.QuestionStatus_Published:
+		subject = "你的题目已通过审核并发布"
+		body = fmt.Sprintf(`<p>你的题目已通过审核并发布：<a href="%s">%s</a></p>`, link, link)
+	case model.QuestionStatus_Approved:
+		subject = "你的题目已通过审核"
+		body = fmt.Sprintf(
+			`<p>你的题目已通过审核，将于 %s 发布：<a href="%s">%s</a></p>`,
+			question.PublishedAt.Format(time.RFC3339),
+			link,
+			link,
+		)
+	case model.QuestionStatus_Rejected:
+		subject = "你的题目未通过审核"
+		body = fmt.Sprintf(
+			`<p>你的题目未通过审核：<a href="%s">%s</a></p><p>原因：%s</p>`,
+			link,
+			link,
+			html.EscapeString(derefOrEmpty(question.ReviewReason)),
+		)
+	default:
+		return
+	}
+
+	if err := app.SendEmail(author.Email, subject, body); err != nil {
+		app.Logger.Warn("Failed to send review notification", zap.Error(err))
+	}
+}
+
+// canViewQuestion 已发布的题目所有人可见，未发布的仅作者和审核人员可见.
+func canViewQuestion(ctx context.Context, question model.Questions) bool {
+	if question.IsPublished {
+		return true
+	}
+	return middleware.RequireOwnerOrPermission(ctx, question.CreatedBy, enum.PermQuestionPublish) == nil
+}
+
+func toQuestionReviewState(question model.Questions) *oapi.QuestionReviewState {
+	state := &oapi.QuestionReviewState{
+		Status:       oapi.QuestionStatus(question.Status),
+		SubmittedAt:  question.SubmittedAt,
+		ReviewedAt:   question.ReviewedAt,
+		ReviewReason: question.ReviewReason,
+	}
+	if question.Status == model.QuestionStatus_Published ||
+		question.Status == model.QuestionStatus_Approved {
+		state.PublishedAt = question.PublishedAt
+	}
+	return state
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/enum"
+	question_repo "genshin-quiz/internal/repository/question"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+)
+
+// UpdateQuestion 作者编辑题目，每次编辑生成新的修订版本.
+// 请求中带 id 的选项原地更新，不带 id 的新增，未出现的已有选项标记为移除.
+// 作者修改已审核题目的内容或答案后，题目撤下并重新进入待审核，有编辑权限的管理人员修改不受影响.
+func UpdateQuestion(
+	ctx context.Context,
+	app *config.App,
+	req oapi.UpdateQuestionRequestObject,
+) (*oapi.CreateQuestionRequest, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	question, err := getAuthoredQuestion(ctx, app, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateQuestionBody(*req.Body); err != nil {
+		return nil, err
+	}
+
+	existingOptions, err := question_repo.GetQuestionOptions(ctx, app.DB, question.Question.ID)
+	if err != nil {
+		return nil, err
+	}
+	existingMap := make(map[uuid.UUID]model.QuestionOptions, len(existingOptions))
+	for _, opt := range existingOptions {
+		existingMap[opt.OptionUUID] = opt
+	}
+	// 指定的选项 ID 必须属于该题目且不能重复
+	seen := make(map[uuid.UUID]bool, len(req.Body.Options))
+	for _, option := range req.Body.Options {
+		if option.Id == nil {
+			continue
+		}
+		if _, exists := existingMap[*option.Id]; !exists || seen[*option.Id] {
+			return nil, common.NewBadRequestError("选项 ID 无效")
+		}
+		seen[*option.Id] = true
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	previous, err := question_repo.BuildQuestionSnapshot(ctx, tx, question.Question)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updatedQuestion, err := question_repo.UpdateQuestionContent(
+		ctx,
+		tx,
+		question.Question.ID,
+		model.Questions{
+			QuestionType: model.QuestionType(req.Body.QuestionType),
+			Category:     model.Category(req.Body.Category),
+			Difficulty:   model.Difficulty(req.Body.Difficulty),
+			Public:       req.Body.Public,
+			UpdatedAt:    &now,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 题目翻译整体替换
+	err = question_repo.DeleteQuestionTranslations(ctx, tx, updatedQuestion.ID)
+	if err != nil {
+		return nil, err
+	}
+	transModels := buildQuestionTranslations(
+		updatedQuestion.ID,
+		req.Body.QuestionText,
+		req.Body.Explanation,
+		now,
+	)
+	err = question_repo.InsertQuestionTranslations(ctx, tx, transModels)
+	if err != nil {
+		return nil, err
+	}
+
+	err = replaceQuestionOptions(ctx, tx, updatedQuestion.ID, existingMap, req.Body.Options, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 记录新版本快照
+	snapshot, err := question_repo.BuildQuestionSnapshot(ctx, tx, *updatedQuestion)
+	if err != nil {
+		return nil, err
+	}
+	if needsReReview(ctx, question.Question.Status) && !reflect.DeepEqual(previous, snapshot) {
+		updatedQuestion, err = question_repo.ReturnQuestionToReview(ctx, tx, updatedQuestion.ID, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = question_repo.InsertQuestionRevision(
+		ctx,
+		tx,
+		*updatedQuestion,
+		*snapshot,
+		userClaims.UserID,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.TagQuestions, cache.TagExams)
+
+	return snapshot, nil
+}
+
+// needsReReview 待审核、已通过或已发布的题目由作者修改后需要重新审核.
+func needsReReview(ctx context.Context, status model.QuestionStatus) bool {
+	switch status {
+	case model.QuestionStatus_PendingReview, model.QuestionStatus_Approved, model.QuestionStatus_Published:
+		return !middleware.HasPermission(ctx, enum.PermQuestionEdit)
+	default:
+		return false
+	}
+}
+
+// replaceQuestionOptions 已有选项原地更新，新选项插入，未出现的已有选项标记为移除.
+func replaceQuestionOptions(
+	ctx context.Context,
+	db qrm.DB,
+	questionID int64,
+	existingMap map[uuid.UUID]model.QuestionOptions,
+	options []oapi.CreateQuestionOptionRequest,
+	now time.Time,
+) error {
+	keptIDs := make([]int64, 0, len(options))
+	kept := make(map[uuid.UUID]bool, len(options))
+	optionTransModels := make([]model.QuestionOptionTranslations, 0)
+	newOptions := make([]model.QuestionOptions, 0)
+	newOptionTexts := make([]*oapi.LocalizedText, 0)
+	for _, option := range options {
+		if option.Id == nil {
+			newOptions = append(newOptions, model.QuestionOptions{
+				QuestionID: questionID,
+				OptionUUID: uuid.New(),
+				OptionType: model.QuestionOptionType(option.OptionType),
+				ImgURL:     option.MediaUrl,
+				IsAnswer:   option.IsAnswer,
+				CreatedAt:  now,
+			})
+			newOptionTexts = append(newOptionTexts, option.Text)
+			continue
+		}
+
+		existing := existingMap[*option.Id]
+		existing.OptionType = model.QuestionOptionType(option.OptionType)
+		existing.ImgURL = option.MediaUrl
+		existing.IsAnswer = option.IsAnswer
+		err := question_repo.UpdateQuestionOption(ctx, db, existing)
+		if err != nil {
+			return err
+		}
+		kept[existing.OptionUUID] = true
+		keptIDs = append(keptIDs, existing.ID)
+		optionTransModels = append(
+			optionTransModels,
+			buildOptionTranslations(existing.ID, option.Text, now)...,
+		)
+	}
+
+	removedIDs := make([]int64, 0)
+	for optionUUID, opt := range existingMap {
+		if !kept[optionUUID] {
+			removedIDs = append(removedIDs, opt.ID)
+		}
+	}
+	err := question_repo.RemoveQuestionOptions(ctx, db, removedIDs, now)
+	if err != nil {
+		return err
+	}
+
+	if len(newOptions) > 0 {
+		insertedOptions, err := question_repo.InsertQuestionOptions(ctx, db, newOptions)
+		if err != nil {
+			return err
+		}
+		for i, option := range *insertedOptions {
+			optionTransModels = append(
+				optionTransModels,
+				buildOptionTranslations(option.ID, newOptionTexts[i], now)...,
+			)
+		}
+	}
+
+	// 选项翻译整体替换
+	err = question_repo.DeleteOptionTranslations(ctx, db, keptIDs)
+	if err != nil {
+		return err
+	}
+	return question_repo.InsertOptionTranslations(ctx, db, optionTransModels)
+}
+
+// getAuthoredQuestion 获取题目并校验当前用户是否为作者或有编辑权限的管理员.
+func getAuthoredQuestion(
+	ctx context.Context,
+	app *config.App,
+	questionUUID uuid.UUID,
+) (*dao.SimpleQuestion, error) {
+	question, err := question_repo.GetQuestionByUUID(ctx, app.DB, questionUUID)
+	if err != nil {
+		return nil, err
+	}
+	err = middleware.RequireOwnerOrPermission(ctx, question.Question.CreatedBy, enum.PermQuestionEdit)
+	if err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// validateQuestionBody 校验题目文本和选项，单选和判断题只能有一个正确答案.
+func validateQuestionBody(body oapi.CreateQuestionRequest) error {
+	if len(body.QuestionText) == 0 {
+		return common.NewBadRequestError("题目内容不能为空")
+	}
+	if len(body.Options) < 2 {
+		return common.NewBadRequestError("至少需要两个选项")
+	}
+
+	answers := 0
+	for _, option := range body.Options {
+		if option.IsAnswer {
+			answers++
+		}
+	}
+	if answers == 0 {
+		return common.NewBadRequestError("至少需要一个正确答案")
+	}
+	if body.QuestionType != oapi.MultipleChoice && answers != 1 {
+		return common.NewBadRequestError("该题型只能有一个正确答案")
+	}
+
+	return nil
+}
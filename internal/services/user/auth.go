@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
 	"genshin-quiz/generated/oapi"
 	"genshin-quiz/internal/enum"
 	user_repo "genshin-quiz/internal/repository/user"
@@ -102,7 +103,19 @@ func ResetPassword(
 		return nil, errors.WrapPrefix(err, "update user password failed", 0)
 	}
 
-	// 5. 提交事务
+	// 5. 注销该用户的全部会话，已签发的令牌随即失效
+	err = user_repo.RevokeUserSessions(
+		ctx,
+		tx,
+		tokenRecord.UserID,
+		model.SessionRevokeReason_PasswordChanged,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. 提交事务
 	if err := tx.Commit(); err != nil {
 		return nil, errors.WrapPrefix(err, "commit transaction failed", 0)
 	}
@@ -148,13 +161,29 @@ func ChangePassword(
 		return nil, errors.WrapPrefix(err, "hash new password failed", 0)
 	}
 
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "begin transaction failed", 0)
+	}
+	defer tx.Rollback()
+
 	// 6. 更新数据库中的密码凭证
 	hashedNewPwdStr := string(hashedNewPwd)
-	err = user_repo.UpdateUserCredential(ctx, app.DB, userID, "password", &hashedNewPwdStr)
+	err = user_repo.UpdateUserCredential(ctx, tx, userID, "password", &hashedNewPwdStr)
 	if err != nil {
 		return nil, errors.WrapPrefix(err, "update password failed", 0)
 	}
 
+	// 7. 注销全部会话（包括当前会话），需要重新登录
+	err = user_repo.RevokeUserSessions(ctx, tx, userID, model.SessionRevokeReason_PasswordChanged, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.WrapPrefix(err, "commit transaction failed", 0)
+	}
+
 	return &oapi.PostChangePassword200Response{}, nil
 }
 
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/enum"
+	"genshin-quiz/internal/metrics"
 	user_repo "genshin-quiz/internal/repository/user"
 	"genshin-quiz/internal/util"
 
@@ -75,9 +77,10 @@ func RegisterUser(
 
 	response, err := realLogin(
 		ctx,
+		app,
 		tx,
-		app.Config.JWTSecret,
 		"password",
+		nil,
 		res,
 		profile,
 		privacies,
@@ -91,6 +94,7 @@ func RegisterUser(
 	if err := tx.Commit(); err != nil {
 		return nil, go_errors.WrapPrefix(err, "failed to commit transaction", 0)
 	}
+	metrics.RecordRegistration(enum.LoginPassword)
 
 	return response, nil
 }
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao"
+	"genshin-quiz/internal/dao/transformer"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-errors/errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// 导出文件保留时间，过期后由定时任务删除.
+	dataExportTTL = 7 * 24 * time.Hour
+	// 每次定时任务最多生成的导出数.
+	dataExportBatchSize = 20
+)
+
+// RequestDataExport 申请导出个人数据，由定时任务在后台生成，完成后邮件通知.
+// 同一时间只能有一个正在生成的导出.
+func RequestDataExport(
+	ctx context.Context,
+	app *config.App,
+	req oapi.RequestDataExportRequestObject,
+) (*oapi.DataExport, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+	if !req.Body.Format.Valid() {
+		return nil, common.NewBadRequestError("导出格式无效")
+	}
+
+	pending, err := user_repo.HasPendingDataExport(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, common.NewBadRequestError("已有正在生成的导出")
+	}
+
+	export, err := user_repo.InsertDataExport(ctx, app.DB, model.UserDataExports{
+		ExportUUID: uuid.New(),
+		UserID:     userClaims.UserID,
+		Format:     model.DataExportFormat(req.Body.Format),
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := transformer.ToDataExport(*export)
+	return &dto, nil
+}
+
+func GetDataExport(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetDataExportRequestObject,
+) (*oapi.DataExport, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	export, err := user_repo.GetDataExportByUUID(ctx, app.DB, userClaims.UserID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := transformer.ToDataExport(*export)
+	return &dto, nil
+}
+
+// DownloadDataExport 返回已生成的导出文件，过期的导出视为不存在.
+func DownloadDataExport(
+	ctx context.Context,
+	app *config.App,
+	req oapi.DownloadDataExportRequestObject,
+) ([]byte, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	export, err := user_repo.GetDataExportByUUID(ctx, app.DB, userClaims.UserID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if export.ExpiresAt != nil && !export.ExpiresAt.After(time.Now()) {
+		return nil, common.ErrExportNotFound
+	}
+	if export.Status != model.DataExportStatus_Completed || export.Data == nil {
+		return nil, common.NewBadRequestError("导出尚未完成")
+	}
+
+	return *export.Data, nil
+}
+
+// BuildPendingDataExports 生成等待中的导出并清理过期文件，返回生成成功的数量.
+// 单个导出失败只标记该导出，不影响其他用户.
+func BuildPendingDataExports(
+	ctx context.Context,
+	app *config.App,
+	now time.Time,
+) (int, error) {
+	purged, err := user_repo.DeleteExpiredDataExports(ctx, app.DB, now)
+	if err != nil {
+		return 0, err
+	}
+	if purged > 0 {
+		app.Logger.Info("Purged expired data exports", zap.Int64("purged", purged))
+	}
+
+	ids, err := user_repo.GetPendingDataExportIDs(ctx, app.DB, dataExportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	built := 0
+	for _, id := range ids {
+		export, err := user_repo.GetDataExportByID(ctx, app.DB, id)
+		if err != nil {
+			return built, err
+		}
+
+		data, err := buildDataExport(ctx, app, *export)
+		if err != nil {
+			app.Logger.Error("Failed to build data export",
+				zap.Int64("export_id", export.ID),
+				zap.Error(err),
+			)
+			err = user_repo.FailDataExport(ctx, app.DB, export.ID, err.Error(), time.Now())
+			if err != nil {
+				return built, err
+			}
+			continue
+		}
+
+		completedAt := time.Now()
+		expiresAt := completedAt.Add(dataExportTTL)
+		err = user_repo.CompleteDataExport(ctx, app.DB, export.ID, data, completedAt, expiresAt)
+		if err != nil {
+			return built, err
+		}
+		built++
+		notifyDataExportReady(ctx, app, export.UserID)
+	}
+	return built, nil
+}
+
+// buildDataExport 汇总用户数据并按导出格式打包.
+func buildDataExport(
+	ctx context.Context,
+	app *config.App,
+	export model.UserDataExports,
+) ([]byte, error) {
+	data, err := collectUserData(ctx, app, export.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if export.Format == model.DataExportFormat_Zip {
+		return encodeDataExportZip(*data)
+	}
+	return json.MarshalIndent(data, "", "  ")
+}
+
+func collectUserData(
+	ctx context.Context,
+	app *config.App,
+	userID int64,
+) (*dao.UserDataExport, error) {
+	user, err := user_repo.GetUserInfoByID(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := user_repo.GetUserProfileByID(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	privacies, err := user_repo.GetUserPrivaciesByID(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := dao.UserDataExport{
+		Profile: dao.ExportProfile{
+			UUID:                  user.UserUUID,
+			Email:                 user.Email,
+			Nickname:              user.Nickname,
+			AvatarURL:             user.AvatarURL,
+			Biography:             user.Biography,
+			Language:              user.Language,
+			EmailVerified:         user.EmailVerified,
+			RegisteredIP:          user.CreatedIP,
+			RegisteredAt:          user.CreatedAt,
+			Gender:                profile.Gender,
+			Country:               profile.Country,
+			Timezone:              profile.Timezone,
+			Birthday:              profile.Birthday,
+			Website:               profile.Website,
+			Twitter:               profile.Twitter,
+			Discord:               profile.Discord,
+			EmailVisibility:       privacies.EmailVisibility,
+			BirthdayVisibility:    privacies.BirthdayVisibility,
+			GenderVisibility:      privacies.GenderVisibility,
+			CountryVisibility:     privacies.CountryVisibility,
+			LeaderboardVisibility: privacies.LeaderboardVisibility,
+		},
+	}
+
+	data.Submissions, err = user_repo.GetExportSubmissions(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	data.Votes, err = user_repo.GetExportVotes(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	data.Likes, err = user_repo.GetExportLikes(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	data.Comments, err = user_repo.GetExportComments(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	data.LoginHistory, err = user_repo.GetExportLoginHistory(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// encodeDataExportZip 每个分区写成一个 JSON 文件.
+func encodeDataExportZip(data dao.UserDataExport) ([]byte, error) {
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"profile.json", data.Profile},
+		{"submissions.json", data.Submissions},
+		{"votes.json", data.Votes},
+		{"likes.json", data.Likes},
+		{"comments.json", data.Comments},
+		{"login_history.json", data.LoginHistory},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, errors.WrapPrefix(err, "create export zip entry failed", 0)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(f.content); err != nil {
+			return nil, errors.WrapPrefix(err, "encode export zip entry failed", 0)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errors.WrapPrefix(err, "close export zip failed", 0)
+	}
+	return buf.Bytes(), nil
+}
+
+// notifyDataExportReady 邮件通知导出完成，发送失败只记录日志.
+func notifyDataExportReady(ctx context.Context, app *config.App, userID int64) {
+	user, err := user_repo.GetUserInfoByID(ctx, app.DB, userID)
+	if err != nil {
+		app.Logger.Warn("Failed to load user for data export email", zap.Error(err))
+		return
+	}
+	err = app.SendEmail(
+		user.Email,
+		"个人数据导出已完成",
+		"您申请的个人数据导出已生成，请在 7 天内登录下载。",
+	)
+	if err != nil {
+		app.Logger.Warn("Failed to send data export email", zap.Error(err))
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+	poll_repo "genshin-quiz/internal/repository/poll"
+	question_repo "genshin-quiz/internal/repository/question"
+	user_repo "genshin-quiz/internal/repository/user"
+	question_services "genshin-quiz/internal/services/question"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"go.uber.org/zap"
+)
+
+// 每次定时任务最多处理的注销账号数.
+const accountDeletionBatchSize = 100
+
+// DeleteUser 申请注销账号，冷静期内可以撤销，到期后由定时任务匿名化.
+func DeleteUser(
+	ctx context.Context,
+	app *config.App,
+	req oapi.DeleteUserRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	user, err := user_repo.GetUserInfoByID(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return err
+	}
+	if user.DeletionScheduledAt != nil {
+		return common.NewBadRequestError("已申请注销")
+	}
+
+	now := time.Now()
+	scheduledAt := now.Add(app.Config.AccountDeletionGrace)
+	err = user_repo.ScheduleUserDeletion(ctx, app.DB, user.ID, now, scheduledAt)
+	if err != nil {
+		return err
+	}
+
+	// 通知邮件发送失败不影响注销申请
+	body := fmt.Sprintf(
+		"您的账号将于 %s 注销，注销前登录并撤销申请即可保留账号。",
+		scheduledAt.Format(time.DateTime),
+	)
+	if err := app.SendEmail(user.Email, "账号注销申请", body); err != nil {
+		app.Logger.Warn("Failed to send account deletion email", zap.Error(err))
+	}
+	return nil
+}
+
+// CancelUserDeletion 冷静期内撤销注销申请.
+func CancelUserDeletion(
+	ctx context.Context,
+	app *config.App,
+	req oapi.CancelUserDeletionRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	user, err := user_repo.GetUserInfoByID(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return err
+	}
+	if user.DeletionScheduledAt == nil {
+		return common.NewBadRequestError("未申请注销")
+	}
+
+	return user_repo.CancelUserDeletion(ctx, app.DB, user.ID)
+}
+
+// ProcessAccountDeletions 匿名化冷静期已结束的账号，返回处理的数量.
+func ProcessAccountDeletions(
+	ctx context.Context,
+	app *config.App,
+	now time.Time,
+) (int, error) {
+	users, err := user_repo.GetUsersDueForDeletion(ctx, app.DB, now, accountDeletionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, nil
+	}
+
+	placeholder, err := user_repo.GetUserInfoByUUID(ctx, app.DB, user_repo.DeletedUserUUID)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, user := range users {
+		err := anonymizeAccount(ctx, app, user, placeholder.ID, now)
+		if err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// anonymizeAccount 按配置处理账号发布的题目和投票，再清除个人数据.
+// keep：内容转给占位账号继续展示；remove：题目软删除，投票隐藏.
+func anonymizeAccount(
+	ctx context.Context,
+	app *config.App,
+	user model.Users,
+	placeholderID int64,
+	now time.Time,
+) error {
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	switch app.Config.DeletedContentPolicy {
+	case enum.DeletedContentRemove:
+		questionIDs, err := question_repo.GetQuestionIDsByAuthor(ctx, tx, user.ID)
+		if err != nil {
+			return err
+		}
+		err = question_services.SoftDeleteQuestions(ctx, tx, user.ID, questionIDs, user.ID, now)
+		if err != nil {
+			return err
+		}
+		err = poll_repo.HidePollsByAuthor(ctx, tx, user.ID, now)
+		if err != nil {
+			return err
+		}
+	default:
+		err = question_repo.ReassignQuestionAuthor(ctx, tx, user.ID, placeholderID)
+		if err != nil {
+			return err
+		}
+		err = poll_repo.ReassignPollAuthor(ctx, tx, user.ID, placeholderID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = user_repo.DeleteUser(ctx, tx, user, now)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, app.Redis, cache.UserContentTags...)
+	return nil
+}
@@ -2,18 +2,42 @@ package services
 
 import (
 	"context"
+	"time"
+
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/dao"
 	"genshin-quiz/internal/dao/transformer"
 	"genshin-quiz/internal/enum"
 	user_repo "genshin-quiz/internal/repository/user"
 )
 
+// 排行榜统计随作答持续变化，不逐次失效，依靠较短的 TTL 刷新.
+const leaderboardCacheTTL = time.Minute
+
+// GetUsers 用户排行榜，按排序参数和访问者缓存，用户资料或隐私设置变更时失效.
 func GetUsers(
 	ctx context.Context,
 	app *config.App,
 	req oapi.GetUsersRequestObject,
+) (*oapi.GetUsers200JSONResponse, error) {
+	return cache.GetOrLoad(
+		ctx,
+		app.Redis,
+		cache.Key(ctx, "leaderboard", req.Params),
+		[]string{cache.TagLeaderboard},
+		leaderboardCacheTTL,
+		func(ctx context.Context) (*oapi.GetUsers200JSONResponse, error) {
+			return loadUsersLeaderboard(ctx, app, req)
+		},
+	)
+}
+
+func loadUsersLeaderboard(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetUsersRequestObject,
 ) (*oapi.GetUsers200JSONResponse, error) {
 	limit := 10
 	if req.Params.Limit != nil {
@@ -7,8 +7,11 @@ import (
 	"genshin-quiz/generated/oapi"
 	"genshin-quiz/internal/dao/transformer"
 	"genshin-quiz/internal/enum"
+	"genshin-quiz/internal/metrics"
 	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
 	"genshin-quiz/internal/webserver/middleware"
+	"time"
 
 	"genshin-quiz/internal/common"
 
@@ -21,47 +24,50 @@ func LoginUser(
 	ctx context.Context,
 	app *config.App,
 	req oapi.PostLoginUserRequestObject,
-) (*oapi.AuthResponse, error) {
+) (*oapi.AuthResponse, *oapi.TwoFactorChallenge, error) {
 	email := req.Body.Email
 	pwd := req.Body.Password
 
 	// 获取用户信息
 	authInfo, err := user_repo.GetPasswordByEmail(ctx, app.DB, string(email))
 	if err != nil {
-		return nil, errors.WrapPrefix(err, "login user failed", 0)
+		return nil, nil, errors.WrapPrefix(err, "login user failed", 0)
 	}
 
+	// 密码错误不写登录日志，只计入指标
 	hashedPwd := authInfo.Credential
 	if hashedPwd == nil {
-		return nil, common.ErrInvalidCredentials
+		metrics.RecordLogin(enum.LoginPassword, enum.LoginStatusFailed)
+		return nil, nil, common.ErrInvalidCredentials
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(*hashedPwd), []byte(pwd))
 	if err != nil {
 		// 密码错误
-		return nil, common.ErrInvalidCredentials
+		metrics.RecordLogin(enum.LoginPassword, enum.LoginStatusFailed)
+		return nil, nil, common.ErrInvalidCredentials
 	}
 
 	// TODO:获取用户的其他统计信息
 	userID := authInfo.Users.ID
 	userProfile, err := user_repo.GetUserProfileByID(ctx, app.DB, userID)
 	if err != nil {
-		return nil, errors.WrapPrefix(err, "login user failed", 0)
+		return nil, nil, errors.WrapPrefix(err, "login user failed", 0)
 	}
 	userPrivacies, err := user_repo.GetUserPrivaciesByID(ctx, app.DB, userID)
 	if err != nil {
-		return nil, errors.WrapPrefix(err, "login user failed", 0)
+		return nil, nil, errors.WrapPrefix(err, "login user failed", 0)
 	}
 	userStats, err := user_repo.GetUserStatisticsByID(ctx, app.DB, userID)
 	if err != nil {
-		return nil, errors.WrapPrefix(err, "login user failed", 0)
+		return nil, nil, errors.WrapPrefix(err, "login user failed", 0)
 	}
 
-	// 登录流程
-	return realLogin(
+	// 登录流程，开启两步验证的账号先返回挑战令牌
+	return startLogin(
 		ctx,
+		app,
 		app.DB,
-		app.Config.JWTSecret,
 		"password",
 		&authInfo.Users,
 		userProfile,
@@ -70,34 +76,24 @@ func LoginUser(
 	)
 }
 
+// realLogin 签发会话完成登录，secondFactor 为完成两步验证时使用的方式.
 func realLogin(
 	ctx context.Context,
+	app *config.App,
 	db qrm.DB,
-	secret string,
 	loginType enum.LoginProvider, // "password", "google"
+	secondFactor *enum.SecondFactor,
 	user *model.Users,
 	profile *model.UserProfiles,
 	privacies *model.UserPrivacies,
 	stats *model.UserStats,
 ) (*oapi.AuthResponse, error) {
-	// 生成 JWT
-	tokenString, err := middleware.GenerateJWT(user.ID, user.Email, secret)
-	if err != nil {
-		return nil, errors.WrapPrefix(err, "GenerateJWT failed", 0)
-	}
-	// 从 Context 提取 IP 和 User-Agent
-	ip, _ := ctx.Value(middleware.RealIPKey).(string)
-	// 如果 IP 为空，赋值默认值，防止数据库 INET 字段解析报错
-	if ip == "" {
-		ip = "127.0.0.1"
-	}
-
-	var userAgent *string
-	if ua, ok := ctx.Value(middleware.UserAgentKey).(string); ok && ua != "" {
-		userAgent = &ua
+	if err := checkLoginAllowed(ctx, db, user, loginType); err != nil {
+		return nil, err
 	}
 
 	// 写登录日志
+	ip, userAgent := loginClientInfo(ctx)
 	loginInfo, err := user_repo.InsertLoginLog(
 		ctx,
 		db,
@@ -106,13 +102,23 @@ func realLogin(
 		userAgent,
 		loginType,
 		enum.LoginStatusSuccess,
+		secondFactor,
 	)
 	if err != nil {
 		return nil, err
 	}
+	metrics.RecordLogin(loginType, enum.LoginStatusSuccess)
+
+	// 创建会话，签发访问令牌和刷新令牌
+	tokens, err := issueSession(ctx, app, db, user, &loginInfo.ID, secondFactor != nil)
+	if err != nil {
+		return nil, err
+	}
 
 	return &oapi.AuthResponse{
-		Token: tokenString,
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
 		User: transformer.UserModelToPrivate(
 			*user,
 			*profile,
@@ -122,3 +128,52 @@ func realLogin(
 		),
 	}, nil
 }
+
+// checkLoginAllowed 被封禁或已注销的账号拒绝登录，并记录为拦截.
+func checkLoginAllowed(
+	ctx context.Context,
+	db qrm.DB,
+	user *model.Users,
+	loginType enum.LoginProvider,
+) error {
+	err := util.CheckUserStatus(*user, time.Now())
+	if err == nil {
+		return nil
+	}
+
+	ip, userAgent := loginClientInfo(ctx)
+	_, logErr := user_repo.InsertLoginLog(
+		ctx,
+		db,
+		user.ID,
+		ip,
+		userAgent,
+		loginType,
+		enum.LoginStatusBlocked,
+		nil,
+	)
+	if logErr != nil {
+		return logErr
+	}
+	metrics.RecordLogin(loginType, enum.LoginStatusBlocked)
+	// 已注销的账号按账号不存在处理
+	if errors.Is(err, common.ErrAccountDeleted) {
+		return common.ErrInvalidCredentials
+	}
+	return err
+}
+
+// loginClientInfo 从 Context 提取 IP 和 User-Agent.
+func loginClientInfo(ctx context.Context) (string, *string) {
+	ip, _ := ctx.Value(middleware.RealIPKey).(string)
+	// 如果 IP 为空，赋值默认值，防止数据库 INET 字段解析报错
+	if ip == "" {
+		ip = "127.0.0.1"
+	}
+
+	var userAgent *string
+	if ua, ok := ctx.Value(middleware.UserAgentKey).(string); ok && ua != "" {
+		userAgent = &ua
+	}
+	return ip, userAgent
+}
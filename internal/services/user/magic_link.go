@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+	"genshin-quiz/internal/ratelimit"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-errors/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	magicLinkTTL          = 15 * time.Minute
+	magicLinkLimitWindow  = time.Hour
+	magicLinkEmailLimit   = 5
+	magicLinkIPLimit      = 20
+	magicLinkEmailSubject = "登录链接"
+)
+
+// RequestMagicLink 向邮箱发送一次性登录链接.
+// 查询账号和发送邮件在后台执行，无论邮箱是否注册都立即返回成功，响应时间不会泄露账号是否存在.
+func RequestMagicLink(
+	ctx context.Context,
+	app *config.App,
+	req oapi.RequestMagicLinkRequestObject,
+) error {
+	email := strings.TrimSpace(string(req.Body.Email))
+	if email == "" {
+		return common.NewBadRequestError("邮箱不能为空")
+	}
+
+	// 按邮箱和 IP 分别限流，计数与邮箱是否存在无关；邮箱取哈希，不保存明文
+	emailHash := sha256.Sum256([]byte(strings.ToLower(email)))
+	ip, _ := ctx.Value(middleware.RealIPKey).(string)
+	limits := []struct {
+		key      string
+		requests int
+	}{
+		{key: "magic_link_email:" + hex.EncodeToString(emailHash[:]), requests: magicLinkEmailLimit},
+		{key: "magic_link_ip:" + ip, requests: magicLinkIPLimit},
+	}
+	for _, limit := range limits {
+		result, err := app.Limiter.Allow(ctx, limit.key, ratelimit.Limit{
+			Requests: limit.requests,
+			Window:   magicLinkLimitWindow,
+		})
+		if err != nil {
+			return err
+		}
+		if !result.Allowed {
+			return common.ErrTooManyAttempts
+		}
+	}
+
+	app.Lifecycle.Go(func(ctx context.Context) {
+		if err := sendMagicLink(ctx, app, email); err != nil {
+			app.Logger.Warn("Failed to send magic link", zap.Error(err))
+		}
+	})
+	return nil
+}
+
+// sendMagicLink 邮箱未注册或账号不可登录时不发送.
+func sendMagicLink(ctx context.Context, app *config.App, email string) error {
+	user, err := user_repo.GetUserByEmail(ctx, app.DB, email)
+	if err != nil {
+		if errors.Is(err, common.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	// 被封禁或已注销的账号不发送链接，登录时同样会被拦截
+	if util.CheckUserStatus(*user, time.Now()) != nil {
+		return nil
+	}
+
+	rawToken, err := user_repo.InsertUserToken(ctx, app.DB, user.ID, enum.TokenTypeMagicLink, magicLinkTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"点击以下链接登录，链接 %d 分钟内有效且只能使用一次：<br>%s",
+		int(magicLinkTTL.Minutes()),
+		util.GenerateMagicLoginLink(app.Config.Domain, rawToken),
+	)
+	return app.SendEmail(user.Email, magicLinkEmailSubject, body)
+}
+
+// VerifyMagicLink 核销登录链接中的令牌并登录，能收到链接即证明拥有该邮箱.
+// 开启两步验证的账号仍需完成第二步.
+func VerifyMagicLink(
+	ctx context.Context,
+	app *config.App,
+	req oapi.VerifyMagicLinkRequestObject,
+) (*oapi.AuthResponse, *oapi.TwoFactorChallenge, error) {
+	if req.Body.Token == "" {
+		return nil, nil, common.ErrInvalidToken
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	// 令牌不存在、过期或已使用都返回同一错误
+	tokenRecord, err := user_repo.VerifyAndUseToken(ctx, tx, req.Body.Token, enum.TokenTypeMagicLink)
+	if err != nil {
+		if !errors.Is(err, common.ErrInvalidToken) {
+			app.Logger.Error("Failed to verify magic link token", zap.Error(err))
+		}
+		return nil, nil, common.ErrInvalidToken
+	}
+	user, err := user_repo.GetUserInfoByID(ctx, tx, tokenRecord.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.EmailVerified {
+		err = user_repo.SetUserEmailVerified(ctx, tx, user.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		user.EmailVerified = true
+	}
+	// 先提交令牌核销，被封禁的账号登录时也能留下拦截日志
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	profile, err := user_repo.GetUserProfileByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	privacies, err := user_repo.GetUserPrivaciesByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats, err := user_repo.GetUserStatisticsByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return startLogin(ctx, app, app.DB, enum.LoginMagicLink, user, profile, privacies, stats)
+}
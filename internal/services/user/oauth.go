@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/dao/transformer"
+	"genshin-quiz/internal/enum"
+	"genshin-quiz/internal/metrics"
+	"genshin-quiz/internal/oauth"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-errors/errors"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+const oauthStateTTL = 10 * time.Minute
+
+// OAuthAuthorize 生成第三方授权地址，state、PKCE code_verifier 和 nonce 保存在服务端.
+// link 为 true 时回调将第三方账号绑定到当前登录用户.
+func OAuthAuthorize(
+	ctx context.Context,
+	app *config.App,
+	req oapi.OAuthAuthorizeRequestObject,
+) (*oapi.OAuthAuthorization, error) {
+	provider, err := oauth.NewProvider(app.OAuth, enum.LoginProvider(req.Provider))
+	if err != nil {
+		return nil, err
+	}
+
+	var linkUserID *int64
+	if req.Params.Link != nil && *req.Params.Link {
+		userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+		if !ok {
+			return nil, common.ErrUserNotInContext
+		}
+		linkUserID = &userClaims.UserID
+	}
+
+	state, err := oauth.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := oauth.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oauth.RandomString()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = user_repo.InsertOAuthState(ctx, app.DB, state, model.OAuthStates{
+		Provider:     string(req.Provider),
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		LinkUserID:   linkUserID,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(oauthStateTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.OAuthAuthorization{
+		AuthorizationUrl: provider.AuthCodeURL(
+			app.OAuth.RedirectURL,
+			state,
+			oauth.CodeChallenge(codeVerifier),
+			nonce,
+		),
+		State: state,
+	}, nil
+}
+
+// OAuthCallback 校验 state 后用授权码换取第三方身份并登录.
+// 已绑定的第三方账号直接登录；未绑定时按 state 中的绑定请求或已验证的邮箱关联已有账号，否则创建新账号.
+func OAuthCallback(
+	ctx context.Context,
+	app *config.App,
+	req oapi.OAuthCallbackRequestObject,
+) (*oapi.AuthResponse, *oapi.TwoFactorChallenge, error) {
+	providerName := enum.LoginProvider(req.Provider)
+	provider, err := oauth.NewProvider(app.OAuth, providerName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.Body.Code == "" || req.Body.State == "" {
+		return nil, nil, common.NewBadRequestError("缺少授权码或 state")
+	}
+
+	state, err := user_repo.ConsumeOAuthState(ctx, app.DB, req.Body.State, string(req.Provider), time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	identity, err := provider.Exchange(
+		ctx,
+		app.OAuth.RedirectURL,
+		req.Body.Code,
+		state.CodeVerifier,
+		state.Nonce,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	extraData, err := json.Marshal(identity.Raw)
+	if err != nil {
+		return nil, nil, errors.WrapPrefix(err, "encode oauth profile failed", 0)
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	user, created, err := resolveOAuthUser(ctx, tx, providerName, state.LinkUserID, identity, string(extraData))
+	if err != nil {
+		return nil, nil, err
+	}
+	// 先提交绑定关系，被封禁的账号登录时也能留下拦截日志
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	if created {
+		metrics.RecordRegistration(providerName)
+	}
+
+	profile, err := user_repo.GetUserProfileByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	privacies, err := user_repo.GetUserPrivaciesByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats, err := user_repo.GetUserStatisticsByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return startLogin(ctx, app, app.DB, providerName, user, profile, privacies, stats)
+}
+
+// GetLinkedIdentities 获取当前用户的全部登录方式.
+func GetLinkedIdentities(
+	ctx context.Context,
+	app *config.App,
+	_ oapi.GetLinkedIdentitiesRequestObject,
+) ([]oapi.LinkedIdentity, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	creds, err := user_repo.GetUserCredentials(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]oapi.LinkedIdentity, 0, len(creds))
+	for _, cred := range creds {
+		dtos = append(dtos, transformer.ToLinkedIdentity(cred))
+	}
+	return dtos, nil
+}
+
+// UnlinkIdentity 解绑第三方账号，至少保留一种登录方式.
+func UnlinkIdentity(
+	ctx context.Context,
+	app *config.App,
+	req oapi.UnlinkIdentityRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	creds, err := user_repo.GetUserCredentials(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return err
+	}
+	linked := false
+	for _, cred := range creds {
+		if cred.IdentityType == string(req.Provider) {
+			linked = true
+			break
+		}
+	}
+	if !linked {
+		return common.NewNotFoundError("未绑定该登录方式")
+	}
+	if len(creds) == 1 {
+		return common.NewBadRequestError("不能解绑唯一的登录方式")
+	}
+
+	return user_repo.DeleteUserCredential(ctx, app.DB, userClaims.UserID, string(req.Provider))
+}
+
+// oauthAccountAction 第三方身份登录时对应账号的处理方式.
+type oauthAccountAction int
+
+const (
+	oauthLoginLinked     oauthAccountAction = iota // 已绑定，直接登录
+	oauthLinkCurrentUser                           // 绑定到发起绑定的当前用户
+	oauthLinkEmailUser                             // 绑定到邮箱相同的已有账号
+	oauthCreateUser                                // 创建新账号
+)
+
+// decideOAuthAccount 决定第三方身份对应的账号.
+// linkedUserID 为已绑定该身份的用户，emailRegistered 表示第三方邮箱是否已注册.
+func decideOAuthAccount(
+	linkUserID *int64,
+	linkedUserID *int64,
+	identity *oauth.Identity,
+	emailRegistered bool,
+) (oauthAccountAction, error) {
+	if linkedUserID != nil {
+		if linkUserID != nil && *linkUserID != *linkedUserID {
+			return 0, common.NewBadRequestError("该第三方账号已绑定其他用户")
+		}
+		return oauthLoginLinked, nil
+	}
+	if linkUserID != nil {
+		return oauthLinkCurrentUser, nil
+	}
+	if strings.TrimSpace(identity.Email) == "" {
+		return 0, common.NewBadRequestError("第三方账号未提供邮箱")
+	}
+	if !emailRegistered {
+		return oauthCreateUser, nil
+	}
+	// 邮箱未经第三方验证时不能据此关联，避免冒用他人邮箱接管账号
+	if !identity.EmailVerified {
+		return 0, common.NewBadRequestError("该邮箱已注册，请登录后绑定第三方账号")
+	}
+	return oauthLinkEmailUser, nil
+}
+
+// resolveOAuthUser 找到第三方身份对应的用户，必要时绑定或创建账号，created 表示新建了账号.
+func resolveOAuthUser(
+	ctx context.Context,
+	db qrm.DB,
+	provider enum.LoginProvider,
+	linkUserID *int64,
+	identity *oauth.Identity,
+	extraData string,
+) (*model.Users, bool, error) {
+	cred, err := user_repo.GetCredentialByIdentifier(ctx, db, string(provider), identity.Subject)
+	if err != nil {
+		return nil, false, err
+	}
+	var linkedUserID *int64
+	if cred != nil {
+		linkedUserID = &cred.UserID
+	}
+
+	email := strings.TrimSpace(identity.Email)
+	var emailUser *model.Users
+	if cred == nil && linkUserID == nil && email != "" {
+		emailUser, err = user_repo.GetUserByEmail(ctx, db, email)
+		if err != nil && !errors.Is(err, common.ErrUserNotFound) {
+			return nil, false, err
+		}
+	}
+
+	action, err := decideOAuthAccount(linkUserID, linkedUserID, identity, emailUser != nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var user *model.Users
+	switch action {
+	case oauthLoginLinked:
+		err = user_repo.UpdateCredentialExtraData(ctx, db, cred.ID, extraData)
+		if err != nil {
+			return nil, false, err
+		}
+		user, err = user_repo.GetUserInfoByID(ctx, db, cred.UserID)
+		return user, false, err
+	case oauthLinkCurrentUser:
+		user, err = user_repo.GetUserInfoByID(ctx, db, *linkUserID)
+	case oauthLinkEmailUser:
+		user = emailUser
+	case oauthCreateUser:
+		user, err = createOAuthUser(ctx, db, email, identity.EmailVerified)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, err := user_repo.GetUserCredential(ctx, db, user.ID, string(provider)); err == nil {
+		return nil, false, common.NewBadRequestError("已绑定该登录方式的其他账号")
+	} else if !errors.Is(err, common.ErrUserNotFound) {
+		return nil, false, err
+	}
+	err = user_repo.InsertOAuthCredential(ctx, db, user.ID, string(provider), identity.Subject, extraData)
+	if err != nil {
+		return nil, false, err
+	}
+	return user, action == oauthCreateUser, nil
+}
+
+// createOAuthUser 创建第三方登录的新账号，没有密码凭证.
+func createOAuthUser(
+	ctx context.Context,
+	db qrm.DB,
+	email string,
+	emailVerified bool,
+) (*model.Users, error) {
+	user, err := user_repo.InsertUser(ctx, db, email, util.LanguageOrDefault(nil))
+	if err != nil {
+		return nil, err
+	}
+	if emailVerified {
+		err = user_repo.SetUserEmailVerified(ctx, db, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		user.EmailVerified = true
+	}
+	if _, err := user_repo.InsertUserProfile(ctx, db, user.ID); err != nil {
+		return nil, errors.WrapPrefix(err, "failed to insert user profile", 0)
+	}
+	if _, err := user_repo.InsertUserPrivacies(ctx, db, user.ID); err != nil {
+		return nil, errors.WrapPrefix(err, "failed to insert user privacies", 0)
+	}
+	if _, err := user_repo.InsertUserStats(ctx, db, user.ID); err != nil {
+		return nil, errors.WrapPrefix(err, "failed to insert user stats", 0)
+	}
+	return user, nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+
+	"genshin-quiz/internal/oauth"
+)
+
+func TestDecideOAuthAccount(t *testing.T) {
+	currentUser := int64(1)
+	otherUser := int64(2)
+	verified := &oauth.Identity{Subject: "sub", Email: "traveler@example.com", EmailVerified: true}
+	unverified := &oauth.Identity{Subject: "sub", Email: "traveler@example.com"}
+	noEmail := &oauth.Identity{Subject: "sub"}
+
+	tests := []struct {
+		name            string
+		linkUserID      *int64
+		linkedUserID    *int64
+		identity        *oauth.Identity
+		emailRegistered bool
+		want            oauthAccountAction
+		wantErr         bool
+	}{
+		{name: "linked identity logs in", linkedUserID: &otherUser, identity: verified, want: oauthLoginLinked},
+		{
+			name:         "relinking own identity logs in",
+			linkUserID:   &currentUser,
+			linkedUserID: &currentUser,
+			identity:     verified,
+			want:         oauthLoginLinked,
+		},
+		{
+			name:         "identity linked to another user",
+			linkUserID:   &currentUser,
+			linkedUserID: &otherUser,
+			identity:     verified,
+			wantErr:      true,
+		},
+		{
+			name:            "link request binds current user",
+			linkUserID:      &currentUser,
+			identity:        unverified,
+			emailRegistered: true,
+			want:            oauthLinkCurrentUser,
+		},
+		{name: "verified email links existing account", identity: verified, emailRegistered: true, want: oauthLinkEmailUser},
+		{name: "unverified email cannot take over account", identity: unverified, emailRegistered: true, wantErr: true},
+		{name: "new email creates account", identity: unverified, want: oauthCreateUser},
+		{name: "missing email", identity: noEmail, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decideOAuthAccount(tt.linkUserID, tt.linkedUserID, tt.identity, tt.emailRegistered)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got action %d, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("action = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-errors/errors"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RefreshToken 用刷新令牌换取新的访问令牌，旧刷新令牌作废并签发新的.
+// 已作废的刷新令牌再次出现说明令牌可能泄露，注销整个会话.
+func RefreshToken(
+	ctx context.Context,
+	app *config.App,
+	req oapi.RefreshTokenRequestObject,
+) (*oapi.AuthTokens, error) {
+	if req.Body.RefreshToken == "" {
+		return nil, common.ErrInvalidToken
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	record, err := user_repo.GetRefreshTokenForUpdate(ctx, tx, req.Body.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session := record.Session
+	if session.RevokedAt != nil || !session.ExpiresAt.After(now) {
+		return nil, common.ErrInvalidToken
+	}
+
+	if record.Token.UsedAt != nil {
+		err = user_repo.RevokeSession(ctx, tx, session.ID, model.SessionRevokeReason_TokenReuse, now)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		app.Logger.Warn("Refresh token reuse detected, session revoked",
+			zap.Int64("user_id", session.UserID),
+			zap.String("session_id", session.SessionUUID.String()),
+		)
+		return nil, common.ErrInvalidToken
+	}
+
+	user, err := user_repo.GetUserInfoByID(ctx, tx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckUserStatus(*user, now); err != nil {
+		if errors.Is(err, common.ErrAccountDeleted) {
+			return nil, common.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	err = user_repo.MarkRefreshTokenUsed(ctx, tx, record.Token.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	err = user_repo.TouchSession(ctx, tx, session.ID, now, now.Add(app.Config.RefreshTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := user_repo.InsertRefreshToken(ctx, tx, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := signAccessToken(app, user, session.SessionUUID, refreshToken, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// LogoutUser 注销当前会话.
+func LogoutUser(
+	ctx context.Context,
+	app *config.App,
+	req oapi.LogoutUserRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	return revokeSession(ctx, app, userClaims.UserID, userClaims.SessionID, model.SessionRevokeReason_Logout)
+}
+
+// GetSessions 当前用户的有效会话，附带登录时的 IP 和 User-Agent.
+func GetSessions(
+	ctx context.Context,
+	app *config.App,
+	req oapi.GetSessionsRequestObject,
+) ([]oapi.Session, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	sessions, err := user_repo.GetActiveSessions(ctx, app.DB, userClaims.UserID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]oapi.Session, 0, len(sessions))
+	for _, s := range sessions {
+		dto := oapi.Session{
+			Id:         s.Session.SessionUUID,
+			CreatedAt:  s.Session.CreatedAt,
+			LastUsedAt: s.Session.LastUsedAt,
+			ExpiresAt:  s.Session.ExpiresAt,
+			Current:    s.Session.SessionUUID == userClaims.SessionID,
+		}
+		if s.LoginLog != nil {
+			dto.IpAddress = s.LoginLog.IPAddress
+			dto.UserAgent = s.LoginLog.UserAgent
+		}
+		dtos = append(dtos, dto)
+	}
+	return dtos, nil
+}
+
+// RevokeSession 注销当前用户的指定会话.
+func RevokeSession(
+	ctx context.Context,
+	app *config.App,
+	req oapi.RevokeSessionRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	return revokeSession(ctx, app, userClaims.UserID, req.Id, model.SessionRevokeReason_Revoked)
+}
+
+// RevokeAllSessions 注销当前用户的全部会话，包括发起请求的会话.
+func RevokeAllSessions(
+	ctx context.Context,
+	app *config.App,
+	req oapi.RevokeAllSessionsRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+
+	return user_repo.RevokeUserSessions(
+		ctx,
+		app.DB,
+		userClaims.UserID,
+		model.SessionRevokeReason_Revoked,
+		time.Now(),
+	)
+}
+
+func revokeSession(
+	ctx context.Context,
+	app *config.App,
+	userID int64,
+	sessionUUID uuid.UUID,
+	reason model.SessionRevokeReason,
+) error {
+	now := time.Now()
+	session, err := user_repo.GetActiveSession(ctx, app.DB, userID, sessionUUID, now)
+	if err != nil {
+		return err
+	}
+	return user_repo.RevokeSession(ctx, app.DB, session.ID, reason, now)
+}
+
+// issueSession 创建登录会话并签发访问令牌和刷新令牌.
+func issueSession(
+	ctx context.Context,
+	app *config.App,
+	db qrm.DB,
+	user *model.Users,
+	loginLogID *int64,
+	twoFactorVerified bool,
+) (*oapi.AuthTokens, error) {
+	now := time.Now()
+	session, err := user_repo.InsertSession(ctx, db, model.UserSessions{
+		SessionUUID: uuid.New(),
+		UserID:      user.ID,
+		LoginLogID:  loginLogID,
+		CreatedAt:   now,
+		LastUsedAt:  now,
+		ExpiresAt:   now.Add(app.Config.RefreshTokenTTL),
+		// 强制管理人员两步验证时，未经两步验证的会话不具备管理权限
+		TwoFactorVerified: twoFactorVerified,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := user_repo.InsertRefreshToken(ctx, db, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	return signAccessToken(app, user, session.SessionUUID, refreshToken, now)
+}
+
+func signAccessToken(
+	app *config.App,
+	user *model.Users,
+	sessionUUID uuid.UUID,
+	refreshToken string,
+	now time.Time,
+) (*oapi.AuthTokens, error) {
+	expiresAt := now.Add(app.Config.AccessTokenTTL)
+	token, err := middleware.GenerateJWT(user.ID, user.Email, sessionUUID, app.Config.JWTSecret, expiresAt)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "GenerateJWT failed", 0)
+	}
+
+	return &oapi.AuthTokens{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
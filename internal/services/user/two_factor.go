@@ -0,0 +1,498 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+	"genshin-quiz/internal/metrics"
+	"genshin-quiz/internal/ratelimit"
+	user_repo "genshin-quiz/internal/repository/user"
+	"genshin-quiz/internal/util"
+	"genshin-quiz/internal/webserver/middleware"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	twoFactorIssuer          = "Genshin Quiz"
+	twoFactorChallengeType   = "two_factor_challenge"
+	twoFactorChallengeTTL    = 5 * time.Minute
+	twoFactorRecoveryCodes   = 10
+	twoFactorMaxFailures     = 5
+	twoFactorFailureWindow   = 15 * time.Minute
+	twoFactorTOTPCodeLength  = 6
+	twoFactorInvalidCodeText = "验证码错误"
+)
+
+// twoFactorChallengeClaims 第一因素验证通过后签发的挑战令牌，不能用作访问令牌.
+type twoFactorChallengeClaims struct {
+	Type      string             `json:"typ"`
+	UserID    int64              `json:"uid"`
+	LoginType enum.LoginProvider `json:"prv"`
+	jwt.RegisteredClaims
+}
+
+// startLogin 第一因素验证通过后调用，开启两步验证的账号返回挑战令牌，否则直接登录.
+func startLogin(
+	ctx context.Context,
+	app *config.App,
+	db qrm.DB,
+	loginType enum.LoginProvider,
+	user *model.Users,
+	profile *model.UserProfiles,
+	privacies *model.UserPrivacies,
+	stats *model.UserStats,
+) (*oapi.AuthResponse, *oapi.TwoFactorChallenge, error) {
+	if err := checkLoginAllowed(ctx, db, user, loginType); err != nil {
+		return nil, nil, err
+	}
+
+	twoFactor, err := user_repo.GetTwoFactor(ctx, db, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if twoFactor != nil && twoFactor.EnabledAt != nil {
+		challenge, err := issueTwoFactorChallenge(app, user.ID, loginType, time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, challenge, nil
+	}
+
+	res, err := realLogin(ctx, app, db, loginType, nil, user, profile, privacies, stats)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, nil, nil
+}
+
+// VerifyTwoFactorLogin 使用挑战令牌和验证码或恢复码完成登录，成功和失败都记录登录日志.
+func VerifyTwoFactorLogin(
+	ctx context.Context,
+	app *config.App,
+	req oapi.VerifyTwoFactorLoginRequestObject,
+) (*oapi.AuthResponse, error) {
+	claims, ok := parseTwoFactorChallenge(app.Config.JWTSecret, req.Body.ChallengeToken)
+	if !ok {
+		return nil, common.ErrInvalidToken
+	}
+
+	user, err := user_repo.GetUserInfoByID(ctx, app.DB, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	twoFactor, err := user_repo.GetTwoFactor(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil || twoFactor.EnabledAt == nil {
+		return nil, common.ErrInvalidToken
+	}
+
+	limitKey, err := takeSecondFactorAttempt(ctx, app, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	factor, valid, err := verifySecondFactor(ctx, app.DB, twoFactor, req.Body.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		if err := recordSecondFactorFailure(ctx, app, user.ID, claims.LoginType, factor); err != nil {
+			return nil, err
+		}
+		return nil, common.NewUnauthorizedError(twoFactorInvalidCodeText)
+	}
+	resetSecondFactorAttempts(ctx, app, limitKey)
+
+	profile, err := user_repo.GetUserProfileByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	privacies, err := user_repo.GetUserPrivaciesByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := user_repo.GetUserStatisticsByID(ctx, app.DB, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return realLogin(ctx, app, app.DB, claims.LoginType, &factor, user, profile, privacies, stats)
+}
+
+// GetTwoFactorStatus 获取当前用户的两步验证状态.
+func GetTwoFactorStatus(
+	ctx context.Context,
+	app *config.App,
+	_ oapi.GetTwoFactorStatusRequestObject,
+) (*oapi.TwoFactorStatus, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	twoFactor, err := user_repo.GetTwoFactor(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := user_repo.CountUnusedRecoveryCodes(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.TwoFactorStatus{
+		Enabled:                twoFactor != nil && twoFactor.EnabledAt != nil,
+		Required:               twoFactorRequired(app, userClaims.Role),
+		RecoveryCodesRemaining: int(remaining),
+	}, nil
+}
+
+// EnrollTwoFactor 生成新的 TOTP 密钥，使用验证码确认后才启用.
+func EnrollTwoFactor(
+	ctx context.Context,
+	app *config.App,
+	_ oapi.EnrollTwoFactorRequestObject,
+) (*oapi.TwoFactorEnrollment, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	twoFactor, err := user_repo.GetTwoFactor(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor != nil && twoFactor.EnabledAt != nil {
+		return nil, common.NewBadRequestError("已开启两步验证")
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	err = user_repo.SaveTwoFactorSecret(ctx, app.DB, userClaims.UserID, secret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &oapi.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningUri: util.TOTPProvisioningURI(twoFactorIssuer, userClaims.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor 校验认证器中的验证码后启用两步验证，返回只显示一次的恢复码.
+// 当前会话视为已完成两步验证.
+func ConfirmTwoFactor(
+	ctx context.Context,
+	app *config.App,
+	req oapi.ConfirmTwoFactorRequestObject,
+) (*oapi.RecoveryCodes, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	twoFactor, err := user_repo.GetTwoFactor(ctx, app.DB, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil {
+		return nil, common.NewBadRequestError("请先生成两步验证密钥")
+	}
+	if twoFactor.EnabledAt != nil {
+		return nil, common.NewBadRequestError("已开启两步验证")
+	}
+
+	now := time.Now()
+	step, valid := util.ValidateTOTP(twoFactor.Secret, req.Body.Code, now)
+	if !valid {
+		return nil, common.NewBadRequestError(twoFactorInvalidCodeText)
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = user_repo.EnableTwoFactor(ctx, tx, userClaims.UserID, step, now)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := user_repo.ReplaceRecoveryCodes(ctx, tx, userClaims.UserID, twoFactorRecoveryCodes, now)
+	if err != nil {
+		return nil, err
+	}
+	session, err := user_repo.GetActiveSession(ctx, tx, userClaims.UserID, userClaims.SessionID, now)
+	if err != nil {
+		return nil, err
+	}
+	err = user_repo.MarkSessionTwoFactorVerified(ctx, tx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &oapi.RecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor 使用验证码或恢复码关闭两步验证，被要求开启的管理人员不能关闭.
+func DisableTwoFactor(
+	ctx context.Context,
+	app *config.App,
+	req oapi.DisableTwoFactorRequestObject,
+) error {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+	if twoFactorRequired(app, userClaims.Role) {
+		return common.NewBadRequestError("管理人员必须开启两步验证")
+	}
+
+	twoFactor, err := getEnabledTwoFactor(ctx, app, userClaims.UserID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	limitKey, err := takeSecondFactorAttempt(ctx, app, userClaims.UserID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	factor, valid, err := verifySecondFactor(ctx, tx, twoFactor, req.Body.Code, now)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		if err := recordSessionSecondFactorFailure(ctx, app, userClaims, factor); err != nil {
+			return err
+		}
+		return common.NewBadRequestError(twoFactorInvalidCodeText)
+	}
+	resetSecondFactorAttempts(ctx, app, limitKey)
+	err = user_repo.DeleteTwoFactor(ctx, tx, userClaims.UserID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RegenerateRecoveryCodes 验证后重新生成恢复码，旧恢复码全部作废.
+func RegenerateRecoveryCodes(
+	ctx context.Context,
+	app *config.App,
+	req oapi.RegenerateRecoveryCodesRequestObject,
+) (*oapi.RecoveryCodes, error) {
+	userClaims, ok := middleware.GetUserFromContextOnly(ctx)
+	if !ok {
+		return nil, common.ErrUserNotInContext
+	}
+
+	twoFactor, err := getEnabledTwoFactor(ctx, app, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	limitKey, err := takeSecondFactorAttempt(ctx, app, userClaims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	factor, valid, err := verifySecondFactor(ctx, tx, twoFactor, req.Body.Code, now)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		if err := recordSessionSecondFactorFailure(ctx, app, userClaims, factor); err != nil {
+			return nil, err
+		}
+		return nil, common.NewBadRequestError(twoFactorInvalidCodeText)
+	}
+	resetSecondFactorAttempts(ctx, app, limitKey)
+	codes, err := user_repo.ReplaceRecoveryCodes(ctx, tx, userClaims.UserID, twoFactorRecoveryCodes, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &oapi.RecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+func getEnabledTwoFactor(
+	ctx context.Context,
+	app *config.App,
+	userID int64,
+) (*model.UserTwoFactor, error) {
+	twoFactor, err := user_repo.GetTwoFactor(ctx, app.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil || twoFactor.EnabledAt == nil {
+		return nil, common.NewBadRequestError("未开启两步验证")
+	}
+	return twoFactor, nil
+}
+
+// takeSecondFactorAttempt 校验前先占用一次尝试次数，并发的猜测不能同时绕过次数检查.
+// 登录和账号设置共用次数，返回的键用于验证成功后清零.
+func takeSecondFactorAttempt(ctx context.Context, app *config.App, userID int64) (string, error) {
+	limitKey := fmt.Sprintf("two_factor:%d", userID)
+	result, err := app.Limiter.Allow(ctx, limitKey, ratelimit.Limit{
+		Requests: twoFactorMaxFailures,
+		Window:   twoFactorFailureWindow,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !result.Allowed {
+		return "", common.ErrTooManyAttempts
+	}
+	return limitKey, nil
+}
+
+func resetSecondFactorAttempts(ctx context.Context, app *config.App, limitKey string) {
+	if err := app.Limiter.Reset(ctx, limitKey); err != nil {
+		app.Logger.Warn("Failed to reset two factor attempts", zap.Error(err))
+	}
+}
+
+// recordSecondFactorFailure 失败记入登录日志供审计，不随业务事务回滚.
+func recordSecondFactorFailure(
+	ctx context.Context,
+	app *config.App,
+	userID int64,
+	loginType enum.LoginProvider,
+	factor enum.SecondFactor,
+) error {
+	ip, userAgent := loginClientInfo(ctx)
+	_, err := user_repo.InsertLoginLog(
+		ctx,
+		app.DB,
+		userID,
+		ip,
+		userAgent,
+		loginType,
+		enum.LoginStatusFailed,
+		&factor,
+	)
+	if err != nil {
+		return err
+	}
+	metrics.RecordLogin(loginType, enum.LoginStatusFailed)
+	return nil
+}
+
+// recordSessionSecondFactorFailure 已登录用户验证失败时按当前会话的登录方式记录.
+func recordSessionSecondFactorFailure(
+	ctx context.Context,
+	app *config.App,
+	userClaims *middleware.UserClaims,
+	factor enum.SecondFactor,
+) error {
+	loginType, err := user_repo.GetSessionLoginProvider(ctx, app.DB, userClaims.SessionID)
+	if err != nil {
+		return err
+	}
+	return recordSecondFactorFailure(ctx, app, userClaims.UserID, loginType, factor)
+}
+
+// verifySecondFactor 6 位数字按 TOTP 验证码校验，其余按恢复码核销.
+// 同一时间步的验证码只能使用一次.
+func verifySecondFactor(
+	ctx context.Context,
+	db qrm.DB,
+	twoFactor *model.UserTwoFactor,
+	code string,
+	now time.Time,
+) (enum.SecondFactor, bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != twoFactorTOTPCodeLength {
+		used, err := user_repo.UseRecoveryCode(ctx, db, twoFactor.UserID, strings.ToLower(code))
+		return enum.SecondFactorRecoveryCode, used, err
+	}
+
+	step, valid := util.ValidateTOTP(twoFactor.Secret, code, now)
+	if !valid {
+		return enum.SecondFactorTOTP, false, nil
+	}
+	used, err := user_repo.UseTOTPStep(ctx, db, twoFactor.UserID, step)
+	return enum.SecondFactorTOTP, used, err
+}
+
+// twoFactorRequired 开启强制两步验证时，管理员和版主必须开启.
+func twoFactorRequired(app *config.App, role enum.UserRole) bool {
+	return app.Config.RequireStaffTwoFactor && role != enum.UserRoleUser
+}
+
+func issueTwoFactorChallenge(
+	app *config.App,
+	userID int64,
+	loginType enum.LoginProvider,
+	now time.Time,
+) (*oapi.TwoFactorChallenge, error) {
+	expiresAt := now.Add(twoFactorChallengeTTL)
+	claims := twoFactorChallengeClaims{
+		Type:      twoFactorChallengeType,
+		UserID:    userID,
+		LoginType: loginType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(app.Config.JWTSecret))
+	if err != nil {
+		return nil, err
+	}
+	return &oapi.TwoFactorChallenge{
+		ChallengeToken: signed,
+		ExpiresAt:      expiresAt,
+	}, nil
+}
+
+func parseTwoFactorChallenge(secret, tokenString string) (*twoFactorChallengeClaims, bool) {
+	var claims twoFactorChallengeClaims
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		&claims,
+		func(*jwt.Token) (any, error) { return []byte(secret), nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !token.Valid || claims.Type != twoFactorChallengeType {
+		return nil, false
+	}
+	return &claims, true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/cache"
 	"genshin-quiz/internal/common"
 	"genshin-quiz/internal/dao"
 	"genshin-quiz/internal/dao/transformer"
@@ -32,6 +33,16 @@ func DTOToGender(g oapi.Gender) int16 {
 	}
 }
 
+func DTOToVisibility(v oapi.Visibility) int16 {
+	switch v {
+	case oapi.Public:
+		return 1
+	default:
+		// 未知值按 private 处理，避免意外公开
+		return 0
+	}
+}
+
 func UpdateUser(
 	ctx context.Context,
 	app *config.App,
@@ -75,6 +86,10 @@ func UpdateUser(
 		GenderVisibility:   nil,
 		CountryVisibility:  nil,
 	}
+	if req.Body.LeaderboardVisibility != nil {
+		leaderboardVisibility := DTOToVisibility(*req.Body.LeaderboardVisibility)
+		privaciesParams.LeaderboardVisibility = &leaderboardVisibility
+	}
 
 	tx, err := app.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -99,6 +114,7 @@ func UpdateUser(
 	if err := tx.Commit(); err != nil {
 		return nil, errors.WrapPrefix(err, "failed to commit transaction", 0)
 	}
+	cache.Invalidate(ctx, app.Redis, cache.TagLeaderboard)
 
 	stats, err := user_repo.GetUserStatisticsByID(ctx, app.DB, userInfo.ID)
 	if err != nil {
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"genshin-quiz/internal/common"
+)
+
+// MaxCommentLength 评论最大字数，题目评论和投票评论共用.
+const MaxCommentLength = 2000
+
+// ValidateCommentContent 去除首尾空白并校验评论内容.
+func ValidateCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", common.NewBadRequestError("评论内容不能为空")
+	}
+	if utf8.RuneCountInString(content) > MaxCommentLength {
+		return "", common.NewBadRequestError("评论内容过长")
+	}
+	return content, nil
+}
@@ -72,6 +72,15 @@ func GenerateEmailVerifyLink(domain, rawToken string) string {
 	return buildAuthLink(domain, "/verify-email", rawToken)
 }
 
+func GenerateMagicLoginLink(domain, rawToken string) string {
+	return buildAuthLink(domain, "/magic-login", rawToken)
+}
+
+// GenerateQuestionLink 题目详情页链接，用于邮件通知.
+func GenerateQuestionLink(domain, questionUUID string) string {
+	return strings.TrimSuffix(domain, "/") + "/questions/" + questionUUID
+}
+
 func LanguageOrDefault(lang *string) string {
 	if lang == nil {
 		return "zh-CN"
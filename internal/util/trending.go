@@ -0,0 +1,198 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+const (
+	// TrendingHalfLife 热度半衰期：每过一个半衰期，行为的权重减半
+	TrendingHalfLife = 48 * time.Hour
+	// TrendingWindow 只统计窗口内的行为，更早的权重已可以忽略
+	TrendingWindow = 14 * 24 * time.Hour
+)
+
+// timeDecaySQL 返回按半衰期衰减的权重表达式，column 为 SQL 中的时间列.
+func timeDecaySQL(column string) string {
+	return fmt.Sprintf(
+		"POWER(0.5, EXTRACT(EPOCH FROM (NOW() - %s)) / %d)",
+		column,
+		int64(TrendingHalfLife.Seconds()),
+	)
+}
+
+// qualifiedColumn 返回带表名的列名，用于原生 SQL 片段.
+func qualifiedColumn(column pg.Column) string {
+	return column.TableName() + "." + column.Name()
+}
+
+// TrendingTarget 热度分数写入的表和列.
+type TrendingTarget struct {
+	Table pg.Table
+	ID    pg.ColumnInteger
+	Score pg.ColumnFloat
+}
+
+// TrendingActivity 参与行为（作答、投票等）所在的表.
+// Amount 为每条记录计入的数量，nil 时每条记 1.
+type TrendingActivity struct {
+	Table     pg.Table
+	ID        pg.ColumnInteger
+	UserID    pg.ColumnInteger
+	CreatedAt pg.ColumnTimestampz
+	Amount    pg.ColumnInteger
+}
+
+// TrendingLikes 点赞所在的表，Value 为 1 或 -1.
+type TrendingLikes struct {
+	Table     pg.Table
+	ID        pg.ColumnInteger
+	Value     pg.ColumnInteger
+	UpdatedAt pg.ColumnTimestampz
+}
+
+// TrendingSpec 一类内容的热度计算配置，权重一般为 行为 < 参与人数 < 点赞.
+type TrendingSpec struct {
+	Name              string
+	Target            TrendingTarget
+	Activity          TrendingActivity
+	Likes             TrendingLikes
+	ActivityWeight    float64
+	ParticipantWeight float64
+	LikeWeight        float64
+}
+
+// trendingSource 一类热度行为，Stmt 需返回 id 和 score 两列.
+type trendingSource struct {
+	Name   string
+	Stmt   pg.SelectStatement
+	Weight float64
+}
+
+// RecalculateTrendingScores 按时间衰减重新计算热度，由定时任务调用.
+// 统计窗口内的行为、参与人数（每个用户只按最近一次计算）和点赞（踩为负分）.
+func RecalculateTrendingScores(
+	ctx context.Context,
+	db qrm.DB,
+	spec TrendingSpec,
+) error {
+	activity := spec.Activity
+	likes := spec.Likes
+	since := pg.TimestampzT(time.Now().Add(-TrendingWindow))
+
+	activityScore := timeDecaySQL(qualifiedColumn(activity.CreatedAt))
+	if activity.Amount != nil {
+		activityScore = qualifiedColumn(activity.Amount) + " * " + activityScore
+	}
+	activityStmt := pg.SELECT(
+		activity.ID.AS("id"),
+		pg.RawFloat("SUM("+activityScore+")").AS("score"),
+	).FROM(
+		activity.Table,
+	).WHERE(
+		activity.CreatedAt.GT(since),
+	).GROUP_BY(
+		activity.ID,
+	)
+
+	participants := pg.SELECT(
+		activity.ID,
+		activity.UserID,
+		pg.RawTimestampz("MAX("+qualifiedColumn(activity.CreatedAt)+")").AS("latest"),
+	).FROM(
+		activity.Table,
+	).WHERE(
+		activity.CreatedAt.GT(since),
+	).GROUP_BY(
+		activity.ID,
+		activity.UserID,
+	).AsTable("participants")
+
+	participantID := activity.ID.From(participants)
+	participantStmt := pg.SELECT(
+		participantID.AS("id"),
+		pg.RawFloat("SUM("+timeDecaySQL("participants.latest")+")").AS("score"),
+	).FROM(
+		participants,
+	).GROUP_BY(
+		participantID,
+	)
+
+	likeScore := qualifiedColumn(likes.Value) + " * " + timeDecaySQL(qualifiedColumn(likes.UpdatedAt))
+	likeStmt := pg.SELECT(
+		likes.ID.AS("id"),
+		pg.RawFloat("SUM("+likeScore+")").AS("score"),
+	).FROM(
+		likes.Table,
+	).WHERE(
+		likes.UpdatedAt.GT(since),
+	).GROUP_BY(
+		likes.ID,
+	)
+
+	return writeTrendingScores(ctx, db, spec.Target, []trendingSource{
+		{Name: spec.Name + " activity", Stmt: activityStmt, Weight: spec.ActivityWeight},
+		{Name: spec.Name + " participant", Stmt: participantStmt, Weight: spec.ParticipantWeight},
+		{Name: spec.Name + " like", Stmt: likeStmt, Weight: spec.LikeWeight},
+	})
+}
+
+// writeTrendingScores 按权重汇总各类行为的分数，清零旧分数后一次性写入.
+func writeTrendingScores(
+	ctx context.Context,
+	db qrm.DB,
+	target TrendingTarget,
+	sources []trendingSource,
+) error {
+	scores := make(map[int64]float64)
+	for _, source := range sources {
+		var results []struct {
+			ID    int64   `alias:"id"`
+			Score float64 `alias:"score"`
+		}
+		err := source.Stmt.QueryContext(ctx, db, &results)
+		if err != nil {
+			return errors.WrapPrefix(err, "query "+source.Name+" trending failed", 0)
+		}
+		for _, r := range results {
+			scores[r.ID] += source.Weight * r.Score
+		}
+	}
+
+	// 先清零窗口外的内容，再写入新的分数
+	resetStmt := target.Table.UPDATE(target.Score).
+		SET(pg.Float(0)).
+		WHERE(target.Score.NOT_EQ(pg.Float(0)))
+	_, err := resetStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "reset trending failed", 0)
+	}
+
+	rows := make([]pg.RowExpression, 0, len(scores))
+	for id, score := range scores {
+		if score <= 0 {
+			continue
+		}
+		rows = append(rows, pg.WRAP(pg.Int64(id), pg.CAST(pg.Float(score)).AS_DOUBLE()))
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	values := pg.VALUES(rows...).AS("scores", pg.IntegerColumn("id"), pg.FloatColumn("score"))
+	updateStmt := target.Table.UPDATE(target.Score).
+		SET(pg.FloatColumn("score").From(values)).
+		FROM(values).
+		WHERE(target.ID.EQ(pg.IntegerColumn("id").From(values)))
+	_, err = updateStmt.ExecContext(ctx, db)
+	if err != nil {
+		return errors.WrapPrefix(err, "update trending failed", 0)
+	}
+
+	return nil
+}
@@ -1,8 +1,47 @@
 package util
 
-// 检查用户是否是管理员（保持向后兼容）.
-func IsAdmin(
-	userRole int16,
-) bool {
-	return userRole == 0
+import "genshin-quiz/internal/enum"
+
+// rolePermissions 角色对应的权限，管理员拥有全部权限.
+var rolePermissions = map[enum.UserRole][]enum.Permission{
+	enum.UserRoleUser: {},
+	enum.UserRoleModerator: {
+		enum.PermQuestionDelete,
+		enum.PermQuestionPublish,
+		enum.PermCommentModerate,
+		enum.PermPollDelete,
+		enum.PermReportReview,
+		enum.PermUserSuspend,
+	},
+	enum.UserRoleAdmin: {
+		enum.PermQuestionEdit,
+		enum.PermQuestionDelete,
+		enum.PermQuestionPurge,
+		enum.PermQuestionPublish,
+		enum.PermCommentModerate,
+		enum.PermPollDelete,
+		enum.PermReportReview,
+		enum.PermUserSuspend,
+		enum.PermExamAttemptReview,
+	},
+}
+
+// RolePermissions 获取角色拥有的权限，未知角色没有任何权限.
+func RolePermissions(role enum.UserRole) []enum.Permission {
+	return rolePermissions[role]
+}
+
+// HasPermission 检查角色是否拥有指定权限.
+func HasPermission(role enum.UserRole, permission enum.Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsModerator 管理员和版主都属于管理人员.
+func IsModerator(role enum.UserRole) bool {
+	return role == enum.UserRoleAdmin || role == enum.UserRoleModerator
 }
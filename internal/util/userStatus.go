@@ -0,0 +1,37 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"genshin-quiz/generated/db/genshinquiz/public/model"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+)
+
+// CheckUserStatus 检查账号是否可以登录和访问，封禁已到期的账号视为正常.
+func CheckUserStatus(user model.Users, now time.Time) error {
+	if user.DeletedAt != nil || enum.UserStatus(user.Status) == enum.UserStatusDeleted {
+		return common.ErrAccountDeleted
+	}
+	if enum.UserStatus(user.Status) != enum.UserStatusSuspended {
+		return nil
+	}
+	if user.SuspendedUntil != nil && !user.SuspendedUntil.After(now) {
+		return nil
+	}
+
+	// 带上封禁期限和原因，方便前端提示
+	detail := "永久封禁"
+	if user.SuspendedUntil != nil {
+		detail = fmt.Sprintf("封禁至 %s", user.SuspendedUntil.Format(time.RFC3339))
+	}
+	if user.SuspensionReason != nil && *user.SuspensionReason != "" {
+		detail = fmt.Sprintf("%s，原因：%s", detail, *user.SuspensionReason)
+	}
+	return &common.APIError{
+		Code:    common.ErrAccountSuspended.Code,
+		Message: common.ErrAccountSuspended.Message,
+		Detail:  detail,
+	}
+}
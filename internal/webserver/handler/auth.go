@@ -21,10 +21,13 @@ func (h *Handler) PostLoginUser(
 	ctx context.Context,
 	req oapi.PostLoginUserRequestObject,
 ) (oapi.PostLoginUserResponseObject, error) {
-	res, err := services.LoginUser(ctx, h.app, req)
+	res, challenge, err := services.LoginUser(ctx, h.app, req)
 	if err != nil {
 		return nil, err
 	}
+	if challenge != nil {
+		return (oapi.PostLoginUser202JSONResponse)(*challenge), nil
+	}
 	return (oapi.PostLoginUser200JSONResponse)(*res), nil
 }
 
@@ -82,3 +85,94 @@ func (h *Handler) PostVerifyEmail(
 	}
 	return *res, nil
 }
+
+func (h *Handler) RequestMagicLink(
+	ctx context.Context,
+	req oapi.RequestMagicLinkRequestObject,
+) (oapi.RequestMagicLinkResponseObject, error) {
+	err := services.RequestMagicLink(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.RequestMagicLink202Response{}, nil
+}
+
+func (h *Handler) VerifyMagicLink(
+	ctx context.Context,
+	req oapi.VerifyMagicLinkRequestObject,
+) (oapi.VerifyMagicLinkResponseObject, error) {
+	res, challenge, err := services.VerifyMagicLink(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return (oapi.VerifyMagicLink202JSONResponse)(*challenge), nil
+	}
+	return (oapi.VerifyMagicLink200JSONResponse)(*res), nil
+}
+
+func (h *Handler) VerifyTwoFactorLogin(
+	ctx context.Context,
+	req oapi.VerifyTwoFactorLoginRequestObject,
+) (oapi.VerifyTwoFactorLoginResponseObject, error) {
+	res, err := services.VerifyTwoFactorLogin(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.VerifyTwoFactorLogin200JSONResponse)(*res), nil
+}
+
+func (h *Handler) GetTwoFactorStatus(
+	ctx context.Context,
+	req oapi.GetTwoFactorStatusRequestObject,
+) (oapi.GetTwoFactorStatusResponseObject, error) {
+	res, err := services.GetTwoFactorStatus(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetTwoFactorStatus200JSONResponse)(*res), nil
+}
+
+func (h *Handler) EnrollTwoFactor(
+	ctx context.Context,
+	req oapi.EnrollTwoFactorRequestObject,
+) (oapi.EnrollTwoFactorResponseObject, error) {
+	res, err := services.EnrollTwoFactor(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.EnrollTwoFactor200JSONResponse)(*res), nil
+}
+
+func (h *Handler) ConfirmTwoFactor(
+	ctx context.Context,
+	req oapi.ConfirmTwoFactorRequestObject,
+) (oapi.ConfirmTwoFactorResponseObject, error) {
+	res, err := services.ConfirmTwoFactor(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.ConfirmTwoFactor200JSONResponse)(*res), nil
+}
+
+func (h *Handler) DisableTwoFactor(
+	ctx context.Context,
+	req oapi.DisableTwoFactorRequestObject,
+) (oapi.DisableTwoFactorResponseObject, error) {
+	err := services.DisableTwoFactor(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.DisableTwoFactor204Response{}, nil
+}
+
+func (h *Handler) RegenerateRecoveryCodes(
+	ctx context.Context,
+	req oapi.RegenerateRecoveryCodesRequestObject,
+) (oapi.RegenerateRecoveryCodesResponseObject, error) {
+	res, err := services.RegenerateRecoveryCodes(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.RegenerateRecoveryCodes200JSONResponse)(*res), nil
+}
@@ -3,11 +3,126 @@ package handler
 import (
 	"context"
 	"genshin-quiz/generated/oapi"
+	services "genshin-quiz/internal/services/exam"
 )
 
-func (*Handler) GetExams(
+func (h *Handler) GetExams(
 	ctx context.Context,
 	req oapi.GetExamsRequestObject,
 ) (oapi.GetExamsResponseObject, error) {
-	return (oapi.GetExams200JSONResponse{}), nil
+	res, err := services.GetExams(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
+func (h *Handler) GetExam(
+	ctx context.Context,
+	req oapi.GetExamRequestObject,
+) (oapi.GetExamResponseObject, error) {
+	res, err := services.GetExam(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetExam200JSONResponse)(*res), nil
+}
+
+func (h *Handler) PostCreateExam(
+	ctx context.Context,
+	req oapi.PostCreateExamRequestObject,
+) (oapi.PostCreateExamResponseObject, error) {
+	res, err := services.PostCreateExam(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostCreateExam201JSONResponse)(*res), nil
+}
+
+func (h *Handler) PostGenerateExam(
+	ctx context.Context,
+	req oapi.PostGenerateExamRequestObject,
+) (oapi.PostGenerateExamResponseObject, error) {
+	res, err := services.PostGenerateExam(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostGenerateExam201JSONResponse)(*res), nil
+}
+
+func (h *Handler) DeleteExam(
+	ctx context.Context,
+	req oapi.DeleteExamRequestObject,
+) (oapi.DeleteExamResponseObject, error) {
+	err := services.DeleteExam(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.DeleteExam204Response{}, nil
+}
+
+func (h *Handler) PostLikeExam(
+	ctx context.Context,
+	req oapi.PostLikeExamRequestObject,
+) (oapi.PostLikeExamResponseObject, error) {
+	err := services.PostLikeExam(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.PostLikeExam201Response{}, nil
+}
+
+func (h *Handler) GetExamLeaderboard(
+	ctx context.Context,
+	req oapi.GetExamLeaderboardRequestObject,
+) (oapi.GetExamLeaderboardResponseObject, error) {
+	res, err := services.GetExamLeaderboard(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetExamLeaderboard200JSONResponse)(*res), nil
+}
+
+func (h *Handler) PostExamAccess(
+	ctx context.Context,
+	req oapi.PostExamAccessRequestObject,
+) (oapi.PostExamAccessResponseObject, error) {
+	res, err := services.PostExamAccess(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostExamAccess200JSONResponse)(*res), nil
+}
+
+func (h *Handler) PostStartExamAttempt(
+	ctx context.Context,
+	req oapi.PostStartExamAttemptRequestObject,
+) (oapi.PostStartExamAttemptResponseObject, error) {
+	res, err := services.PostStartExamAttempt(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostStartExamAttempt201JSONResponse)(*res), nil
+}
+
+func (h *Handler) GetExamAttempt(
+	ctx context.Context,
+	req oapi.GetExamAttemptRequestObject,
+) (oapi.GetExamAttemptResponseObject, error) {
+	res, err := services.GetExamAttempt(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetExamAttempt200JSONResponse)(*res), nil
+}
+
+func (h *Handler) PostSubmitExamAttempt(
+	ctx context.Context,
+	req oapi.PostSubmitExamAttemptRequestObject,
+) (oapi.PostSubmitExamAttemptResponseObject, error) {
+	res, err := services.PostSubmitExamAttempt(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostSubmitExamAttempt200JSONResponse)(*res), nil
 }
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"genshin-quiz/generated/oapi"
+
+	services "genshin-quiz/internal/services/moderation"
+)
+
+func (h *Handler) PostReport(
+	ctx context.Context,
+	req oapi.PostReportRequestObject,
+) (oapi.PostReportResponseObject, error) {
+	err := services.PostReport(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.PostReport204Response{}, nil
+}
+
+func (h *Handler) GetReportQueue(
+	ctx context.Context,
+	req oapi.GetReportQueueRequestObject,
+) (oapi.GetReportQueueResponseObject, error) {
+	res, err := services.GetReportQueue(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
+func (h *Handler) ResolveReport(
+	ctx context.Context,
+	req oapi.ResolveReportRequestObject,
+) (oapi.ResolveReportResponseObject, error) {
+	res, err := services.ResolveReport(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.ResolveReport200JSONResponse)(*res), nil
+}
+
+func (h *Handler) SuspendUser(
+	ctx context.Context,
+	req oapi.SuspendUserRequestObject,
+) (oapi.SuspendUserResponseObject, error) {
+	err := services.SuspendUser(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.SuspendUser204Response{}, nil
+}
+
+func (h *Handler) UnsuspendUser(
+	ctx context.Context,
+	req oapi.UnsuspendUserRequestObject,
+) (oapi.UnsuspendUserResponseObject, error) {
+	err := services.UnsuspendUser(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.UnsuspendUser204Response{}, nil
+}
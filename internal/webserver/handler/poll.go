@@ -61,3 +61,69 @@ func (h *Handler) PostLikePoll(
 	}
 	return oapi.PostLikePoll201Response{}, nil
 }
+
+func (h *Handler) GetPollComments(
+	ctx context.Context,
+	req oapi.GetPollCommentsRequestObject,
+) (oapi.GetPollCommentsResponseObject, error) {
+	res, err := services.GetPollComments(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetPollComments200JSONResponse)(*res), nil
+}
+
+func (h *Handler) PostPollComment(
+	ctx context.Context,
+	req oapi.PostPollCommentRequestObject,
+) (oapi.PostPollCommentResponseObject, error) {
+	res, err := services.PostPollComment(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostPollComment201JSONResponse)(*res), nil
+}
+
+func (h *Handler) UpdatePollComment(
+	ctx context.Context,
+	req oapi.UpdatePollCommentRequestObject,
+) (oapi.UpdatePollCommentResponseObject, error) {
+	res, err := services.UpdatePollComment(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.UpdatePollComment200JSONResponse)(*res), nil
+}
+
+func (h *Handler) DeletePollComment(
+	ctx context.Context,
+	req oapi.DeletePollCommentRequestObject,
+) (oapi.DeletePollCommentResponseObject, error) {
+	err := services.DeletePollComment(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.DeletePollComment204Response{}, nil
+}
+
+func (h *Handler) AddPollCommentReaction(
+	ctx context.Context,
+	req oapi.AddPollCommentReactionRequestObject,
+) (oapi.AddPollCommentReactionResponseObject, error) {
+	err := services.AddPollCommentReaction(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.AddPollCommentReaction204Response{}, nil
+}
+
+func (h *Handler) RemovePollCommentReaction(
+	ctx context.Context,
+	req oapi.RemovePollCommentReactionRequestObject,
+) (oapi.RemovePollCommentReactionResponseObject, error) {
+	err := services.RemovePollCommentReaction(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.RemovePollCommentReaction204Response{}, nil
+}
@@ -84,3 +84,146 @@ func (h *Handler) PostLikeQuestion(
 	}
 	return oapi.PostLikeQuestion201Response{}, nil
 }
+
+func (h *Handler) UpdateQuestion(
+	ctx context.Context,
+	req oapi.UpdateQuestionRequestObject,
+) (oapi.UpdateQuestionResponseObject, error) {
+	res, err := services.UpdateQuestion(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.UpdateQuestion200JSONResponse)(*res), nil
+}
+
+func (h *Handler) GetQuestionRevisions(
+	ctx context.Context,
+	req oapi.GetQuestionRevisionsRequestObject,
+) (oapi.GetQuestionRevisionsResponseObject, error) {
+	res, err := services.GetQuestionRevisions(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetQuestionRevisions200JSONResponse)(res), nil
+}
+
+func (h *Handler) GetQuestionRevisionDiff(
+	ctx context.Context,
+	req oapi.GetQuestionRevisionDiffRequestObject,
+) (oapi.GetQuestionRevisionDiffResponseObject, error) {
+	res, err := services.GetQuestionRevisionDiff(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetQuestionRevisionDiff200JSONResponse)(*res), nil
+}
+
+func (h *Handler) DeleteQuestion(
+	ctx context.Context,
+	req oapi.DeleteQuestionRequestObject,
+) (oapi.DeleteQuestionResponseObject, error) {
+	err := services.DeleteQuestion(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.DeleteQuestion204Response{}, nil
+}
+
+func (h *Handler) RestoreQuestion(
+	ctx context.Context,
+	req oapi.RestoreQuestionRequestObject,
+) (oapi.RestoreQuestionResponseObject, error) {
+	err := services.RestoreQuestion(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.RestoreQuestion204Response{}, nil
+}
+
+func (h *Handler) PurgeQuestion(
+	ctx context.Context,
+	req oapi.PurgeQuestionRequestObject,
+) (oapi.PurgeQuestionResponseObject, error) {
+	err := services.PurgeQuestion(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.PurgeQuestion204Response{}, nil
+}
+
+func (h *Handler) PostQuestionReviewRequest(
+	ctx context.Context,
+	req oapi.PostQuestionReviewRequestRequestObject,
+) (oapi.PostQuestionReviewRequestResponseObject, error) {
+	res, err := services.PostQuestionReviewRequest(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostQuestionReviewRequest200JSONResponse)(*res), nil
+}
+
+func (h *Handler) PostQuestionReview(
+	ctx context.Context,
+	req oapi.PostQuestionReviewRequestObject,
+) (oapi.PostQuestionReviewResponseObject, error) {
+	res, err := services.PostQuestionReview(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostQuestionReview200JSONResponse)(*res), nil
+}
+
+func (h *Handler) GetQuestionReviewQueue(
+	ctx context.Context,
+	req oapi.GetQuestionReviewQueueRequestObject,
+) (oapi.GetQuestionReviewQueueResponseObject, error) {
+	res, err := services.GetQuestionReviewQueue(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
+func (h *Handler) GetQuestionComments(
+	ctx context.Context,
+	req oapi.GetQuestionCommentsRequestObject,
+) (oapi.GetQuestionCommentsResponseObject, error) {
+	res, err := services.GetQuestionComments(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetQuestionComments200JSONResponse)(*res), nil
+}
+
+func (h *Handler) PostQuestionComment(
+	ctx context.Context,
+	req oapi.PostQuestionCommentRequestObject,
+) (oapi.PostQuestionCommentResponseObject, error) {
+	res, err := services.PostQuestionComment(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.PostQuestionComment201JSONResponse)(*res), nil
+}
+
+func (h *Handler) UpdateQuestionComment(
+	ctx context.Context,
+	req oapi.UpdateQuestionCommentRequestObject,
+) (oapi.UpdateQuestionCommentResponseObject, error) {
+	res, err := services.UpdateQuestionComment(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.UpdateQuestionComment200JSONResponse)(*res), nil
+}
+
+func (h *Handler) DeleteQuestionComment(
+	ctx context.Context,
+	req oapi.DeleteQuestionCommentRequestObject,
+) (oapi.DeleteQuestionCommentResponseObject, error) {
+	err := services.DeleteQuestionComment(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.DeleteQuestionComment204Response{}, nil
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"genshin-quiz/generated/oapi"
 	poll_services "genshin-quiz/internal/services/poll"
@@ -52,6 +53,166 @@ func (h *Handler) UpdateUser(
 	return (oapi.UpdateUser200JSONResponse)(*res), nil
 }
 
+func (h *Handler) RefreshToken(
+	ctx context.Context,
+	req oapi.RefreshTokenRequestObject,
+) (oapi.RefreshTokenResponseObject, error) {
+	res, err := services.RefreshToken(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.RefreshToken200JSONResponse)(*res), nil
+}
+
+func (h *Handler) LogoutUser(
+	ctx context.Context,
+	req oapi.LogoutUserRequestObject,
+) (oapi.LogoutUserResponseObject, error) {
+	err := services.LogoutUser(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.LogoutUser204Response{}, nil
+}
+
+func (h *Handler) GetSessions(
+	ctx context.Context,
+	req oapi.GetSessionsRequestObject,
+) (oapi.GetSessionsResponseObject, error) {
+	res, err := services.GetSessions(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetSessions200JSONResponse)(res), nil
+}
+
+func (h *Handler) RevokeSession(
+	ctx context.Context,
+	req oapi.RevokeSessionRequestObject,
+) (oapi.RevokeSessionResponseObject, error) {
+	err := services.RevokeSession(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.RevokeSession204Response{}, nil
+}
+
+func (h *Handler) RevokeAllSessions(
+	ctx context.Context,
+	req oapi.RevokeAllSessionsRequestObject,
+) (oapi.RevokeAllSessionsResponseObject, error) {
+	err := services.RevokeAllSessions(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.RevokeAllSessions204Response{}, nil
+}
+
+func (h *Handler) OAuthAuthorize(
+	ctx context.Context,
+	req oapi.OAuthAuthorizeRequestObject,
+) (oapi.OAuthAuthorizeResponseObject, error) {
+	res, err := services.OAuthAuthorize(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.OAuthAuthorize200JSONResponse)(*res), nil
+}
+
+func (h *Handler) OAuthCallback(
+	ctx context.Context,
+	req oapi.OAuthCallbackRequestObject,
+) (oapi.OAuthCallbackResponseObject, error) {
+	res, challenge, err := services.OAuthCallback(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return (oapi.OAuthCallback202JSONResponse)(*challenge), nil
+	}
+	return (oapi.OAuthCallback200JSONResponse)(*res), nil
+}
+
+func (h *Handler) GetLinkedIdentities(
+	ctx context.Context,
+	req oapi.GetLinkedIdentitiesRequestObject,
+) (oapi.GetLinkedIdentitiesResponseObject, error) {
+	res, err := services.GetLinkedIdentities(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetLinkedIdentities200JSONResponse)(res), nil
+}
+
+func (h *Handler) UnlinkIdentity(
+	ctx context.Context,
+	req oapi.UnlinkIdentityRequestObject,
+) (oapi.UnlinkIdentityResponseObject, error) {
+	err := services.UnlinkIdentity(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.UnlinkIdentity204Response{}, nil
+}
+
+func (h *Handler) DeleteUser(
+	ctx context.Context,
+	req oapi.DeleteUserRequestObject,
+) (oapi.DeleteUserResponseObject, error) {
+	err := services.DeleteUser(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.DeleteUser204Response{}, nil
+}
+
+func (h *Handler) CancelUserDeletion(
+	ctx context.Context,
+	req oapi.CancelUserDeletionRequestObject,
+) (oapi.CancelUserDeletionResponseObject, error) {
+	err := services.CancelUserDeletion(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.CancelUserDeletion204Response{}, nil
+}
+
+func (h *Handler) RequestDataExport(
+	ctx context.Context,
+	req oapi.RequestDataExportRequestObject,
+) (oapi.RequestDataExportResponseObject, error) {
+	res, err := services.RequestDataExport(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.RequestDataExport202JSONResponse)(*res), nil
+}
+
+func (h *Handler) GetDataExport(
+	ctx context.Context,
+	req oapi.GetDataExportRequestObject,
+) (oapi.GetDataExportResponseObject, error) {
+	res, err := services.GetDataExport(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return (oapi.GetDataExport200JSONResponse)(*res), nil
+}
+
+func (h *Handler) DownloadDataExport(
+	ctx context.Context,
+	req oapi.DownloadDataExportRequestObject,
+) (oapi.DownloadDataExportResponseObject, error) {
+	data, err := services.DownloadDataExport(ctx, h.app, req)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.DownloadDataExport200ApplicationoctetStreamResponse{
+		Body:          bytes.NewReader(data),
+		ContentLength: int64(len(data)),
+	}, nil
+}
+
 func (h *Handler) GetUserPolls(
 	ctx context.Context,
 	req oapi.GetUserPollsRequestObject,
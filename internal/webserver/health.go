@@ -0,0 +1,76 @@
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"genshin-quiz/config"
+	"genshin-quiz/internal/health"
+)
+
+// healthRoutes 存活和就绪探针.
+// live 只说明进程仍在运行，不检查依赖；ready 检查依赖，失败时负载均衡不再转发请求.
+func healthRoutes(app *config.App) func(r chi.Router) {
+	checker := health.NewChecker(app)
+
+	writeJSON := func(w http.ResponseWriter, code int, body any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			app.Logger.Error("Failed to write health check response", zap.Error(err))
+		}
+	}
+
+	// rootStatus GET 和 HEAD 共用，退出过程中返回 503，负载均衡不再转发新请求
+	rootStatus := func() (string, int) {
+		if !app.Lifecycle.Ready() {
+			return health.StatusShuttingDown, http.StatusServiceUnavailable
+		}
+		return health.StatusOK, http.StatusOK
+	}
+
+	return func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			status, code := rootStatus()
+			writeJSON(w, code, map[string]any{"status": status, "timestamp": time.Now().Format(time.RFC3339)})
+		})
+		r.Head("/", func(w http.ResponseWriter, r *http.Request) {
+			_, code := rootStatus()
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Cache-Control", "no-store")
+			w.WriteHeader(code)
+		})
+
+		r.Get("/live", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"status":    health.StatusOK,
+				"version":   app.Config.Version,
+				"timestamp": time.Now().Format(time.RFC3339),
+			})
+		})
+
+		r.Get("/ready", func(w http.ResponseWriter, r *http.Request) {
+			// 开始退出后立即返回未就绪，不再检查依赖
+			if !app.Lifecycle.Ready() {
+				writeJSON(w, http.StatusServiceUnavailable, health.Report{
+					Status:    health.StatusShuttingDown,
+					Version:   app.Config.Version,
+					Timestamp: time.Now(),
+				})
+				return
+			}
+
+			report := checker.Ready(r.Context())
+			code := http.StatusOK
+			if report.Status == health.StatusFail {
+				code = http.StatusServiceUnavailable
+			}
+			writeJSON(w, code, report)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
 	user_repo "genshin-quiz/internal/repository/user"
 	"genshin-quiz/internal/util"
 	"net/http"
@@ -13,11 +14,17 @@ import (
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/go-jet/jet/v2/qrm"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type UserClaims struct {
 	UserID int64  `json:"user_id"`
 	Email  string `json:"email"`
+	// 所属登录会话，会话注销后 token 立即失效
+	SessionID uuid.UUID `json:"sid"`
+	// 角色和权限每次请求从数据库读取，不写入 token
+	Role        enum.UserRole     `json:"-"`
+	Permissions []enum.Permission `json:"-"`
 	jwt.RegisteredClaims
 }
 
@@ -27,7 +34,7 @@ func JWTAuth(
 	jwtSecret string,
 	db qrm.DB,
 	requireToken bool,
-	requireAdmin bool,
+	requireStaffTwoFactor bool,
 ) func(http.Handler) http.Handler {
 	tokenAuth := jwtauth.New("HS256", []byte(jwtSecret), nil)
 
@@ -48,7 +55,7 @@ func JWTAuth(
 			}
 
 			// 提供了token，则需要验证
-			userClaims, err := parseAndValidateToken(r, tokenAuth, db, requireAdmin)
+			userClaims, err := parseAndValidateToken(r, tokenAuth, db, requireStaffTwoFactor)
 			if err != nil {
 				// token无效 - 不管是强制还是可选认证都应该报错
 				handleAuthError(w, err)
@@ -66,7 +73,7 @@ func parseAndValidateToken(
 	r *http.Request,
 	tokenAuth *jwtauth.JWTAuth,
 	db qrm.DB,
-	requireAdmin bool,
+	requireStaffTwoFactor bool,
 ) (*UserClaims, error) {
 	// Extract Bearer token
 	authHeader := r.Header.Get("Authorization")
@@ -92,6 +99,15 @@ func parseAndValidateToken(
 		return nil, common.ErrInvalidToken
 	}
 
+	var sid string
+	if err := token.Get("sid", &sid); err != nil {
+		return nil, common.ErrInvalidToken
+	}
+	sessionID, err := uuid.Parse(sid)
+	if err != nil {
+		return nil, common.ErrInvalidToken
+	}
+
 	// 检查用户是否仍然存在于数据库中，并获取用户信息包括角色
 	userInfo, err := user_repo.GetUserInfoByID(r.Context(), db, int64(userIDFloat))
 	if err != nil {
@@ -100,14 +116,32 @@ func parseAndValidateToken(
 		}
 		return nil, err
 	}
+	// 被封禁或已注销的账号即使 token 未过期也拒绝访问
+	if err := util.CheckUserStatus(*userInfo, time.Now()); err != nil {
+		if errors.Is(err, common.ErrAccountDeleted) {
+			return nil, common.ErrUserNotFound
+		}
+		return nil, err
+	}
 
-	if requireAdmin && util.IsAdmin(userInfo.UserRole) {
-		return nil, common.ErrAdminAuthError
+	// 会话已注销（退出登录、修改密码等）或已过期
+	session, err := user_repo.GetActiveSession(r.Context(), db, userInfo.ID, sessionID, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
+	role := enum.UserRole(userInfo.UserRole)
+	permissions := util.RolePermissions(role)
+	// 强制管理人员两步验证时，未经两步验证的会话只有普通用户权限
+	if requireStaffTwoFactor && role != enum.UserRoleUser && !session.TwoFactorVerified {
+		permissions = util.RolePermissions(enum.UserRoleUser)
+	}
 	return &UserClaims{
-		UserID: int64(userIDFloat),
-		Email:  email,
+		UserID:      int64(userIDFloat),
+		Email:       email,
+		SessionID:   sessionID,
+		Role:        role,
+		Permissions: permissions,
 	}, nil
 }
 
@@ -124,6 +158,31 @@ func handleAuthError(w http.ResponseWriter, err error) {
 		return
 	}
 
+	if errors.Is(err, common.ErrSessionNotFound) {
+		writeErrorResponse(
+			w,
+			http.StatusUnauthorized,
+			"Session has been revoked",
+			"SESSION_REVOKED",
+			"Your session has ended. Please login again.",
+			true, // 强制登出
+		)
+		return
+	}
+
+	var apiErr *common.APIError
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden {
+		writeErrorResponse(
+			w,
+			http.StatusForbidden,
+			apiErr.Message,
+			"USER_SUSPENDED",
+			apiErr.Detail,
+			true, // 强制登出
+		)
+		return
+	}
+
 	if errors.Is(err, common.ErrDatabaseError) {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
@@ -148,32 +207,39 @@ func GetUserFromContextOnly(ctx context.Context) (*UserClaims, bool) {
 	return &user, ok
 }
 
-func GenerateJWT(userID int64, email, secret string) (string, error) {
+// GenerateJWT 签发访问令牌，过期后使用刷新令牌换取.
+func GenerateJWT(
+	userID int64,
+	email string,
+	sessionID uuid.UUID,
+	secret string,
+	expiresAt time.Time,
+) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+		"sid":     sessionID.String(),
+		"exp":     expiresAt.Unix(),
 	})
 
 	return token.SignedString([]byte(secret))
 }
 
 // RequiredJWTAuth 强制JWT认证中间件.
-func RequiredJWTAuth(jwtSecret string, db qrm.DB) func(http.Handler) http.Handler {
-	return JWTAuth(jwtSecret, db, true, false)
-}
-
-// RequiredAdminJWTAuth 强制管理员JWT认证中间件.
-func RequiredAdminJWTAuth(jwtSecret string, db qrm.DB) func(http.Handler) http.Handler {
-	return JWTAuth(jwtSecret, db, true, true)
+func RequiredJWTAuth(jwtSecret string, db qrm.DB, requireStaffTwoFactor bool) func(http.Handler) http.Handler {
+	return JWTAuth(jwtSecret, db, true, requireStaffTwoFactor)
 }
 
 // OptionalJWTAuth 可选JWT认证中间件.
-func OptionalJWTAuth(jwtSecret string, db qrm.DB) func(http.Handler) http.Handler {
-	return JWTAuth(jwtSecret, db, false, false)
+func OptionalJWTAuth(jwtSecret string, db qrm.DB, requireStaffTwoFactor bool) func(http.Handler) http.Handler {
+	return JWTAuth(jwtSecret, db, false, requireStaffTwoFactor)
 }
 
-func ConditionalJWTAuth(jwtSecret string, db qrm.DB) func(http.Handler) http.Handler {
+func ConditionalJWTAuth(
+	jwtSecret string,
+	db qrm.DB,
+	requireStaffTwoFactor bool,
+) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			path := r.URL.Path
@@ -182,12 +248,12 @@ func ConditionalJWTAuth(jwtSecret string, db qrm.DB) func(http.Handler) http.Han
 			// 检查是否是公开端点
 			if isPublicEndpoint(path, method) {
 				// 公开端点，使用可选认证（不强制要求token，但如果有token会解析）
-				OptionalJWTAuth(jwtSecret, db)(next).ServeHTTP(w, r)
+				OptionalJWTAuth(jwtSecret, db, requireStaffTwoFactor)(next).ServeHTTP(w, r)
 				return
 			}
 
 			// 需要认证的端点，使用强制认证流程
-			RequiredJWTAuth(jwtSecret, db)(next).ServeHTTP(w, r)
+			RequiredJWTAuth(jwtSecret, db, requireStaffTwoFactor)(next).ServeHTTP(w, r)
 		})
 	}
 }
@@ -198,9 +264,14 @@ func isPublicEndpoint(path, method string) bool {
 		// 认证相关 - 不需要认证
 		"/auth/register":        {"POST"},
 		"/auth/login":           {"POST"},
+		"/auth/refresh":         {"POST"},
 		"/auth/forgot-password": {"POST"},
 		"/auth/reset-password":  {"POST"},
 		"/auth/verify-email":    {"POST"},
+		"/auth/magic-link":      {"POST"},
+		"/auth/magic-link/*":    {"POST"},
+		"/auth/2fa/verify":      {"POST"},
+		"/auth/oauth/*":         {"POST"}, // 第三方登录，绑定时可选携带 token
 
 		// 公开的只读API - 不需要认证
 		"/home":        {"GET"},
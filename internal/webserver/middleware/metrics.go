@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/metrics"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type operationContextKey struct{}
+
+// Metrics 记录请求数和耗时.
+// 放在错误处理中间件外层，panic 转换成的 500 也能被统计；operation 由 MetricsOperationMiddleware 填写.
+func Metrics() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			operation := new(string)
+
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				metrics.ObserveHTTPRequest(*operation, r.Method, status, time.Since(start))
+			}()
+
+			ctx := context.WithValue(r.Context(), operationContextKey{}, operation)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// MetricsOperationMiddleware 把 oapi operation ID 交给 Metrics 作为标签.
+func MetricsOperationMiddleware() oapi.StrictMiddlewareFunc {
+	return func(f oapi.StrictHandlerFunc, operationID string) oapi.StrictHandlerFunc {
+		return func(
+			ctx context.Context,
+			w http.ResponseWriter,
+			r *http.Request,
+			request any,
+		) (any, error) {
+			if operation, ok := ctx.Value(operationContextKey{}).(*string); ok {
+				*operation = operationID
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/enum"
+)
+
+// operationPermissions 接口需要的权限，按 oapi operation ID 配置.
+// 只有与资源归属无关的接口在这里声明，"作者或管理人员" 的接口由服务层使用 RequireOwnerOrPermission 检查.
+var operationPermissions = map[string]enum.Permission{
+	"GetQuestionReviewQueue": enum.PermQuestionPublish,
+	"PostQuestionReview":     enum.PermQuestionPublish,
+	"PurgeQuestion":          enum.PermQuestionPurge,
+	"GetReportQueue":         enum.PermReportReview,
+	"ResolveReport":          enum.PermReportReview,
+	"SuspendUser":            enum.PermUserSuspend,
+	"UnsuspendUser":          enum.PermUserSuspend,
+}
+
+// PermissionMiddleware 检查当前用户是否拥有接口声明的权限.
+func PermissionMiddleware() oapi.StrictMiddlewareFunc {
+	return func(f oapi.StrictHandlerFunc, operationID string) oapi.StrictHandlerFunc {
+		permission, exists := operationPermissions[operationID]
+		if !exists {
+			return f
+		}
+		return func(
+			ctx context.Context,
+			w http.ResponseWriter,
+			r *http.Request,
+			request any,
+		) (any, error) {
+			if err := RequirePermission(ctx, permission); err != nil {
+				return nil, err
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
+
+// HasPermission 检查当前用户是否拥有指定权限，未登录时返回 false.
+func HasPermission(ctx context.Context, permission enum.Permission) bool {
+	userClaims, ok := GetUserFromContextOnly(ctx)
+	if !ok {
+		return false
+	}
+	for _, p := range userClaims.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission 当前用户必须拥有指定权限.
+func RequirePermission(ctx context.Context, permission enum.Permission) error {
+	if _, ok := GetUserFromContextOnly(ctx); !ok {
+		return common.ErrUserNotInContext
+	}
+	if !HasPermission(ctx, permission) {
+		return common.ErrPermissionDenied
+	}
+	return nil
+}
+
+// RequireOwnerOrPermission 资源作者本人或拥有指定权限的管理人员才能操作.
+func RequireOwnerOrPermission(ctx context.Context, ownerID int64, permission enum.Permission) error {
+	userClaims, ok := GetUserFromContextOnly(ctx)
+	if !ok {
+		return common.ErrUserNotInContext
+	}
+	if userClaims.UserID == ownerID {
+		return nil
+	}
+	if !HasPermission(ctx, permission) {
+		return common.ErrPermissionDenied
+	}
+	return nil
+}
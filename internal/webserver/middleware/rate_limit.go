@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"genshin-quiz/config"
+	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/common"
+	"genshin-quiz/internal/ratelimit"
+
+	"go.uber.org/zap"
+)
+
+// operationRateLimits 接口的限流配置，按 oapi operation ID 配置.
+// 登录用户按用户 ID 计数，未登录按 IP 计数.
+var operationRateLimits = map[string]ratelimit.Limit{
+	// 防止暴力破解
+	"PostLoginUser":        {Requests: 10, Window: time.Minute},
+	"VerifyTwoFactorLogin": {Requests: 10, Window: time.Minute},
+	"PostExamAccess":       {Requests: 10, Window: time.Minute},
+	"PostResetPassword":    {Requests: 10, Window: time.Minute},
+	"PostVerifyEmail":      {Requests: 10, Window: time.Minute},
+	"VerifyMagicLink":      {Requests: 10, Window: time.Minute},
+	"OAuthCallback":        {Requests: 20, Window: time.Minute},
+	"RefreshToken":         {Requests: 30, Window: time.Minute},
+	// 会发送邮件或创建账号的接口
+	"PostRegisterUser":          {Requests: 5, Window: time.Hour},
+	"PostForgotPassword":        {Requests: 5, Window: time.Hour},
+	"PostSendVerificationEmail": {Requests: 5, Window: time.Hour},
+	"RequestMagicLink":          {Requests: 10, Window: time.Hour},
+	// 防止脚本刷题、刷票和刷评论
+	"PostSubmitAnswer":      {Requests: 30, Window: time.Minute},
+	"PostSubmitExamAttempt": {Requests: 10, Window: time.Minute},
+	"PostVotePoll":          {Requests: 30, Window: time.Minute},
+	"PostQuestionComment":   {Requests: 10, Window: time.Minute},
+	"PostPollComment":       {Requests: 10, Window: time.Minute},
+	"PostReport":            {Requests: 10, Window: time.Minute},
+	"RequestDataExport":     {Requests: 3, Window: time.Hour},
+}
+
+// RateLimitMiddleware 按接口限流，响应中带 RateLimit-* 头，超限时返回 429 和 Retry-After.
+// 未配置 Redis 时使用进程内计数；Redis 出错时放行，避免限流故障影响正常请求.
+func RateLimitMiddleware(app *config.App) oapi.StrictMiddlewareFunc {
+	limiter := app.Limiter
+
+	return func(f oapi.StrictHandlerFunc, operationID string) oapi.StrictHandlerFunc {
+		limit, exists := operationRateLimits[operationID]
+		if !exists {
+			return f
+		}
+		return func(
+			ctx context.Context,
+			w http.ResponseWriter,
+			r *http.Request,
+			request any,
+		) (any, error) {
+			key := operationID + ":" + rateLimitSubject(ctx)
+			result, err := limiter.Allow(ctx, key, limit)
+			if err != nil {
+				app.Logger.Warn("Rate limit check failed", zap.String("operation", operationID), zap.Error(err))
+				return f(ctx, w, r, request)
+			}
+
+			writeRateLimitHeaders(w, limit, result)
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+				return nil, common.ErrRateLimited
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
+
+// rateLimitSubject 登录用户按用户 ID，未登录按 SecureRealIP 解析的 IP.
+func rateLimitSubject(ctx context.Context) string {
+	if userClaims, ok := GetUserFromContextOnly(ctx); ok {
+		return "u:" + strconv.FormatInt(userClaims.UserID, 10)
+	}
+	ip, _ := ctx.Value(RealIPKey).(string)
+	return "ip:" + ip
+}
+
+func writeRateLimitHeaders(w http.ResponseWriter, limit ratelimit.Limit, result ratelimit.Result) {
+	header := w.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, ceilSeconds(limit.Window)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -1,8 +1,9 @@
 package webserver
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"net"
 	"net/http"
 	"time"
 
@@ -14,11 +15,13 @@ import (
 
 	"genshin-quiz/config"
 	"genshin-quiz/generated/oapi"
+	"genshin-quiz/internal/metrics"
 	"genshin-quiz/internal/webserver/handler"
 	mw "genshin-quiz/internal/webserver/middleware"
 )
 
 type Server struct {
+	app        *config.App
 	router     *chi.Mux
 	serverAddr string
 }
@@ -31,42 +34,40 @@ func NewServer(app *config.App) *Server {
 	r.Use(middleware.RequestID)
 	r.Use(mw.SecureRealIP)
 	r.Use(mw.Logger(app.Logger))
+	r.Use(mw.Metrics())
 	// 使用自定义的错误处理中间件，替代 chi 的 Recoverer
 	r.Use(mw.Handler(app))
 	r.Use(middleware.Timeout(60 * time.Second))
 
 	// CORS configuration
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Exam-Access-Token"},
+		ExposedHeaders: []string{
+			"Link",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"RateLimit-Policy",
+			"Retry-After",
+		},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
 	// Health check endpoint - 必须在 OpenAPI 路由之前定义，避免被覆盖
-	r.Route("/health", func(r chi.Router) {
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			_, err := w.Write(
-				[]byte(`{"status":"ok","timestamp":"` + time.Now().Format(time.RFC3339) + `"}`),
-			)
-			if err != nil {
-				app.Logger.Error("Failed to write health check response", zap.Error(err))
-			}
-		})
-		r.Head("/", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-		})
-	})
+	r.Route("/health", healthRoutes(app))
+
+	// 没有独立管理端口时，配置了令牌才在主端口暴露指标
+	if app.Config.MetricsAddr == "" && app.Config.MetricsToken != "" {
+		r.Method(http.MethodGet, "/metrics", metrics.Handler(app.Config.MetricsToken))
+	}
 
 	// Setup API routes - 使用条件认证中间件，根据路径决定是否需要认证
 	r.Group(func(r chi.Router) {
 		// JWT 认证中间件
-		r.Use(mw.ConditionalJWTAuth(app.Config.JWTSecret, app.DB))
+		r.Use(mw.ConditionalJWTAuth(app.Config.JWTSecret, app.DB, app.Config.RequireStaffTwoFactor))
 
 		baseURL := ""
 		serverOptions := oapi.StrictHTTPServerOptions{
@@ -75,20 +76,28 @@ func NewServer(app *config.App) *Server {
 		}
 		strictHandler := oapi.NewStrictHandlerWithOptions(
 			handler.NewHandler(app),
-			[]oapi.StrictMiddlewareFunc{},
+			// 靠后的中间件在外层，先记录 operation，再限流，最后检查权限
+			[]oapi.StrictMiddlewareFunc{
+				mw.PermissionMiddleware(),
+				mw.RateLimitMiddleware(app),
+				mw.MetricsOperationMiddleware(),
+			},
 			serverOptions,
 		)
 		oapi.HandlerFromMuxWithBaseURL(strictHandler, r, baseURL)
 	})
 
 	return &Server{
+		app:        app,
 		router:     r,
 		serverAddr: fmt.Sprintf("%s:%s", app.Server.Host, app.Server.Port),
 	}
 }
 
+// Start 启动 HTTP 服务并阻塞，收到 SIGINT/SIGTERM 后停止接收新连接，
+// 在退出期限内等待进行中的请求完成，然后关闭其余资源.
 func (s *Server) Start() {
-	log.Print("Starting server", zap.String("addr", s.serverAddr))
+	s.app.Logger.Info("Starting server", zap.String("addr", s.serverAddr))
 
 	const maxHeaderBytes = 1 << 20
 	const readTimeout = 10 * time.Second
@@ -105,7 +114,32 @@ func (s *Server) Start() {
 		IdleTimeout:    idleTimeout,
 	}
 
-	log.Fatal(srv.ListenAndServe())
+	ln, err := net.Listen("tcp", s.serverAddr)
+	if err != nil {
+		s.app.Logger.Error("Failed to listen", zap.Error(err))
+		s.app.Lifecycle.Shutdown()
+		return
+	}
+
+	lifecycle := s.app.Lifecycle
+	lifecycle.OnShutdown("http server", srv.Shutdown)
+	quit := lifecycle.Signals()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+	lifecycle.SetReady()
+
+	select {
+	case sig := <-quit:
+		s.app.Logger.Info("Received signal", zap.String("signal", sig.String()))
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			s.app.Logger.Error("HTTP server stopped unexpectedly", zap.Error(err))
+		}
+	}
+	lifecycle.Shutdown()
 }
 
 func (s *Server) Router() chi.Router {
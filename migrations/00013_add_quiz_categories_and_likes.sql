@@ -0,0 +1,34 @@
+-- +goose Up
+-- Add categories to quizzes (a quiz may cover several categories)
+ALTER TABLE quizzes ADD COLUMN categories category[] NOT NULL DEFAULT '{}';
+-- 快速读取用的冗余统计
+ALTER TABLE quizzes ADD COLUMN likes_count INTEGER NOT NULL DEFAULT 0;
+
+-- Create quiz likes table
+CREATE TABLE quiz_likes (
+    id BIGSERIAL PRIMARY KEY,
+
+    quiz_id BIGINT NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    value SMALLINT NOT NULL, -- 1 = like, -1 = dislike
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (quiz_id, user_id)
+);
+
+CREATE INDEX idx_quizzes_categories ON quizzes USING GIN(categories);
+CREATE INDEX idx_quiz_likes_quiz ON quiz_likes(quiz_id);
+CREATE INDEX idx_quiz_likes_user ON quiz_likes(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_quiz_likes_user;
+DROP INDEX IF EXISTS idx_quiz_likes_quiz;
+DROP INDEX IF EXISTS idx_quizzes_categories;
+
+DROP TABLE IF EXISTS quiz_likes;
+
+ALTER TABLE quizzes DROP COLUMN IF EXISTS likes_count;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS categories;
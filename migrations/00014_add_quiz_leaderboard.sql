@@ -0,0 +1,19 @@
+-- +goose Up
+-- 记录每道题的判分结果，统计和回顾时不再重新判分
+ALTER TABLE quiz_answers ADD COLUMN is_correct BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE quiz_answers ADD COLUMN points INTEGER NOT NULL DEFAULT 0;
+
+-- 是否在测验排行榜中显示用户信息
+ALTER TABLE user_privacies ADD COLUMN leaderboard_visibility SMALLINT NOT NULL DEFAULT 1;
+COMMENT ON COLUMN user_privacies.leaderboard_visibility IS 'Visibility: 0=private, 1=public, 2=friends';
+
+-- 排行榜：按测验取每个用户的最佳成绩
+CREATE INDEX idx_quiz_attempts_quiz_user_score ON quiz_attempts(quiz_id, user_id, total_score DESC, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_quiz_attempts_quiz_user_score;
+
+ALTER TABLE user_privacies DROP COLUMN IF EXISTS leaderboard_visibility;
+
+ALTER TABLE quiz_answers DROP COLUMN IF EXISTS points;
+ALTER TABLE quiz_answers DROP COLUMN IF EXISTS is_correct;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- 每次作答打乱题目和选项顺序
+ALTER TABLE quizzes ADD COLUMN shuffle BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 打乱用的随机种子，NULL 表示未打乱
+ALTER TABLE quiz_attempts ADD COLUMN shuffle_seed BIGINT;
+
+-- 记录用户看到的顺序，回顾时按原样重放
+ALTER TABLE quiz_answers ADD COLUMN display_order INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE quiz_answers ADD COLUMN option_order BIGINT[];
+
+-- +goose Down
+ALTER TABLE quiz_answers DROP COLUMN IF EXISTS option_order;
+ALTER TABLE quiz_answers DROP COLUMN IF EXISTS display_order;
+
+ALTER TABLE quiz_attempts DROP COLUMN IF EXISTS shuffle_seed;
+
+ALTER TABLE quizzes DROP COLUMN IF EXISTS shuffle;
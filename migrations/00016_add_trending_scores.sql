@@ -0,0 +1,23 @@
+-- +goose Up
+-- 热度分数，由定时任务按时间衰减重新计算
+ALTER TABLE quizzes ADD COLUMN trending_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE polls ADD COLUMN trending_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_quizzes_trending_score ON quizzes(trending_score DESC);
+CREATE INDEX idx_polls_trending_score ON polls(trending_score DESC);
+
+-- 热度统计按时间窗口过滤
+CREATE INDEX idx_user_votes_created_at ON user_votes(created_at);
+CREATE INDEX idx_quiz_likes_updated_at ON quiz_likes(updated_at);
+CREATE INDEX idx_poll_likes_updated_at ON poll_likes(updated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_poll_likes_updated_at;
+DROP INDEX IF EXISTS idx_quiz_likes_updated_at;
+DROP INDEX IF EXISTS idx_user_votes_created_at;
+
+DROP INDEX IF EXISTS idx_polls_trending_score;
+DROP INDEX IF EXISTS idx_quizzes_trending_score;
+
+ALTER TABLE polls DROP COLUMN IF EXISTS trending_score;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS trending_score;
@@ -0,0 +1,77 @@
+-- +goose Up
+-- 题目修订历史：每次编辑生成一条不可变快照
+CREATE TABLE question_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    question_id BIGINT NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL, -- 从 1 开始递增
+    snapshot JSONB NOT NULL, -- 与 CreateQuestionRequest 结构一致，包含选项及正确答案
+    created_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(question_id, revision)
+);
+
+ALTER TABLE questions ADD COLUMN current_revision INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE questions ADD COLUMN updated_at TIMESTAMPTZ;
+
+-- 编辑时删除的选项保留下来，已有提交仍可关联
+ALTER TABLE question_options ADD COLUMN removed_at TIMESTAMPTZ;
+
+-- 提交时作答的修订版本
+ALTER TABLE question_submissions ADD COLUMN revision_id BIGINT REFERENCES question_revisions(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_question_submissions_revision_id ON question_submissions(revision_id);
+
+-- 为已有题目生成第 1 版快照
+INSERT INTO question_revisions (question_id, revision, snapshot, created_by, created_at)
+SELECT
+    q.id,
+    1,
+    jsonb_build_object(
+        'question_type', q.question_type,
+        'category', q.category,
+        'difficulty', q.difficulty,
+        'public', q.public,
+        'question_text', COALESCE(
+            (SELECT jsonb_object_agg(t.language, t.question_text)
+             FROM question_translations t WHERE t.question_id = q.id),
+            '{}'::jsonb
+        ),
+        'explanation', COALESCE(
+            (SELECT jsonb_object_agg(t.language, t.explanation)
+             FROM question_translations t WHERE t.question_id = q.id AND t.explanation IS NOT NULL),
+            '{}'::jsonb
+        ),
+        'options', COALESCE(
+            (SELECT jsonb_agg(jsonb_build_object(
+                'id', o.option_uuid,
+                'option_type', o.option_type,
+                'media_url', o.img_url,
+                'is_answer', o.is_answer,
+                'text', COALESCE(
+                    (SELECT jsonb_object_agg(ot.language, ot.option_text)
+                     FROM question_option_translations ot WHERE ot.option_id = o.id),
+                    '{}'::jsonb
+                )
+            ) ORDER BY o.id)
+             FROM question_options o WHERE o.question_id = q.id),
+            '[]'::jsonb
+        )
+    ),
+    q.created_by,
+    q.created_at
+FROM questions q;
+
+UPDATE question_submissions s
+SET revision_id = r.id
+FROM question_revisions r
+WHERE r.question_id = s.question_id AND r.revision = 1;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_question_submissions_revision_id;
+
+ALTER TABLE question_submissions DROP COLUMN IF EXISTS revision_id;
+ALTER TABLE question_options DROP COLUMN IF EXISTS removed_at;
+ALTER TABLE questions DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE questions DROP COLUMN IF EXISTS current_revision;
+
+DROP TABLE IF EXISTS question_revisions;
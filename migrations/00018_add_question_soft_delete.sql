@@ -0,0 +1,12 @@
+-- +goose Up
+-- 题目软删除：保留已有测验引用和提交记录
+ALTER TABLE questions ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE questions ADD COLUMN deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_questions_deleted_at ON questions(deleted_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_questions_deleted_at;
+
+ALTER TABLE questions DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE questions DROP COLUMN IF EXISTS deleted_at;
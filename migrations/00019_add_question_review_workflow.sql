@@ -0,0 +1,28 @@
+-- +goose Up
+-- 题目状态：草稿 -> 待审核 -> 通过（定时发布）/ 已发布，或被驳回
+CREATE TYPE question_status AS ENUM ('draft', 'pending_review', 'approved', 'published', 'rejected');
+
+ALTER TABLE questions ADD COLUMN status question_status NOT NULL DEFAULT 'draft';
+ALTER TABLE questions ADD COLUMN submitted_at TIMESTAMPTZ; -- 提交审核时间
+ALTER TABLE questions ADD COLUMN reviewed_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE questions ADD COLUMN reviewed_at TIMESTAMPTZ;
+ALTER TABLE questions ADD COLUMN review_reason TEXT; -- 驳回原因
+
+-- 已有的已发布题目直接视为已发布
+UPDATE questions SET status = 'published' WHERE is_published;
+
+-- 审核队列和定时发布
+CREATE INDEX idx_questions_status_submitted_at ON questions(status, submitted_at);
+CREATE INDEX idx_questions_status_published_at ON questions(status, published_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_questions_status_published_at;
+DROP INDEX IF EXISTS idx_questions_status_submitted_at;
+
+ALTER TABLE questions DROP COLUMN IF EXISTS review_reason;
+ALTER TABLE questions DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE questions DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE questions DROP COLUMN IF EXISTS submitted_at;
+ALTER TABLE questions DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS question_status;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- 评论对外使用 UUID，支持楼中楼回复
+ALTER TABLE question_comments ADD COLUMN comment_uuid UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE question_comments ADD COLUMN parent_id BIGINT REFERENCES question_comments(id) ON DELETE CASCADE;
+ALTER TABLE question_comments ADD COLUMN root_id BIGINT REFERENCES question_comments(id) ON DELETE CASCADE; -- 所属楼层，顶层评论为空
+ALTER TABLE question_comments ADD COLUMN is_spoiler BOOLEAN NOT NULL DEFAULT FALSE; -- 包含答案，未解答的用户不可见
+ALTER TABLE question_comments ADD COLUMN deleted_at TIMESTAMPTZ; -- 删除后保留楼层，内容不再展示
+ALTER TABLE question_comments ADD COLUMN deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_question_comments_uuid ON question_comments(comment_uuid);
+CREATE INDEX idx_question_comments_question_root ON question_comments(question_id, root_id, created_at);
+CREATE INDEX idx_question_comments_root_id ON question_comments(root_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_question_comments_root_id;
+DROP INDEX IF EXISTS idx_question_comments_question_root;
+DROP INDEX IF EXISTS idx_question_comments_uuid;
+
+ALTER TABLE question_comments DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE question_comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE question_comments DROP COLUMN IF EXISTS is_spoiler;
+ALTER TABLE question_comments DROP COLUMN IF EXISTS root_id;
+ALTER TABLE question_comments DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE question_comments DROP COLUMN IF EXISTS comment_uuid;
@@ -0,0 +1,38 @@
+-- +goose Up
+-- 删除评论改为软删除，回复保留并显示为 [deleted]，不再随上级评论级联删除
+ALTER TABLE poll_comments DROP CONSTRAINT IF EXISTS poll_comments_parent_id_fkey;
+ALTER TABLE poll_comments ADD CONSTRAINT poll_comments_parent_id_fkey
+    FOREIGN KEY (parent_id) REFERENCES poll_comments(id) ON DELETE SET NULL;
+
+ALTER TABLE poll_comments ADD COLUMN root_id BIGINT REFERENCES poll_comments(id) ON DELETE SET NULL; -- 所属楼层，顶层评论为空
+ALTER TABLE poll_comments ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE poll_comments ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE poll_comments ADD COLUMN deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+UPDATE poll_comments SET updated_at = created_at;
+
+-- 已有回复补全所属楼层
+WITH RECURSIVE threads AS (
+    SELECT id, id AS root FROM poll_comments WHERE parent_id IS NULL
+    UNION ALL
+    SELECT c.id, t.root FROM poll_comments c INNER JOIN threads t ON c.parent_id = t.id
+)
+UPDATE poll_comments p SET root_id = t.root FROM threads t WHERE p.id = t.id AND t.root <> p.id;
+
+CREATE INDEX idx_poll_comments_poll_root ON poll_comments(poll_id, root_id, created_at);
+CREATE INDEX idx_poll_comments_root_id ON poll_comments(root_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_poll_comments_root_id;
+DROP INDEX IF EXISTS idx_poll_comments_poll_root;
+
+DELETE FROM poll_comments WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE poll_comments DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE poll_comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE poll_comments DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE poll_comments DROP COLUMN IF EXISTS root_id;
+
+ALTER TABLE poll_comments DROP CONSTRAINT IF EXISTS poll_comments_parent_id_fkey;
+ALTER TABLE poll_comments ADD CONSTRAINT poll_comments_parent_id_fkey
+    FOREIGN KEY (parent_id) REFERENCES poll_comments(id) ON DELETE CASCADE;
@@ -0,0 +1,74 @@
+-- +goose Up
+CREATE TYPE report_target_type AS ENUM ('question', 'poll', 'question_comment', 'poll_comment', 'user');
+CREATE TYPE report_reason AS ENUM ('spam', 'offensive', 'wrong_answer', 'spoiler', 'copyright', 'other');
+CREATE TYPE report_status AS ENUM ('open', 'dismissed', 'resolved');
+CREATE TYPE moderation_action AS ENUM ('dismiss', 'hide', 'suspend');
+
+-- 同一对象的未处理举报合并为一条，每次举报记录在 report_entries
+CREATE TABLE reports (
+    id BIGSERIAL PRIMARY KEY,
+    report_uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    target_type report_target_type NOT NULL,
+    target_id BIGINT NOT NULL,
+    target_uuid UUID NOT NULL,
+    target_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL, -- 被举报内容的作者
+    status report_status NOT NULL DEFAULT 'open',
+    report_count INT NOT NULL DEFAULT 1,
+    resolved_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE report_entries (
+    id BIGSERIAL PRIMARY KEY,
+    report_id BIGINT NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    reporter_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason report_reason NOT NULL,
+    details TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (report_id, reporter_id)
+);
+
+-- 审核操作日志
+CREATE TABLE moderation_logs (
+    id BIGSERIAL PRIMARY KEY,
+    moderator_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    action moderation_action NOT NULL,
+    report_id BIGINT REFERENCES reports(id) ON DELETE SET NULL,
+    target_type report_target_type NOT NULL,
+    target_id BIGINT NOT NULL,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 被隐藏的投票不再出现在列表和详情中
+ALTER TABLE polls ADD COLUMN hidden_at TIMESTAMPTZ;
+ALTER TABLE polls ADD COLUMN hidden_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_reports_uuid ON reports(report_uuid);
+CREATE UNIQUE INDEX idx_reports_open_target ON reports(target_type, target_id) WHERE status = 'open';
+CREATE INDEX idx_reports_status_count ON reports(status, report_count DESC, created_at);
+CREATE INDEX idx_report_entries_reporter_id ON report_entries(reporter_id);
+CREATE INDEX idx_moderation_logs_target ON moderation_logs(target_type, target_id);
+CREATE INDEX idx_moderation_logs_moderator_id ON moderation_logs(moderator_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_moderation_logs_moderator_id;
+DROP INDEX IF EXISTS idx_moderation_logs_target;
+DROP INDEX IF EXISTS idx_report_entries_reporter_id;
+DROP INDEX IF EXISTS idx_reports_status_count;
+DROP INDEX IF EXISTS idx_reports_open_target;
+DROP INDEX IF EXISTS idx_reports_uuid;
+
+ALTER TABLE polls DROP COLUMN IF EXISTS hidden_by;
+ALTER TABLE polls DROP COLUMN IF EXISTS hidden_at;
+
+DROP TABLE IF EXISTS moderation_logs;
+DROP TABLE IF EXISTS report_entries;
+DROP TABLE IF EXISTS reports;
+
+DROP TYPE IF EXISTS moderation_action;
+DROP TYPE IF EXISTS report_status;
+DROP TYPE IF EXISTS report_reason;
+DROP TYPE IF EXISTS report_target_type;
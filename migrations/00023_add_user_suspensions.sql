@@ -0,0 +1,17 @@
+-- +goose Up
+-- 封禁期限和原因，suspended_until 为空表示永久封禁
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN suspension_reason TEXT;
+
+-- 解除封禁同样记录在审核日志
+ALTER TYPE moderation_action ADD VALUE IF NOT EXISTS 'unsuspend';
+
+-- 定时解除到期的封禁
+CREATE INDEX idx_users_suspended_until ON users(suspended_until) WHERE status = 1;
+
+-- +goose Down
+-- 枚举值无法删除，moderation_action 保留 unsuspend
+DROP INDEX IF EXISTS idx_users_suspended_until;
+
+ALTER TABLE users DROP COLUMN IF EXISTS suspension_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
@@ -0,0 +1,49 @@
+-- +goose Up
+-- 注销申请，冷静期结束后匿名化
+ALTER TABLE users ADD COLUMN deletion_requested_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN deletion_scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL;
+
+-- 占位账号：注销用户保留的题目和投票归到该账号名下
+INSERT INTO users (user_uuid, email, nickname, created_at, updated_at)
+VALUES ('00000000-0000-0000-0000-000000000000', 'deleted-user@deleted.invalid', '已注销用户', now(), now())
+ON CONFLICT DO NOTHING;
+
+-- 个人数据导出
+CREATE TYPE data_export_format AS ENUM ('json', 'zip');
+CREATE TYPE data_export_status AS ENUM ('pending', 'completed', 'failed');
+
+CREATE TABLE user_data_exports (
+    id BIGSERIAL PRIMARY KEY,
+    export_uuid UUID NOT NULL UNIQUE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    format data_export_format NOT NULL,
+    status data_export_status NOT NULL DEFAULT 'pending',
+    data BYTEA, -- 导出文件内容
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ -- 过期后由定时任务清理
+);
+
+CREATE INDEX idx_user_data_exports_user_id ON user_data_exports(user_id, created_at DESC);
+CREATE INDEX idx_user_data_exports_status ON user_data_exports(status, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_data_exports_status;
+DROP INDEX IF EXISTS idx_user_data_exports_user_id;
+DROP TABLE IF EXISTS user_data_exports;
+DROP TYPE IF EXISTS data_export_status;
+DROP TYPE IF EXISTS data_export_format;
+
+-- 占位账号名下已有保留的题目或投票时不删除（外键级联会一并删除这些内容），重新执行 Up 时 ON CONFLICT 跳过
+DELETE FROM users u
+WHERE u.user_uuid = '00000000-0000-0000-0000-000000000000'
+  AND NOT EXISTS (SELECT 1 FROM questions q WHERE q.created_by = u.id)
+  AND NOT EXISTS (SELECT 1 FROM polls p WHERE p.created_by = u.id);
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_requested_at;
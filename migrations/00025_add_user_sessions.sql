@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TYPE session_revoke_reason AS ENUM ('logout', 'revoked', 'password_changed', 'token_reuse');
+
+-- 登录会话，一次登录对应一个会话，刷新令牌在会话内轮换
+CREATE TABLE user_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    session_uuid UUID NOT NULL UNIQUE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    login_log_id BIGINT REFERENCES user_login_logs(id) ON DELETE SET NULL, -- 登录时的 IP 和 User-Agent
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    revoke_reason session_revoke_reason
+);
+
+CREATE INDEX idx_user_sessions_user_id ON user_sessions(user_id, created_at DESC);
+CREATE INDEX idx_user_sessions_expires_at ON user_sessions(expires_at);
+
+-- 刷新令牌只保存哈希，轮换后保留记录用于检测重放
+CREATE TABLE user_refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    session_id BIGINT NOT NULL REFERENCES user_sessions(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    used_at TIMESTAMPTZ -- 已轮换，再次使用视为令牌泄露
+);
+
+CREATE INDEX idx_user_refresh_tokens_session_id ON user_refresh_tokens(session_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_refresh_tokens_session_id;
+DROP TABLE IF EXISTS user_refresh_tokens;
+DROP INDEX IF EXISTS idx_user_sessions_expires_at;
+DROP INDEX IF EXISTS idx_user_sessions_user_id;
+DROP TABLE IF EXISTS user_sessions;
+DROP TYPE IF EXISTS session_revoke_reason;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- 第三方登录授权请求，回调时校验 state 并取回 PKCE code_verifier
+CREATE TABLE oauth_states (
+    id BIGSERIAL PRIMARY KEY,
+    state_hash VARCHAR(64) NOT NULL UNIQUE, -- state 的 SHA-256
+    provider VARCHAR(32) NOT NULL,
+    code_verifier TEXT NOT NULL,
+    nonce TEXT NOT NULL,
+    link_user_id BIGINT REFERENCES users(id) ON DELETE CASCADE, -- 已登录用户绑定第三方账号时设置
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_oauth_states_expires_at ON oauth_states(expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_oauth_states_expires_at;
+DROP TABLE IF EXISTS oauth_states;
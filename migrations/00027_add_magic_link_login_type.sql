@@ -0,0 +1,5 @@
+-- +goose Up
+COMMENT ON COLUMN user_login_logs.credential_type IS 'Login provider: 0=password, 1=google, 2=apple, 3=github, 4=magic_link';
+
+-- +goose Down
+COMMENT ON COLUMN user_login_logs.credential_type IS 'Login provider: 0=password, 1=google, 2=apple, 3=github';
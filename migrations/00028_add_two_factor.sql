@@ -0,0 +1,34 @@
+-- +goose Up
+-- TOTP 两步验证，确认前 enabled_at 为空，不影响登录
+CREATE TABLE user_two_factor (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,  -- base32 编码的 TOTP 密钥
+    enabled_at TIMESTAMPTZ,
+    last_used_step BIGINT,        -- 最近一次通过验证的时间步，防止验证码重放
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- 一次性恢复码，只存哈希
+CREATE TABLE user_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_user_recovery_codes_user_id ON user_recovery_codes(user_id);
+
+ALTER TABLE user_login_logs ADD COLUMN second_factor SMALLINT;
+COMMENT ON COLUMN user_login_logs.second_factor IS 'Second factor: NULL=none, 0=totp, 1=recovery_code';
+
+-- 会话是否通过了两步验证，强制管理人员两步验证时据此判断管理权限
+ALTER TABLE user_sessions ADD COLUMN two_factor_verified BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE user_sessions DROP COLUMN IF EXISTS two_factor_verified;
+ALTER TABLE user_login_logs DROP COLUMN IF EXISTS second_factor;
+DROP INDEX IF EXISTS idx_user_recovery_codes_user_id;
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- 判分时的正确选项，编辑题目修改答案后回顾仍与当时的判分一致
+ALTER TABLE quiz_answers ADD COLUMN correct_option_ids BIGINT[];
+
+-- 已有作答按当前答案回填
+UPDATE quiz_answers a
+SET correct_option_ids = COALESCE(
+    (SELECT array_agg(o.id ORDER BY o.id)
+     FROM question_options o
+     WHERE o.question_id = a.question_id AND o.is_answer AND o.removed_at IS NULL),
+    '{}'
+);
+
+-- +goose Down
+ALTER TABLE quiz_answers DROP COLUMN IF EXISTS correct_option_ids;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- 投票评论的表情回应，每个用户对同一评论的每种表情只能回应一次
+CREATE TABLE poll_comment_reactions (
+    comment_id BIGINT NOT NULL REFERENCES poll_comments(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reaction VARCHAR(16) NOT NULL, -- like, heart, laugh, wow, sad
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (comment_id, user_id, reaction)
+);
+
+CREATE INDEX idx_poll_comment_reactions_user_id ON poll_comment_reactions(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_poll_comment_reactions_user_id;
+DROP TABLE IF EXISTS poll_comment_reactions;